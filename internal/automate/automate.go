@@ -0,0 +1,150 @@
+// Package automate plans changes to project items from rules in a configuration file.
+package automate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Config contains rules that are run in order.
+type Config struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Rule takes actions on items that match a selector.
+type Rule struct {
+	Name string   `yaml:"name"`
+	If   Selector `yaml:"if"`
+	Then Actions  `yaml:"then"`
+
+	filter *filter.Filter
+}
+
+// Selector selects items that match all specified conditions.
+type Selector struct {
+	// Filter is a project view filter e.g., "-status:Done label:bug".
+	Filter string `yaml:"filter"`
+
+	// State is "open", "closed", "merged", or "draft".
+	State string `yaml:"state"`
+
+	// Labels must all be applied to the issue or pull request.
+	Labels []string `yaml:"labels"`
+
+	// Repository is the name with owner of the issue or pull request, which may contain "*" wildcards.
+	Repository string `yaml:"repository"`
+
+	// Fields are field names with values that must match, which may contain "*" wildcards.
+	Fields map[string]string `yaml:"fields"`
+
+	// Age is how long ago the issue or pull request was created e.g., "30d", "4w", "6m", or "1y".
+	Age string `yaml:"age"`
+
+	// Inactive is how long ago the issue or pull request was last updated e.g., "14d".
+	Inactive string `yaml:"inactive"`
+}
+
+// Actions are taken in the order defined on each item that matches a rule.
+type Actions struct {
+	Set       map[string]string `yaml:"set"`
+	Clear     []string          `yaml:"clear"`
+	AddLabels []string          `yaml:"add-labels"`
+	Comment   string            `yaml:"comment"`
+	Archive   bool              `yaml:"archive"`
+	Remove    bool              `yaml:"remove"`
+}
+
+var durationPattern = regexp.MustCompile(`^\d+[dwmy]$`)
+
+// Load reads and validates rules.
+func Load(r io.Reader) (*Config, error) {
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+
+	var config Config
+	if err := dec.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid rules: %w", err)
+	}
+
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("no rules defined")
+	}
+
+	for i, rule := range config.Rules {
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return &config, nil
+}
+
+func (r *Rule) compile() (err error) {
+	r.filter, err = filter.Parse(r.If.Filter)
+	if err != nil {
+		return
+	}
+
+	switch state := strings.ToLower(r.If.State); state {
+	case "":
+	case "open", "closed", "merged", "draft":
+		r.filter.And("is", state)
+	default:
+		return fmt.Errorf("invalid state %q; must be open, closed, merged, or draft", r.If.State)
+	}
+
+	for _, label := range r.If.Labels {
+		r.filter.And("label", label)
+	}
+
+	if r.If.Repository != "" {
+		r.filter.And("repo", r.If.Repository)
+	}
+
+	// Sort field names so filters are deterministic.
+	names := make([]string, 0, len(r.If.Fields))
+	for name := range r.If.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r.filter.And(name, r.If.Fields[name])
+	}
+
+	for _, d := range []struct{ key, value string }{
+		{"created", r.If.Age},
+		{"updated", r.If.Inactive},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if !durationPattern.MatchString(d.value) {
+			return fmt.Errorf("invalid duration %q; must be a number of days, weeks, months, or years e.g., 30d", d.value)
+		}
+		r.filter.And(d.key, "<@today-"+d.value)
+	}
+
+	a := r.Then
+	if len(a.Set) == 0 && len(a.Clear) == 0 && len(a.AddLabels) == 0 && a.Comment == "" && !a.Archive && !a.Remove {
+		return fmt.Errorf("no actions defined")
+	}
+
+	return nil
+}
+
+// Match gets whether the item matches the rule.
+func (r *Rule) Match(item models.ProjectItem, env filter.Env) bool {
+	return r.filter.Match(item, env)
+}
@@ -0,0 +1,208 @@
+package automate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {
+			"id": "I_1",
+			"number": 1,
+			"title": "Fix the parser",
+			"state": "OPEN",
+			"createdAt": "2026-08-01T00:00:00Z",
+			"updatedAt": "2026-08-01T00:00:00Z",
+			"labels": {"nodes": [{"name": "bug"}]},
+			"repository": {"nameWithOwner": "heaths/gh-projects"}
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+				{"title": "Iteration 1", "startDate": "2026-07-27", "duration": 14, "field": {"name": "Iteration", "dataType": "ITERATION"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_2",
+		"type": "PULL_REQUEST",
+		"content": {
+			"id": "PR_2",
+			"number": 2,
+			"title": "Add a feature",
+			"state": "MERGED",
+			"createdAt": "2026-10-01T00:00:00Z",
+			"updatedAt": "2026-10-10T00:00:00Z",
+			"repository": {"nameWithOwner": "heaths/gh-projects"}
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_3",
+		"type": "PULL_REQUEST",
+		"content": {
+			"id": "PR_3",
+			"number": 3,
+			"title": "Write docs",
+			"state": "MERGED",
+			"createdAt": "2026-10-01T00:00:00Z",
+			"updatedAt": "2026-10-10T00:00:00Z",
+			"repository": {"nameWithOwner": "heaths/gh-projects"}
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_4",
+		"type": "DRAFT_ISSUE",
+		"content": {
+			"id": "DI_4",
+			"title": "Someday",
+			"createdAt": "2026-08-01T00:00:00Z",
+			"updatedAt": "2026-08-01T00:00:00Z"
+		},
+		"fieldValues": {
+			"nodes": []
+		}
+	},
+	{
+		"id": "PNI_5",
+		"type": "ISSUE",
+		"isArchived": true,
+		"content": {
+			"id": "I_5",
+			"number": 5,
+			"title": "Archived",
+			"state": "CLOSED",
+			"createdAt": "2026-08-01T00:00:00Z",
+			"updatedAt": "2026-08-01T00:00:00Z",
+			"repository": {"nameWithOwner": "heaths/gh-projects"}
+		},
+		"fieldValues": {
+			"nodes": []
+		}
+	}
+]`
+
+const rulesYAML = `
+rules:
+  - name: Done when merged
+    if:
+      state: merged
+    then:
+      set:
+        Status: Done
+  - name: Stale bugs
+    if:
+      labels: [bug]
+      repository: heaths/*
+      fields:
+        Status: In Progress
+      inactive: 30d
+    then:
+      clear: [Iteration]
+      add-labels: [bug, stale]
+      comment: Is this still in progress?
+  - if:
+      age: 8w
+    then:
+      archive: true
+`
+
+func TestLoad(t *testing.T) {
+	config, err := Load(strings.NewReader(rulesYAML))
+	require.NoError(t, err)
+	require.Len(t, config.Rules, 3)
+
+	assert.Equal(t, "is:merged", config.Rules[0].filter.String())
+	assert.Equal(t, `label:bug repo:heaths/* status:"In Progress" updated:<@today-30d`, config.Rules[1].filter.String())
+	assert.Equal(t, "rule 3", config.Rules[2].Name)
+	assert.Equal(t, "created:<@today-8w", config.Rules[2].filter.String())
+}
+
+func TestLoad_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			yaml:    "",
+			wantErr: "no rules defined",
+		},
+		{
+			name:    "unknown key",
+			yaml:    "rules:\n  - name: test\n    when: {}\n",
+			wantErr: "invalid rules: yaml: unmarshal errors:\n  line 3: field when not found in type automate.Rule",
+		},
+		{
+			name:    "no actions",
+			yaml:    "rules:\n  - name: test\n    if:\n      state: open\n",
+			wantErr: `rule "test": no actions defined`,
+		},
+		{
+			name:    "invalid state",
+			yaml:    "rules:\n  - name: test\n    if:\n      state: draft issue\n    then:\n      archive: true\n",
+			wantErr: `rule "test": invalid state "draft issue"; must be open, closed, merged, or draft`,
+		},
+		{
+			name:    "invalid age",
+			yaml:    "rules:\n  - name: test\n    if:\n      age: 30 days\n    then:\n      archive: true\n",
+			wantErr: `rule "test": invalid duration "30 days"; must be a number of days, weeks, months, or years e.g., 30d`,
+		},
+		{
+			name:    "invalid filter",
+			yaml:    "rules:\n  - name: test\n    if:\n      filter: 'status:\"Todo'\n    then:\n      archive: true\n",
+			wantErr: `rule "test": unterminated quote in filter: status:"Todo`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.yaml))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPlan(t *testing.T) {
+	var items []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(itemsJSON), &items))
+
+	config, err := Load(strings.NewReader(rulesYAML))
+	require.NoError(t, err)
+
+	actions := Plan(config, items, filter.Env{Now: time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)})
+
+	got := make([]string, len(actions))
+	for i, action := range actions {
+		got[i] = action.Rule + ": " + action.Item.ID + " " + action.String()
+	}
+
+	assert.Equal(t, []string{
+		`Done when merged: PNI_2 set Status to "Done"`,
+		`Stale bugs: PNI_1 clear Iteration`,
+		`Stale bugs: PNI_1 add label "stale"`,
+		`Stale bugs: PNI_1 comment`,
+		`rule 3: PNI_1 archive`,
+		`rule 3: PNI_4 archive`,
+	}, got)
+}
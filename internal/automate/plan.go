@@ -0,0 +1,133 @@
+package automate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Kind is the kind of action.
+type Kind string
+
+// Kinds of actions.
+const (
+	ActionSet      Kind = "set"
+	ActionClear    Kind = "clear"
+	ActionAddLabel Kind = "add-label"
+	ActionComment  Kind = "comment"
+	ActionArchive  Kind = "archive"
+	ActionRemove   Kind = "remove"
+)
+
+// Action is a change to an item.
+type Action struct {
+	Rule  string
+	Kind  Kind
+	Item  models.ProjectItem
+	Field string
+	Value string
+}
+
+// String gets a description of the action.
+func (a Action) String() string {
+	switch a.Kind {
+	case ActionSet:
+		return fmt.Sprintf("set %s to %q", a.Field, a.Value)
+	case ActionClear:
+		return fmt.Sprintf("clear %s", a.Field)
+	case ActionAddLabel:
+		return fmt.Sprintf("add label %q", a.Value)
+	default:
+		return string(a.Kind)
+	}
+}
+
+// CommentMarker gets a hidden marker added to comments so a rule only comments once on an issue or pull request.
+func CommentMarker(rule string) string {
+	return fmt.Sprintf("<!-- gh-projects automate: %s -->", rule)
+}
+
+// Plan gets the actions each rule would take on matching items in order.
+//
+// Actions that would not change an item are omitted so rules can be run repeatedly. Rules match items
+// as they were before any actions are taken, and archived or removed items are not matched by later rules.
+// Comments cannot be checked without fetching them, so comment actions should be skipped if an issue or
+// pull request already has a comment containing the CommentMarker for the rule.
+func Plan(config *Config, items []models.ProjectItem, env filter.Env) []Action {
+	var actions []Action
+	gone := make(map[string]bool)
+
+	for _, rule := range config.Rules {
+		for _, item := range items {
+			if item.IsArchived || gone[item.ID] || !rule.Match(item, env) {
+				continue
+			}
+
+			add := func(kind Kind, field, value string) {
+				actions = append(actions, Action{
+					Rule:  rule.Name,
+					Kind:  kind,
+					Item:  item,
+					Field: field,
+					Value: value,
+				})
+			}
+
+			// Sort field names so actions are deterministic.
+			names := make([]string, 0, len(rule.Then.Set))
+			for name := range rule.Then.Set {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				value := rule.Then.Set[name]
+				if current, ok := item.FieldValue(name); !ok || !strings.EqualFold(current.String(), value) {
+					add(ActionSet, name, value)
+				}
+			}
+
+			for _, name := range rule.Then.Clear {
+				if current, ok := item.FieldValue(name); ok && current.String() != "" {
+					add(ActionClear, name, "")
+				}
+			}
+
+			// Only issues and pull requests have labels and comments.
+			if item.Type != "DRAFT_ISSUE" {
+				labels := item.Content.Labels.Names()
+				for _, label := range rule.Then.AddLabels {
+					if !containsFold(labels, label) {
+						add(ActionAddLabel, "", label)
+					}
+				}
+
+				if rule.Then.Comment != "" {
+					add(ActionComment, "", rule.Then.Comment)
+				}
+			}
+
+			if rule.Then.Remove {
+				add(ActionRemove, "", "")
+				gone[item.ID] = true
+			} else if rule.Then.Archive {
+				add(ActionArchive, "", "")
+				gone[item.ID] = true
+			}
+		}
+	}
+
+	return actions
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
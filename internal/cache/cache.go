@@ -0,0 +1,295 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/pkg/config"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+const (
+	// ProjectTTL is how long project IDs and URLs are cached.
+	ProjectTTL = 24 * time.Hour
+
+	// FieldsTTL is how long field definitions are cached.
+	FieldsTTL = time.Hour
+
+	// ItemsTTL is how long maps of issue and pull request numbers to project item IDs are cached.
+	ItemsTTL = 10 * time.Minute
+
+	// CompletionsTTL is how long shell completions are cached.
+	CompletionsTTL = 5 * time.Minute
+)
+
+// Cache stores project metadata on disk keyed by host, owner, and project number.
+// A nil *Cache is valid and caches nothing.
+type Cache struct {
+	dir string
+
+	// Test-only hooks.
+	now func() time.Time
+}
+
+// Project is cached metadata for a project. Expired sections are empty when loaded.
+type Project struct {
+	ID           string    `json:"id,omitempty"`
+	URL          string    `json:"url,omitempty"`
+	Repositories []string  `json:"repositories,omitempty"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+
+	Fields          []models.ProjectField `json:"fields,omitempty"`
+	FieldsUpdatedAt time.Time             `json:"fieldsUpdatedAt"`
+
+	Items          map[string]string `json:"items,omitempty"`
+	ItemsUpdatedAt time.Time         `json:"itemsUpdatedAt"`
+
+	mu sync.Mutex
+}
+
+// DefaultDir gets the directory under the gh config directory where projects are cached.
+func DefaultDir() string {
+	return filepath.Join(config.ConfigDir(), "gh-projects", "cache")
+}
+
+// New creates a Cache that stores files under dir.
+func New(dir string) *Cache {
+	return &Cache{
+		dir: dir,
+		now: time.Now,
+	}
+}
+
+// Load gets the cached project or an empty project if not cached or disabled.
+func (c *Cache) Load(host, owner string, number int) *Project {
+	p := &Project{}
+	if c == nil {
+		return p
+	}
+
+	data, err := os.ReadFile(c.path(host, owner, number))
+	if err != nil {
+		return p
+	}
+
+	// Treat a corrupt cache as empty; it will be overwritten when saved.
+	if err := json.Unmarshal(data, p); err != nil {
+		return &Project{}
+	}
+
+	now := c.now()
+	if now.Sub(p.UpdatedAt) > ProjectTTL {
+		p.ID, p.URL, p.Repositories = "", "", nil
+	}
+	if now.Sub(p.FieldsUpdatedAt) > FieldsTTL {
+		p.Fields = nil
+	}
+	if now.Sub(p.ItemsUpdatedAt) > ItemsTTL {
+		p.Items = nil
+	}
+
+	return p
+}
+
+// Save writes the project to the cache.
+func (c *Cache) Save(host, owner string, number int, p *Project) error {
+	if c == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	data, err := json.Marshal(p)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	path := c.path(host, owner, number)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Remove removes a cached project.
+func (c *Cache) Remove(host, owner string, number int) error {
+	if c == nil {
+		return nil
+	}
+
+	err := os.Remove(c.path(host, owner, number))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// Clear removes all cached projects.
+func (c *Cache) Clear() error {
+	if c == nil {
+		return nil
+	}
+
+	return os.RemoveAll(c.dir)
+}
+
+type completions struct {
+	Values    []string  `json:"values"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// LoadCompletions gets cached shell completions by name for an owner, or nil if not cached, expired, or disabled.
+func (c *Cache) LoadCompletions(host, owner, name string) []string {
+	if c == nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.completionsPath(host, owner, name))
+	if err != nil {
+		return nil
+	}
+
+	var v completions
+	if err := json.Unmarshal(data, &v); err != nil || c.now().Sub(v.UpdatedAt) > CompletionsTTL {
+		return nil
+	}
+
+	return v.Values
+}
+
+// SaveCompletions caches shell completions by name for an owner.
+func (c *Cache) SaveCompletions(host, owner, name string, values []string) error {
+	if c == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(completions{
+		Values:    values,
+		UpdatedAt: c.now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	path := c.completionsPath(host, owner, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+func (c *Cache) timeNow() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+
+	return c.now()
+}
+
+func (c *Cache) path(host, owner string, number int) string {
+	return filepath.Join(c.dir, strings.ToLower(host), strings.ToLower(owner), fmt.Sprintf("%d.json", number))
+}
+
+func (c *Cache) completionsPath(host, owner, name string) string {
+	return filepath.Join(c.dir, strings.ToLower(host), strings.ToLower(owner), "completions", strings.ToLower(name)+".json")
+}
+
+// SetProject caches the project ID and URL. Values are kept in memory even if caching is disabled.
+func (c *Cache) SetProject(p *Project, id, url string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ID != id {
+		p.Repositories = nil
+	}
+	p.ID = id
+	p.URL = url
+	p.UpdatedAt = c.timeNow()
+}
+
+// SetFields caches all field definitions for the project.
+func (c *Cache) SetFields(p *Project, fields []models.ProjectField) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Fields = fields
+	p.FieldsUpdatedAt = c.timeNow()
+}
+
+// SetItems caches all project item IDs indexed by ItemKey.
+func (c *Cache) SetItems(p *Project, items map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Items = items
+	p.ItemsUpdatedAt = c.timeNow()
+}
+
+// HasRepository gets whether the project is known to be linked to the repository.
+func (p *Project) HasRepository(repo string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, r := range p.Repositories {
+		if strings.EqualFold(r, repo) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AddRepository records that the project is linked to the repository.
+func (p *Project) AddRepository(repo string) {
+	if p.HasRepository(repo) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.Repositories = append(p.Repositories, repo)
+}
+
+// ItemKey gets the key for an issue or pull request number in a repository as OWNER/REPO.
+// Projects may contain items from many repositories, so numbers alone are not unique.
+func ItemKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", strings.ToLower(repo), number)
+}
+
+// Item gets a cached project item ID for an issue or pull request number in a repository.
+func (p *Project) Item(repo string, number int) (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	id, ok := p.Items[ItemKey(repo, number)]
+	return id, ok
+}
+
+// SetItem updates a cached project item ID if items are already cached, since a partial map cannot be trusted.
+func (p *Project) SetItem(repo string, number int, id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.Items != nil {
+		p.Items[ItemKey(repo, number)] = id
+	}
+}
+
+// RemoveItem removes a cached project item ID.
+func (p *Project) RemoveItem(repo string, number int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.Items, ItemKey(repo, number))
+}
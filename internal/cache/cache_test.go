@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	c := New(t.TempDir())
+	c.now = func() time.Time {
+		return now
+	}
+
+	p := c.Load("github.com", "heaths", 1)
+	assert.Empty(t, p.ID)
+	assert.Nil(t, p.Fields)
+	assert.Nil(t, p.Items)
+
+	c.SetProject(p, "PN_1", "https://github.com/users/heaths/projects/1")
+	p.AddRepository("heaths/gh-projects")
+	c.SetFields(p, []models.ProjectField{{ID: "PNF_Status", Name: "Status"}})
+	c.SetItems(p, map[string]string{
+		ItemKey("heaths/gh-projects", 1): "PNI_1",
+		ItemKey("heaths/gh-projects", 2): "PNI_2",
+		ItemKey("heaths/other", 2):       "PNI_4",
+	})
+	p.SetItem("heaths/gh-projects", 3, "PNI_3")
+	p.RemoveItem("heaths/gh-projects", 1)
+	assert.NoError(t, c.Save("github.com", "heaths", 1, p))
+
+	// Keys are case-insensitive.
+	p = c.Load("GitHub.com", "Heaths", 1)
+	assert.Equal(t, "PN_1", p.ID)
+	assert.Equal(t, "https://github.com/users/heaths/projects/1", p.URL)
+	assert.True(t, p.HasRepository("Heaths/GH-Projects"))
+	assert.False(t, p.HasRepository("heaths/other"))
+	assert.Equal(t, []models.ProjectField{{ID: "PNF_Status", Name: "Status"}}, p.Fields)
+	assert.Equal(t, map[string]string{"heaths/gh-projects#2": "PNI_2", "heaths/gh-projects#3": "PNI_3", "heaths/other#2": "PNI_4"}, p.Items)
+
+	// Items with the same number in different repositories are cached separately.
+	id, ok := p.Item("Heaths/GH-Projects", 2)
+	assert.True(t, ok)
+	assert.Equal(t, "PNI_2", id)
+	id, ok = p.Item("heaths/other", 2)
+	assert.True(t, ok)
+	assert.Equal(t, "PNI_4", id)
+	_, ok = p.Item("heaths/other", 3)
+	assert.False(t, ok)
+
+	// Items expire first, then fields, then the project.
+	now = now.Add(ItemsTTL + time.Second)
+	p = c.Load("github.com", "heaths", 1)
+	assert.Nil(t, p.Items)
+	assert.NotNil(t, p.Fields)
+
+	now = now.Add(FieldsTTL)
+	p = c.Load("github.com", "heaths", 1)
+	assert.Nil(t, p.Fields)
+	assert.Equal(t, "PN_1", p.ID)
+
+	now = now.Add(ProjectTTL)
+	p = c.Load("github.com", "heaths", 1)
+	assert.Empty(t, p.ID)
+	assert.Empty(t, p.Repositories)
+
+	// Other projects are cached separately.
+	assert.Empty(t, c.Load("github.com", "heaths", 2).ID)
+
+	assert.NoError(t, c.Remove("github.com", "heaths", 1))
+	assert.NoError(t, c.Remove("github.com", "heaths", 1))
+}
+
+func TestCache_completions(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	c := New(t.TempDir())
+	c.now = func() time.Time {
+		return now
+	}
+
+	assert.Nil(t, c.LoadCompletions("github.com", "heaths", "projects"))
+
+	values := []string{"1\tRoadmap", "2\tBacklog"}
+	assert.NoError(t, c.SaveCompletions("github.com", "heaths", "projects", values))
+	assert.Equal(t, values, c.LoadCompletions("GitHub.com", "Heaths", "projects"))
+	assert.Nil(t, c.LoadCompletions("github.com", "heaths", "issues-gh-projects"))
+
+	now = now.Add(CompletionsTTL + time.Second)
+	assert.Nil(t, c.LoadCompletions("github.com", "heaths", "projects"))
+}
+
+func TestCache_SetItem(t *testing.T) {
+	// Items are only updated if the full map was cached.
+	p := &Project{}
+	p.SetItem("heaths/gh-projects", 1, "PNI_1")
+	_, ok := p.Item("heaths/gh-projects", 1)
+	assert.False(t, ok)
+}
+
+func TestCache_Clear(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	c := New(dir)
+
+	p := c.Load("github.com", "heaths", 1)
+	c.SetProject(p, "PN_1", "https://github.com/users/heaths/projects/1")
+	assert.NoError(t, c.Save("github.com", "heaths", 1, p))
+	assert.Equal(t, "PN_1", c.Load("github.com", "heaths", 1).ID)
+
+	assert.NoError(t, c.Clear())
+	assert.Empty(t, c.Load("github.com", "heaths", 1).ID)
+}
+
+func TestCache_nil(t *testing.T) {
+	var c *Cache
+
+	p := c.Load("github.com", "heaths", 1)
+	c.SetProject(p, "PN_1", "https://github.com/users/heaths/projects/1")
+	assert.Equal(t, "PN_1", p.ID)
+	assert.NoError(t, c.Save("github.com", "heaths", 1, p))
+	assert.Empty(t, c.Load("github.com", "heaths", 1).ID)
+	assert.NoError(t, c.Remove("github.com", "heaths", 1))
+	assert.NoError(t, c.Clear())
+}
@@ -0,0 +1,384 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+)
+
+const (
+	DefaultMaxRetries int = 5
+
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Minute
+
+	// GitHub recommends waiting at least one minute after a secondary rate limit without a Retry-After header.
+	secondaryRateLimitBackoff = time.Minute
+)
+
+// Client wraps an api.GQLClient to retry requests that failed due to rate limits or transient server errors.
+// A single Client should be shared by all workers so that concurrency can be reduced when throttled.
+type Client struct {
+	client api.GQLClient
+	log    io.Writer
+
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+
+	limiter *limiter
+
+	mu   sync.Mutex
+	rate *RateLimit
+
+	// Test-only hooks.
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+	jitter func(time.Duration) time.Duration
+}
+
+// RateLimit is the rateLimit object that can be selected in any GraphQL query.
+type RateLimit struct {
+	Cost      int
+	Remaining int
+	ResetAt   time.Time
+}
+
+type Option func(*Client)
+
+// WithConcurrency sets the maximum number of concurrent requests.
+func WithConcurrency(n int) Option {
+	return func(c *Client) {
+		c.limiter = newLimiter(n)
+	}
+}
+
+// WithMaxRetries sets the maximum number of times a request is retried.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) {
+		c.maxRetries = n
+	}
+}
+
+// New creates a GraphQL client using gh configuration and wraps it with a Client.
+func New(opts *api.ClientOptions, options ...Option) (*Client, error) {
+	client, err := gh.GQLClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return Wrap(client, opts.Log, options...), nil
+}
+
+// Wrap wraps an existing api.GQLClient with a Client. Retries are logged to log if not nil.
+func Wrap(client api.GQLClient, log io.Writer, options ...Option) *Client {
+	c := &Client{
+		client:     client,
+		log:        log,
+		maxRetries: DefaultMaxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+		now:        time.Now,
+		sleep:      sleep,
+		jitter:     jitter,
+	}
+
+	for _, opt := range options {
+		opt(c)
+	}
+
+	if c.limiter == nil {
+		c.limiter = newLimiter(1)
+	}
+
+	return c
+}
+
+// Concurrency gets the current number of requests allowed to run concurrently.
+func (c *Client) Concurrency() int {
+	return c.limiter.current()
+}
+
+// RateLimit gets the last rateLimit selected in a query, or nil if none was selected.
+func (c *Client) RateLimit() *RateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rate == nil {
+		return nil
+	}
+
+	rate := *c.rate
+	return &rate
+}
+
+// Do wraps DoWithContext using context.Background.
+func (c *Client) Do(query string, variables map[string]interface{}, response interface{}) error {
+	return c.DoWithContext(context.Background(), query, variables, response)
+}
+
+// DoWithContext executes a GraphQL query request and retries it if rate limited.
+// If the query selects a rateLimit object, subsequent requests will wait for the limit to reset when exhausted.
+func (c *Client) DoWithContext(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	return c.retry(ctx, isMutation(query), func() error {
+		var data json.RawMessage
+		err := c.client.DoWithContext(ctx, query, variables, &data)
+		if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+			return err
+		}
+
+		c.updateRateLimit(data)
+
+		// Partial data may be returned with errors e.g., NOT_FOUND, which callers may still depend on.
+		if response != nil {
+			if jsonErr := json.Unmarshal(data, response); jsonErr != nil && err == nil {
+				err = jsonErr
+			}
+		}
+
+		return err
+	})
+}
+
+// Mutate wraps MutateWithContext using context.Background.
+func (c *Client) Mutate(name string, mutation interface{}, variables map[string]interface{}) error {
+	return c.MutateWithContext(context.Background(), name, mutation, variables)
+}
+
+// MutateWithContext executes a GraphQL mutation request and retries it if rate limited.
+func (c *Client) MutateWithContext(ctx context.Context, name string, mutation interface{}, variables map[string]interface{}) error {
+	return c.retry(ctx, true, func() error {
+		return c.client.MutateWithContext(ctx, name, mutation, variables)
+	})
+}
+
+// Query wraps QueryWithContext using context.Background.
+func (c *Client) Query(name string, query interface{}, variables map[string]interface{}) error {
+	return c.QueryWithContext(context.Background(), name, query, variables)
+}
+
+// QueryWithContext executes a GraphQL query request and retries it if rate limited.
+func (c *Client) QueryWithContext(ctx context.Context, name string, query interface{}, variables map[string]interface{}) error {
+	return c.retry(ctx, false, func() error {
+		return c.client.QueryWithContext(ctx, name, query, variables)
+	})
+}
+
+// retry calls fn until it succeeds or cannot be retried.
+// Mutations are only retried when throttled, since a server error may be returned after changes were made.
+func (c *Client) retry(ctx context.Context, mutation bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		if err := c.waitForReset(ctx); err != nil {
+			return err
+		}
+
+		c.limiter.acquire()
+		err := fn()
+		delay, retry, throttled := c.classify(err, attempt, mutation)
+		c.limiter.release(throttled)
+
+		if !retry {
+			return err
+		}
+
+		if attempt >= c.maxRetries {
+			if throttled {
+				return fmt.Errorf("rate limit exceeded after %d retries: %w", attempt, err)
+			}
+			return err
+		}
+
+		c.logf("Retrying in %s after error: %v\n", delay.Round(time.Millisecond), err)
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// classify determines whether an error can be retried, how long to wait, and whether the request was throttled.
+func (c *Client) classify(err error, attempt int, mutation bool) (delay time.Duration, retry, throttled bool) {
+	if err == nil {
+		return
+	}
+
+	var httpErr api.HTTPError
+	if errors.As(err, &httpErr) {
+		throttled = httpErr.StatusCode == http.StatusForbidden || httpErr.StatusCode == http.StatusTooManyRequests
+		if d, ok := retryAfter(httpErr.Headers); ok {
+			return d, true, throttled
+		}
+
+		if throttled {
+			if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+				if reset, ok := rateLimitReset(httpErr.Headers); ok {
+					return c.until(reset, attempt), true, true
+				}
+			}
+
+			if strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit") {
+				delay = c.backoff(attempt)
+				if delay < secondaryRateLimitBackoff {
+					delay = secondaryRateLimitBackoff
+				}
+				return delay, true, true
+			}
+
+			// Other forbidden responses e.g., insufficient permissions are not retried.
+			return 0, false, false
+		}
+
+		switch httpErr.StatusCode {
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			// A mutation may have been applied even if the response failed e.g., a comment was posted.
+			if mutation {
+				return
+			}
+			return c.backoff(attempt), true, false
+		}
+
+		return
+	}
+
+	var gqlErr api.GQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			if e.Type == "RATE_LIMITED" {
+				if rate := c.RateLimit(); rate != nil && !rate.ResetAt.IsZero() {
+					return c.until(rate.ResetAt, attempt), true, true
+				}
+				return c.backoff(attempt), true, true
+			}
+		}
+	}
+
+	return
+}
+
+// backoff returns an exponential delay with jitter for the given attempt.
+func (c *Client) backoff(attempt int) time.Duration {
+	d := c.minBackoff << attempt
+	if d <= 0 || d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+
+	return d + c.jitter(d)
+}
+
+// until returns the time until t, or a backoff delay if t has already passed.
+func (c *Client) until(t time.Time, attempt int) time.Duration {
+	if d := t.Sub(c.now()); d > 0 {
+		return d
+	}
+
+	return c.backoff(attempt)
+}
+
+// waitForReset waits until the rate limit resets if the last known limit was exhausted.
+func (c *Client) waitForReset(ctx context.Context) error {
+	rate := c.RateLimit()
+	if rate == nil || rate.Remaining > 0 || rate.ResetAt.IsZero() {
+		return nil
+	}
+
+	d := rate.ResetAt.Sub(c.now())
+	if d <= 0 {
+		return nil
+	}
+
+	c.logf("Rate limit exhausted; waiting %s until %s\n", d.Round(time.Second), rate.ResetAt.Format(time.RFC3339))
+	if err := c.sleep(ctx, d); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.rate = nil
+	c.mu.Unlock()
+
+	return nil
+}
+
+func (c *Client) updateRateLimit(data json.RawMessage) {
+	var body struct {
+		RateLimit *RateLimit
+	}
+
+	if err := json.Unmarshal(data, &body); err != nil || body.RateLimit == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.rate = body.RateLimit
+	c.mu.Unlock()
+}
+
+func (c *Client) logf(format string, a ...interface{}) {
+	if c.log != nil {
+		fmt.Fprintf(c.log, format, a...)
+	}
+}
+
+// isMutation gets whether a GraphQL document is a mutation.
+func isMutation(query string) bool {
+	return strings.HasPrefix(strings.TrimSpace(query), "mutation")
+}
+
+func retryAfter(headers http.Header) (time.Duration, bool) {
+	value := headers.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func rateLimitReset(headers http.Header) (time.Time, bool) {
+	value := headers.Get("X-RateLimit-Reset")
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	epoch, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(epoch, 0), true
+}
+
+// jitter returns up to 50% of d so concurrent workers don't retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	if half := int64(d / 2); half > 0 {
+		return time.Duration(rand.Int63n(half))
+	}
+
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,370 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestClient_Do(t *testing.T) {
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		query         string
+		mocks         func()
+		maxRetries    int
+		wantErr       string
+		wantSleeps    []time.Duration
+		wantID        string
+		wantRateLimit *RateLimit
+	}{
+		{
+			name: "success",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"rateLimit": {
+								"cost": 1,
+								"remaining": 4999,
+								"resetAt": "2022-08-01T13:00:00Z"
+							},
+							"node": {
+								"id": "I_1"
+							}
+						}
+					}`)
+			},
+			wantID: "I_1",
+			wantRateLimit: &RateLimit{
+				Cost:      1,
+				Remaining: 4999,
+				ResetAt:   time.Date(2022, 8, 1, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "retries bad gateway",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(502)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(503)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+			wantID:     "I_1",
+		},
+		{
+			name: "honors retry-after",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(403).
+					SetHeader("Retry-After", "30").
+					JSON(`{"message":"You have exceeded a secondary rate limit."}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{30 * time.Second},
+			wantID:     "I_1",
+		},
+		{
+			name: "secondary rate limit without retry-after",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(403).
+					JSON(`{"message":"You have exceeded a secondary rate limit."}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{time.Minute},
+			wantID:     "I_1",
+		},
+		{
+			name: "primary rate limit waits until reset",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(403).
+					SetHeader("X-RateLimit-Remaining", "0").
+					SetHeader("X-RateLimit-Reset", fmt.Sprint(now.Add(10*time.Minute).Unix())).
+					JSON(`{"message":"API rate limit exceeded."}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{10 * time.Minute},
+			wantID:     "I_1",
+		},
+		{
+			name: "retries RATE_LIMITED",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{
+						"data": null,
+						"errors": [
+							{
+								"type": "RATE_LIMITED",
+								"message": "API rate limit exceeded for user ID 1."
+							}
+						]
+					}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{time.Millisecond},
+			wantID:     "I_1",
+		},
+		{
+			name: "does not retry other errors",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"node": null
+						},
+						"errors": [
+							{
+								"type": "NOT_FOUND",
+								"message": "Could not resolve to a node with the global id of 'I_1'"
+							}
+						]
+					}`)
+			},
+			wantErr: "GraphQL: Could not resolve to a node with the global id of 'I_1'",
+		},
+		{
+			name: "does not retry forbidden",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(403).
+					JSON(`{"message":"Resource not accessible by integration"}`)
+			},
+			wantErr: "HTTP 403: Resource not accessible by integration (https://api.github.com/graphql)",
+		},
+		{
+			name:  "does not retry mutation on bad gateway",
+			query: `mutation { addComment(input: {subjectId: "I_1", body: "body"}) { clientMutationId } }`,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(502).
+					JSON(`{"message":"Server Error"}`)
+			},
+			wantErr: "HTTP 502: Server Error (https://api.github.com/graphql)",
+		},
+		{
+			name:  "retries throttled mutation",
+			query: `mutation { addComment(input: {subjectId: "I_1", body: "body"}) { clientMutationId } }`,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(403).
+					SetHeader("Retry-After", "30").
+					JSON(`{"message":"You have exceeded a secondary rate limit."}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":{"id":"I_1"}}}`)
+			},
+			wantSleeps: []time.Duration{30 * time.Second},
+			wantID:     "I_1",
+		},
+		{
+			name:       "gives up",
+			maxRetries: 1,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Times(2).
+					Reply(403).
+					SetHeader("Retry-After", "1").
+					JSON(`{"message":"You have exceeded a secondary rate limit."}`)
+			},
+			wantSleeps: []time.Duration{time.Second},
+			wantErr:    "rate limit exceeded after 1 retries: HTTP 403: You have exceeded a secondary rate limit. (https://api.github.com/graphql)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			options := []Option{}
+			if tt.maxRetries > 0 {
+				options = append(options, WithMaxRetries(tt.maxRetries))
+			}
+
+			client, sleeps := newTestClient(t, now, options...)
+
+			var data struct {
+				Node struct {
+					ID string
+				}
+			}
+			query := tt.query
+			if query == "" {
+				query = `query { node(id: "I_1") { id } }`
+			}
+
+			err := client.Do(query, nil, &data)
+
+			assert.True(t, gock.IsDone(), "%d pending mocks", len(gock.Pending()))
+			assert.Equal(t, tt.wantSleeps, *sleeps)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantID, data.Node.ID)
+			assert.Equal(t, tt.wantRateLimit, client.RateLimit())
+		})
+	}
+}
+
+func TestClient_waitForReset(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	now := time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"rateLimit": {
+					"cost": 1,
+					"remaining": 0,
+					"resetAt": "2022-08-01T12:05:00Z"
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		JSON(`{"data":{}}`)
+
+	client, sleeps := newTestClient(t, now)
+
+	assert.NoError(t, client.Do(`query { rateLimit { cost remaining resetAt } }`, nil, nil))
+	assert.Empty(t, *sleeps)
+
+	assert.NoError(t, client.Do(`query { viewer { id } }`, nil, nil))
+	assert.Equal(t, []time.Duration{5 * time.Minute}, *sleeps)
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_Concurrency(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Times(2).
+		Reply(403).
+		SetHeader("Retry-After", "1").
+		JSON(`{"message":"You have exceeded a secondary rate limit."}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Times(6).
+		Reply(200).
+		JSON(`{"data":{}}`)
+
+	client, _ := newTestClient(t, time.Now(), WithConcurrency(8))
+	assert.Equal(t, 8, client.Concurrency())
+
+	// Each throttled response halves concurrency.
+	assert.NoError(t, client.Do(`query { viewer { id } }`, nil, nil))
+	assert.Equal(t, 2, client.Concurrency())
+
+	// Successful responses slowly increase concurrency.
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, client.Do(`query { viewer { id } }`, nil, nil))
+	}
+	assert.Equal(t, 4, client.Concurrency())
+	assert.True(t, gock.IsDone())
+}
+
+func TestClient_canceled(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(502)
+
+	inner, err := testGQLClient()
+	assert.NoError(t, err)
+
+	client := Wrap(inner, nil)
+	client.minBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err = client.DoWithContext(ctx, `query { viewer { id } }`, nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func newTestClient(t *testing.T, now time.Time, options ...Option) (*Client, *[]time.Duration) {
+	inner, err := testGQLClient()
+	assert.NoError(t, err)
+
+	var log strings.Builder
+	client := Wrap(inner, &log, options...)
+
+	// Remove jitter from backoff so delays are deterministic.
+	client.minBackoff = time.Millisecond
+	client.jitter = func(time.Duration) time.Duration {
+		return 0
+	}
+
+	var sleeps []time.Duration
+	client.now = func() time.Time {
+		return now
+	}
+	client.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	return client, &sleeps
+}
+
+func testGQLClient() (api.GQLClient, error) {
+	return gh.GQLClient(&api.ClientOptions{
+		AuthToken: "***",
+		Host:      "github.com",
+	})
+}
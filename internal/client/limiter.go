@@ -0,0 +1,68 @@
+package client
+
+import "sync"
+
+// limiter bounds the number of concurrent requests. The limit is halved when requests are throttled
+// and increased by one after a number of consecutive successful requests equal to the current limit.
+type limiter struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	max       int
+	limit     int
+	active    int
+	successes int
+}
+
+func newLimiter(max int) *limiter {
+	if max < 1 {
+		max = 1
+	}
+
+	l := &limiter{
+		max:   max,
+		limit: max,
+	}
+	l.cond = sync.NewCond(&l.mu)
+
+	return l
+}
+
+func (l *limiter) acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.active >= l.limit {
+		l.cond.Wait()
+	}
+	l.active++
+}
+
+func (l *limiter) release(throttled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.active--
+	if throttled {
+		l.limit /= 2
+		if l.limit < 1 {
+			l.limit = 1
+		}
+		l.successes = 0
+	} else if l.limit < l.max {
+		l.successes++
+		if l.successes >= l.limit {
+			l.limit++
+			l.successes = 0
+		}
+	}
+
+	l.cond.Broadcast()
+}
+
+func (l *limiter) current() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.limit
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-projects/internal/config"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func NewAliasCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alias",
+		Short: "Manage project aliases",
+		Long: heredoc.Docf(`
+			Aliases are names for projects that can be passed to any command instead of a project number.
+			Projects are referenced as [HOST/]OWNER/NUMBER or a project URL, so aliases can refer to
+			projects of any owner on any host.
+
+			Aliases are stored in the user config file %[1]s, and take precedence over project titles.
+		`, "`"+config.UserPath()+"`"),
+		Example: heredoc.Doc(`
+			# name project 12 owned by acme
+			$ gh projects alias set roadmap acme/12
+
+			# view the project
+			$ gh projects view roadmap
+		`),
+		// Aliases do not require authentication or a repository,
+		// and invalid settings are only a warning so aliases can be changed.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			warnConfigError(globalOpts)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newAliasDeleteCmd(globalOpts))
+	cmd.AddCommand(newAliasListCmd(globalOpts))
+	cmd.AddCommand(newAliasSetCmd(globalOpts))
+
+	return cmd
+}
+
+func newAliasSetCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <name> <project>",
+		Short: "Create or change a project alias",
+		Long: heredoc.Doc(`
+			Create or change an alias for a project referenced as [HOST/]OWNER/NUMBER
+			or a project URL. The host is the default host for gh if not specified.
+		`),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var number int
+			if _, err := parseAlias(args[0], args[1], &number); err != nil {
+				return err
+			}
+
+			return updateUserConfig(globalOpts, func(c *config.Config) error {
+				return c.SetAlias(args[0], args[1])
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newAliasDeleteCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "delete <name>",
+		Short:             "Delete a project alias",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeAliases(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateUserConfig(globalOpts, func(c *config.Config) error {
+				if !c.DeleteAlias(args[0]) {
+					return fmt.Errorf("no such alias: %s", args[0])
+				}
+				return nil
+			})
+		},
+	}
+
+	return cmd
+}
+
+func newAliasListCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List project aliases",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var aliases []template.Alias
+			for _, alias := range globalOpts.config().AliasList() {
+				aliases = append(aliases, template.Alias{Name: alias.Key, Project: alias.Value})
+			}
+
+			if len(aliases) == 0 {
+				if globalOpts.Console.IsStderrTTY() {
+					fmt.Fprintln(globalOpts.Console.Stderr(), "No aliases. Create one with `gh projects alias set`.")
+				}
+				return nil
+			}
+
+			t, err := template.New(globalOpts.Console)
+			if err != nil {
+				return err
+			}
+
+			return t.Aliases(aliases)
+		},
+	}
+
+	return cmd
+}
+
+// updateUserConfig reads the user config file, calls update, and writes the file if update succeeds.
+func updateUserConfig(opts *GlobalOptions, update func(c *config.Config) error) error {
+	path, _ := configPaths(opts)
+	c, err := config.Read(path)
+	if err != nil {
+		return err
+	}
+
+	if err := update(c); err != nil {
+		return err
+	}
+
+	return c.Write(path)
+}
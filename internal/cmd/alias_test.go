@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/config"
+	"github.com/heaths/go-console"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestAliasCmd(t *testing.T) {
+	globalOpts := &GlobalOptions{
+		userConfigPath: filepath.Join(t.TempDir(), "config.yml"),
+	}
+
+	run := func(args ...string) (string, error) {
+		fake := console.Fake()
+		globalOpts.Console = fake
+		require.NoError(t, LoadConfig(globalOpts))
+
+		cmd := NewAliasCmd(globalOpts)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+
+		stdout, _, _ := fake.Buffers()
+		return stdout.String(), err
+	}
+
+	_, err := run("set", "roadmap", "acme/12")
+	require.NoError(t, err)
+	_, err = run("set", "backlog", "https://ghe.example.com/orgs/acme/projects/7")
+	require.NoError(t, err)
+	_, err = run("set", "docs", "github.com/heaths/3")
+	require.NoError(t, err)
+
+	stdout, err := run("list")
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		backlog  https://ghe.example.com/orgs/acme/projects/7
+		docs     github.com/heaths/3
+		roadmap  acme/12
+	`), stdout)
+
+	_, err = run("delete", "Docs")
+	require.NoError(t, err)
+
+	stdout, err = run("list")
+	require.NoError(t, err)
+	assert.Equal(t, heredoc.Doc(`
+		backlog  https://ghe.example.com/orgs/acme/projects/7
+		roadmap  acme/12
+	`), stdout)
+
+	_, err = run("delete", "docs")
+	assert.EqualError(t, err, "no such alias: docs")
+
+	_, err = run("set", "roadmap", "acme")
+	assert.EqualError(t, err, `invalid project "acme" for alias "roadmap"; must be [HOST/]OWNER/NUMBER or a project URL`)
+
+	_, err = run("set", "roadmap", "acme/roadmap")
+	assert.EqualError(t, err, `invalid project "acme/roadmap" for alias "roadmap"; must be [HOST/]OWNER/NUMBER or a project URL`)
+
+	_, err = run("set", "12", "acme/12")
+	assert.EqualError(t, err, `invalid alias "12"; must not be a number or contain spaces, slashes, or colons`)
+}
+
+func TestResolveProjectArg_alias(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		project    string
+		wantNumber int
+		wantHost   string
+		wantOwner  string
+		wantName   string
+	}{
+		{
+			name:       "owner and number",
+			args:       []string{"Roadmap"},
+			wantNumber: 12,
+			wantHost:   "github.com",
+			wantOwner:  "acme",
+		},
+		{
+			name:       "same owner",
+			args:       []string{"mine"},
+			wantNumber: 1,
+			wantHost:   "github.com",
+			wantOwner:  "heaths",
+			wantName:   "gh-projects",
+		},
+		{
+			name:       "host",
+			args:       []string{"backlog"},
+			wantNumber: 7,
+			wantHost:   "ghe.example.com",
+			wantOwner:  "acme",
+		},
+		{
+			name:       "URL",
+			args:       []string{"docs"},
+			wantNumber: 3,
+			wantHost:   "ghe.example.com",
+			wantOwner:  "docs",
+		},
+		{
+			name:       "default project",
+			project:    "roadmap",
+			wantNumber: 12,
+			wantHost:   "github.com",
+			wantOwner:  "acme",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := repository.Parse("heaths/gh-projects")
+			require.NoError(t, err)
+
+			opts := &GlobalOptions{
+				Config: &config.Config{
+					Project: tt.project,
+					Aliases: map[string]string{
+						"roadmap": "acme/12",
+						"mine":    "heaths/1",
+						"backlog": "GHE.example.com/acme/7",
+						"docs":    "https://ghe.example.com/users/docs/projects/3/views/1",
+					},
+				},
+				Repo: repo,
+			}
+
+			var number int
+			require.NoError(t, ProjectNumberArg(opts, &number)(&cobra.Command{}, tt.args))
+			require.NoError(t, ResolveProjectArg(opts))
+
+			assert.Equal(t, tt.wantNumber, number)
+			assert.Equal(t, tt.wantHost, opts.Repo.Host())
+			assert.Equal(t, tt.wantOwner, opts.Repo.Owner())
+			assert.Equal(t, tt.wantName, opts.Repo.Name())
+		})
+	}
+}
+
+func TestEdit_alias(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2OwnerID.*"variables":\{"number":12,"owner":"acme"\}`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"type":"Organization","projectV2":{"id":"PN_12","url":"https://github.com/orgs/acme/projects/12"}}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`UpdateProjectV2.*"id":"PN_12","title":"new title"`).
+		Reply(200).
+		JSON(`{"data":{"updateProjectV2":{"projectV2":{"url":"https://github.com/orgs/acme/projects/12"}}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	globalOpts := &GlobalOptions{
+		Config: &config.Config{
+			Aliases: map[string]string{
+				"roadmap": "acme/12",
+			},
+		},
+		Console: console.Fake(),
+		Repo:    repo,
+
+		authToken: "***",
+		host:      "github.com",
+	}
+
+	cmd := NewEditCmd(globalOpts, nil)
+	cmd.SilenceUsage = true
+
+	// The root command resolves the alias before running subcommands.
+	cmd.PreRunE = func(cmd *cobra.Command, args []string) error {
+		return ResolveProjectArg(globalOpts)
+	}
+
+	cmd.SetArgs([]string{"roadmap", "--title", "new title"})
+	require.NoError(t, cmd.Execute())
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+}
+
+func TestCompletions_aliases(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectsV2`).
+		Reply(200).
+		JSON(ownerProjectsJSON)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2Fields.*"number":12,"owner":"acme"`).
+		Reply(200).
+		JSON(rankFieldsJSON)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	opts := &GlobalOptions{
+		Console: console.Fake(),
+		Cache:   cache.New(t.TempDir()),
+		Config: &config.Config{
+			Aliases: map[string]string{
+				"roadmap": "acme/12",
+				"docs":    "heaths/3",
+			},
+		},
+		Repo: repo,
+
+		authToken: "***",
+		host:      "github.com",
+	}
+
+	assert.Equal(t, heredoc.Doc(`
+		docs	heaths/3
+		roadmap	acme/12
+		1	Roadmap
+		12	Backlog
+		:4
+	`), complete(t, opts, "view", ""))
+
+	assert.Equal(t, heredoc.Doc(`
+		roadmap	acme/12
+		:4
+	`), complete(t, opts, "view", "r"))
+
+	// Fields are completed for the project of an alias.
+	assert.Equal(t, heredoc.Doc(`
+		Status=
+		:6
+	`), complete(t, opts, "edit", "roadmap", "-f", "S"))
+
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+}
@@ -0,0 +1,432 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/automate"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func NewAutomateCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := automateOptions{}
+	cmd := &cobra.Command{
+		Use:   "automate <number>",
+		Short: "Run rules to change project items",
+		Long: heredoc.Docf(`
+			Run rules from a YAML file that take actions on items in a project. Rules
+			run in order on all items, and only take actions that would change an item
+			so they can be run repeatedly e.g., on a schedule.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Each rule selects items with conditions under %[1]sif%[1]s that must all match:
+
+			  filter:     a project view filter e.g., "-status:Done label:bug"
+			  state:      open, closed, merged, or draft
+			  labels:     labels that must all be applied
+			  repository: the name with owner of the issue or pull request
+			  fields:     field names and values
+			  age:        created at least this long ago e.g., 30d, 4w, 6m, or 1y
+			  inactive:   not updated for at least this long e.g., 14d
+
+			Each rule takes actions under %[1]sthen%[1]s in the order listed:
+
+			  set:        field names and values to set
+			  clear:      field names to clear
+			  add-labels: labels to add to the issue or pull request
+			  comment:    a comment to add to the issue or pull request once
+			  archive:    true to archive the item
+			  remove:     true to remove the item from the project
+
+			Pass --dry-run to show actions without taking them.
+		`, "`"),
+		Example: heredoc.Doc(`
+			$ cat rules.yaml
+			rules:
+			  - name: Done when merged
+			    if:
+			      state: merged
+			      filter: -status:Done
+			    then:
+			      set:
+			        Status: Done
+			  - name: Archive old items
+			    if:
+			      fields:
+			        Status: Done
+			      inactive: 30d
+			    then:
+			      archive: true
+
+			$ gh projects automate 1 -c rules.yaml --dry-run
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			f, err := os.Open(opts.configPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			opts.config, err = automate.Load(f)
+			if err != nil {
+				return err
+			}
+
+			return automateRules(&opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", "", "Path to a YAML `file` of rules")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show actions without taking them")
+	_ = cmd.MarkFlagRequired("config")
+	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
+
+	return cmd
+}
+
+type automateOptions struct {
+	GlobalOptions
+
+	number     int
+	configPath string
+	config     *automate.Config
+	dryRun     bool
+}
+
+func automateRules(opts *automateOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	runner, err := newRuleRunner(client, opts.config, projectFields)
+	if err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+	runner.projectID = project.ID
+
+	actions := automate.Plan(opts.config, project.Items, filter.Env{
+		Viewer: project.Viewer,
+		Now:    timeNow(&opts.GlobalOptions),
+	})
+
+	actions, err = skipCommented(client, actions)
+	if err != nil {
+		return
+	}
+
+	out := opts.Console.Stdout()
+	for _, action := range actions {
+		if opts.dryRun {
+			fmt.Fprintf(out, "%s: would %s for %s\n", action.Rule, action, itemTitle(action.Item))
+			continue
+		}
+
+		err = runner.take(action)
+		if err != nil {
+			return
+		}
+
+		fmt.Fprintf(out, "%s: %s for %s\n", action.Rule, action, itemTitle(action.Item))
+	}
+
+	if opts.Console.IsStdoutTTY() || opts.dryRun {
+		count := text.Pluralize(len(actions), "action")
+		if opts.dryRun {
+			fmt.Fprintf(out, "Would take %s\n", count)
+		} else {
+			fmt.Fprintf(out, "Took %s\n", count)
+		}
+	}
+
+	return
+}
+
+// ruleRunner takes actions planned by automation rules.
+type ruleRunner struct {
+	client    api.GQLClient
+	projectID string
+
+	// values are set field values keyed by rule and field name.
+	values map[string]models.Field
+
+	// clears are field IDs keyed by field name.
+	clears map[string]string
+
+	// labels are label IDs keyed by repository and label name.
+	labels map[string]string
+}
+
+// newRuleRunner resolves fields for all rules before any actions are taken.
+func newRuleRunner(client api.GQLClient, config *automate.Config, projectFields []models.ProjectField) (*ruleRunner, error) {
+	r := &ruleRunner{
+		client: client,
+		values: make(map[string]models.Field),
+		clears: make(map[string]string),
+		labels: make(map[string]string),
+	}
+
+	for _, rule := range config.Rules {
+		for name, value := range rule.Then.Set {
+			field, err := resolveField(projectFields, name, value)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			if field == nil {
+				return nil, fmt.Errorf("rule %q: field %q not defined", rule.Name, name)
+			}
+			r.values[rule.Name+"\x00"+name] = *field
+		}
+
+		for _, name := range rule.Then.Clear {
+			field, err := findField(projectFields, name)
+			if err != nil {
+				return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+			}
+			r.clears[name] = field.ID
+		}
+	}
+
+	return r, nil
+}
+
+func (r *ruleRunner) take(action automate.Action) (err error) {
+	item := action.Item
+	switch action.Kind {
+	case automate.ActionSet:
+		err = updateItemsFields(r.client, r.projectID, []string{item.ID}, map[string]models.Field{
+			action.Field: r.values[action.Rule+"\x00"+action.Field],
+		})
+
+	case automate.ActionClear:
+		err = r.client.Do(mutationClearProjectV2ItemFieldValue, map[string]interface{}{
+			"projectId": r.projectID,
+			"itemId":    item.ID,
+			"fieldId":   r.clears[action.Field],
+		}, &struct{}{})
+
+	case automate.ActionAddLabel:
+		var labelID string
+		labelID, err = getLabelID(r.client, item, action.Value, r.labels)
+		if err == nil {
+			err = r.client.Do(mutationAddLabelsToLabelable, map[string]interface{}{
+				"labelableId": item.Content.ID,
+				"labelIds":    []string{labelID},
+			}, &struct{}{})
+		}
+
+	case automate.ActionComment:
+		err = r.client.Do(mutationAddComment, map[string]interface{}{
+			"subjectId": item.Content.ID,
+			"body":      action.Value + "\n\n" + automate.CommentMarker(action.Rule),
+		}, &struct{}{})
+
+	case automate.ActionArchive:
+		err = r.client.Do(mutationArchiveProjectV2Item, map[string]interface{}{
+			"projectId": r.projectID,
+			"itemId":    item.ID,
+		}, &struct{}{})
+
+	case automate.ActionRemove:
+		err = r.client.Do(mutationDeleteProjectV2Item, map[string]interface{}{
+			"id":     r.projectID,
+			"itemId": item.ID,
+		}, &struct{}{})
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s: failed to %s for %s: %w", action.Rule, action, itemTitle(item), err)
+	}
+
+	return nil
+}
+
+// itemTitle gets the number and title of an issue or pull request, or the title of a draft issue.
+func itemTitle(item models.ProjectItem) string {
+	if item.Content.Number != 0 {
+		return fmt.Sprintf("#%d %s", item.Content.Number, item.Content.Title)
+	}
+	return item.Content.Title
+}
+
+// skipCommented removes comment actions for issues and pull requests that already have a comment from the rule.
+func skipCommented(client api.GQLClient, actions []automate.Action) ([]automate.Action, error) {
+	var ids []string
+	for _, action := range actions {
+		if action.Kind == automate.ActionComment {
+			ids = append(ids, action.Item.Content.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return actions, nil
+	}
+
+	const batchSize = 50
+	comments := make(map[string][]string, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var data struct {
+			Nodes []struct {
+				ID       string
+				Comments struct {
+					Nodes []struct {
+						Body string
+					}
+				}
+			}
+		}
+
+		err := client.Do(queryContentComments, map[string]interface{}{"ids": ids[start:end]}, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range data.Nodes {
+			for _, comment := range node.Comments.Nodes {
+				comments[node.ID] = append(comments[node.ID], comment.Body)
+			}
+		}
+	}
+
+	filtered := actions[:0]
+	for _, action := range actions {
+		if action.Kind == automate.ActionComment {
+			marker := automate.CommentMarker(action.Rule)
+			commented := false
+			for _, body := range comments[action.Item.Content.ID] {
+				if strings.Contains(body, marker) {
+					commented = true
+					break
+				}
+			}
+			if commented {
+				continue
+			}
+		}
+		filtered = append(filtered, action)
+	}
+
+	return filtered, nil
+}
+
+// getLabelID gets the ID of a label in the repository of an item, caching IDs by repository and label name.
+func getLabelID(client api.GQLClient, item models.ProjectItem, name string, labels map[string]string) (string, error) {
+	if item.Content.Repository == nil {
+		return "", fmt.Errorf("label %q requires a repository", name)
+	}
+
+	repo := item.Content.Repository.NameWithOwner
+	key := strings.ToLower(repo + "\x00" + name)
+	if id, ok := labels[key]; ok {
+		return id, nil
+	}
+
+	owner, repoName, _ := strings.Cut(repo, "/")
+	var data struct {
+		Repository struct {
+			Label *struct {
+				ID string
+			}
+		}
+	}
+
+	err := client.Do(queryRepositoryLabel, map[string]interface{}{
+		"owner": owner,
+		"name":  repoName,
+		"label": name,
+	}, &data)
+	if err != nil {
+		return "", err
+	}
+
+	if data.Repository.Label == nil {
+		return "", fmt.Errorf("label %q not found in %s", name, repo)
+	}
+
+	labels[key] = data.Repository.Label.ID
+	return data.Repository.Label.ID, nil
+}
+
+const mutationClearProjectV2ItemFieldValue = `
+mutation ClearProjectV2ItemFieldValue($projectId: ID!, $itemId: ID!, $fieldId: ID!) {
+	clearProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId}) {
+		projectV2Item {
+			id
+		}
+	}
+}
+`
+
+const mutationAddLabelsToLabelable = `
+mutation AddLabelsToLabelable($labelableId: ID!, $labelIds: [ID!]!) {
+	addLabelsToLabelable(input: {labelableId: $labelableId, labelIds: $labelIds}) {
+		clientMutationId
+	}
+}
+`
+
+const mutationAddComment = `
+mutation AddComment($subjectId: ID!, $body: String!) {
+	addComment(input: {subjectId: $subjectId, body: $body}) {
+		clientMutationId
+	}
+}
+`
+
+const queryRepositoryLabel = `
+query RepositoryLabel($owner: String!, $name: String!, $label: String!) {
+	repository(owner: $owner, name: $name) {
+		label(name: $label) {
+			id
+		}
+	}
+}
+`
+
+const queryContentComments = `
+query ContentComments($ids: [ID!]!) {
+	nodes(ids: $ids) {
+		...on Issue {
+			id
+			comments(last: 100) {
+				nodes {
+					body
+				}
+			}
+		}
+		...on PullRequest {
+			id
+			comments(last: 100) {
+				nodes {
+					body
+				}
+			}
+		}
+	}
+}
+`
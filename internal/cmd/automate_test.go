@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/automate"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const automateItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Project",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 3,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN", "repository": {"nameWithOwner": "heaths/gh-projects"}},
+							"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						},
+						{
+							"id": "PNI_2",
+							"type": "PULL_REQUEST",
+							"content": {"id": "PR_2", "number": 2, "title": "Add a feature", "state": "MERGED", "repository": {"nameWithOwner": "heaths/gh-projects"}},
+							"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						},
+						{
+							"id": "PNI_3",
+							"type": "DRAFT_ISSUE",
+							"content": {"id": "DI_3", "title": "Someday"},
+							"fieldValues": {"nodes": []}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+const automateRulesYAML = `
+rules:
+  - name: Done when merged
+    if:
+      state: merged
+    then:
+      set:
+        Status: Done
+  - name: Ping open issues
+    if:
+      state: open
+    then:
+      add-labels: [stale]
+      comment: Is this still needed?
+  - name: Remove drafts
+    if:
+      state: draft
+    then:
+      remove: true
+`
+
+func TestAutomateRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		dryRun     bool
+		comments   string
+		mocks      func()
+		wantStdout string
+	}{
+		{
+			name:     "dry run",
+			dryRun:   true,
+			comments: `{"data":{"nodes":[{"id":"I_1","comments":{"nodes":[]}}]}}`,
+			wantStdout: heredoc.Doc(`
+				Done when merged: would set Status to "Done" for #2 Add a feature
+				Ping open issues: would add label "stale" for #1 Fix the parser
+				Ping open issues: would comment for #1 Fix the parser
+				Remove drafts: would remove for Someday
+				Would take 4 actions
+			`),
+		},
+		{
+			name:     "run",
+			comments: `{"data":{"nodes":[{"id":"I_1","comments":{"nodes":[{"body":"Is this still needed?\n\n<!-- gh-projects automate: Ping open issues -->"}]}}]}}`,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","i0":"PNI_2","projectId":"PN_1","v0":\{"singleSelectOptionId":"2"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_2"}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"label":"stale","name":"gh-projects","owner":"heaths"`).
+					Reply(200).
+					JSON(`{"data":{"repository":{"label":{"id":"LA_stale"}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"labelIds":\["LA_stale"\],"labelableId":"I_1"`).
+					Reply(200).
+					JSON(`{"data":{"addLabelsToLabelable":{"clientMutationId":null}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"id":"PN_1","itemId":"PNI_3"`).
+					Reply(200).
+					JSON(`{"data":{"deleteProjectV2Item":{"deletedItemId":"PNI_3"}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				Done when merged: set Status to "Done" for #2 Add a feature
+				Ping open issues: add label "stale" for #1 Fix the parser
+				Remove drafts: remove for Someday
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(roadmapFieldsJSON)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemFields`).
+				Reply(200).
+				JSON(automateItemsJSON)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`ContentComments`).
+				Reply(200).
+				JSON(tt.comments)
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			config, err := automate.Load(strings.NewReader(automateRulesYAML))
+			require.NoError(t, err)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &automateOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				config: config,
+				dryRun: tt.dryRun,
+			}
+
+			err = automateRules(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestAutomateRules_undefinedField(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2Fields`).
+		Reply(200).
+		JSON(roadmapFieldsJSON)
+
+	config, err := automate.Load(strings.NewReader("rules:\n  - name: test\n    then:\n      set:\n        Priority: High\n"))
+	require.NoError(t, err)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	assert.NoError(t, err)
+
+	opts := &automateOptions{
+		GlobalOptions: GlobalOptions{
+			Console: console.Fake(),
+			Repo:    repo,
+
+			authToken: "***",
+			host:      "github.com",
+		},
+		number: 1,
+		config: config,
+	}
+
+	err = automateRules(opts)
+	assert.EqualError(t, err, `rule "test": field "Priority" not defined`)
+}
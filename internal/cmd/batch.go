@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/utils"
+)
+
+const (
+	// BatchSize is the maximum number of aliased selections or mutations in a single request.
+	// Kept small to stay well within GraphQL node and complexity limits and avoid secondary rate limits.
+	BatchSize int = 20
+)
+
+// getContentIDs resolves the IDs of issues or pull requests in a single aliased query.
+func getContentIDs(client api.GQLClient, opts *GlobalOptions, numbers []int) ([]string, error) {
+	var b strings.Builder
+	b.WriteString(`query RepositoryIssuesOrPullRequestsID($owner: String!, $name: String!) {
+	rateLimit {
+		cost
+		remaining
+		resetAt
+	}
+	repository(owner: $owner, name: $name) {
+`)
+	for i, number := range numbers {
+		fmt.Fprintf(&b, "\t\ti%d: issueOrPullRequest(number: %d) {\n\t\t\t...issueOrPullRequestID\n\t\t}\n", i, number)
+	}
+	b.WriteString("\t}\n}\n")
+	b.WriteString(fragmentIssueOrPullRequestID)
+
+	vars := map[string]interface{}{
+		"owner": opts.Repo.Owner(),
+		"name":  opts.Repo.Name(),
+	}
+
+	var data models.RepositoryIssuesOrPullRequests
+	err := client.Do(b.String(), vars, &data)
+	if err != nil {
+		return nil, withoutErrorPaths(err)
+	}
+
+	contentIDs := make([]string, len(numbers))
+	for i, number := range numbers {
+		content := data.Repository[fmt.Sprintf("i%d", i)]
+		if content == nil || content.ID == "" {
+			return nil, fmt.Errorf("issue or pull request #%d not found", number)
+		}
+		contentIDs[i] = content.ID
+	}
+
+	return contentIDs, nil
+}
+
+// addItems adds issues or pull requests to a project in a single aliased mutation and returns the project item IDs.
+func addItems(client api.GQLClient, projectID string, contentIDs []string) ([]string, error) {
+	var b strings.Builder
+	b.WriteString("mutation AddProjectV2ItemsById($id: ID!")
+	for i := range contentIDs {
+		fmt.Fprintf(&b, ", $c%d: ID!", i)
+	}
+	b.WriteString(") {\n")
+	for i := range contentIDs {
+		fmt.Fprintf(&b, "\ta%[1]d: addProjectV2ItemById(input: {projectId: $id, contentId: $c%[1]d}) {\n\t\titem {\n\t\t\tid\n\t\t}\n\t}\n", i)
+	}
+	b.WriteString("}\n")
+
+	vars := map[string]interface{}{
+		"id": projectID,
+	}
+	for i, contentID := range contentIDs {
+		vars[fmt.Sprintf("c%d", i)] = contentID
+	}
+
+	var data map[string]*struct {
+		Item models.ProjectItem
+	}
+	err := client.Do(b.String(), vars, &data)
+	if err != nil {
+		return nil, withoutErrorPaths(err)
+	}
+
+	itemIDs := make([]string, len(contentIDs))
+	for i := range contentIDs {
+		if added := data[fmt.Sprintf("a%d", i)]; added != nil {
+			itemIDs[i] = added.Item.ID
+		}
+	}
+
+	return itemIDs, nil
+}
+
+type fieldUpdate struct {
+	itemID string
+	name   string
+	field  models.Field
+}
+
+// updateItemsFields sets field values for project items using aliased mutations of up to BatchSize updates each.
+func updateItemsFields(client api.GQLClient, projectID string, itemIDs []string, fields map[string]models.Field) error {
+	// Sort field names so batches are deterministic.
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	updates := make([]fieldUpdate, 0, len(itemIDs)*len(names))
+	for _, itemID := range itemIDs {
+		for _, name := range names {
+			updates = append(updates, fieldUpdate{
+				itemID: itemID,
+				name:   name,
+				field:  fields[name],
+			})
+		}
+	}
+
+	for _, batch := range utils.Chunk(updates, BatchSize) {
+		err := updateFieldsBatch(client, projectID, batch)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func updateFieldsBatch(client api.GQLClient, projectID string, updates []fieldUpdate) error {
+	var b strings.Builder
+	b.WriteString("mutation UpdateProjectV2ItemFieldValues($projectId: ID!")
+	for i := range updates {
+		fmt.Fprintf(&b, ", $i%[1]d: ID!, $f%[1]d: ID!, $v%[1]d: ProjectV2FieldValue!", i)
+	}
+	b.WriteString(") {\n")
+	for i := range updates {
+		fmt.Fprintf(&b, "\tu%[1]d: updateProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $i%[1]d, fieldId: $f%[1]d, value: $v%[1]d}) {\n\t\tprojectV2Item {\n\t\t\tid\n\t\t}\n\t}\n", i)
+	}
+	b.WriteString("}\n")
+
+	vars := map[string]interface{}{
+		"projectId": projectID,
+	}
+	for i, update := range updates {
+		vars[fmt.Sprintf("i%d", i)] = update.itemID
+		vars[fmt.Sprintf("f%d", i)] = update.field.ID
+		vars[fmt.Sprintf("v%d", i)] = update.field.Value
+	}
+
+	var data interface{}
+	err := client.Do(b.String(), vars, &data)
+	if err != nil {
+		// Report the first field that failed to update as if it were updated individually.
+		name := updates[0].name
+		if alias := errorAlias(err); alias != "" {
+			var i int
+			if _, scanErr := fmt.Sscanf(alias, "u%d", &i); scanErr == nil && i < len(updates) {
+				name = updates[i].name
+			}
+		}
+		return fmt.Errorf("failed to update field %q: %w", name, withoutErrorPaths(err))
+	}
+
+	return nil
+}
+
+// errorAlias gets the alias of the first top-level selection that failed.
+func errorAlias(err error) string {
+	if err, ok := err.(api.GQLError); ok {
+		for _, e := range err.Errors {
+			if len(e.Path) > 0 {
+				if alias, ok := e.Path[0].(string); ok {
+					return alias
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// withoutErrorPaths removes aliased paths from GraphQL errors so messages are the same as unbatched requests.
+func withoutErrorPaths(err error) error {
+	if gqlErr, ok := err.(api.GQLError); ok {
+		errs := make([]api.GQLErrorItem, len(gqlErr.Errors))
+		for i, e := range gqlErr.Errors {
+			e.Path = nil
+			errs[i] = e
+		}
+		return api.GQLError{Errors: errs}
+	}
+	return err
+}
+
+const fragmentIssueOrPullRequestID = `
+fragment issueOrPullRequestID on IssueOrPullRequest {
+	... on Issue {
+		id
+	}
+	... on PullRequest {
+		id
+	}
+}
+`
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestUpdateItemsFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		itemIDs []string
+		mocks   func()
+		wantErr string
+	}{
+		{
+			name:    "single batch",
+			itemIDs: []string{"PNI_1", "PNI_2"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`u3: updateProjectV2ItemFieldValue`).
+					Reply(200).
+					JSON(`{"data":{}}`)
+			},
+		},
+		{
+			name:    "multiple batches",
+			itemIDs: make([]string, BatchSize),
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Times(2).
+					Reply(200).
+					JSON(`{"data":{}}`)
+			},
+		},
+		{
+			name:    "failed field",
+			itemIDs: []string{"PNI_1", "PNI_2"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"u0": {
+								"projectV2Item": {
+									"id": "PNI_1"
+								}
+							},
+							"u1": null
+						},
+						"errors": [
+							{
+								"type": "NOT_FOUND",
+								"path": ["u1"],
+								"message": "Could not resolve to a node with the global id of 'PNF_Status'"
+							}
+						]
+					}`)
+			},
+			wantErr: `failed to update field "Status": GraphQL: Could not resolve to a node with the global id of 'PNF_Status'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			client, err := gh.GQLClient(&api.ClientOptions{
+				AuthToken: "***",
+				Host:      "github.com",
+			})
+			assert.NoError(t, err)
+
+			fields := map[string]models.Field{
+				"Iteration": {ID: "PNF_Iteration"},
+				"Status":    {ID: "PNF_Status"},
+			}
+
+			err = updateItemsFields(client, "PN_1", tt.itemIDs, fields)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+)
+
+func viewBoard(opts *viewOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	field, err := getField(client, opts.number, opts.groupBy, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := make([]models.ProjectItem, 0, len(project.Items))
+	for _, item := range unarchivedItems(project.Items) {
+		// Draft issues have no state but are shown on boards.
+		if item.Type == "DRAFT_ISSUE" || equalItemState(item.Content.State, opts.state) {
+			items = append(items, item)
+		}
+	}
+
+	groups, err := filter.GroupBy(items, field)
+	if err != nil {
+		return
+	}
+
+	columns := make([]template.BoardColumn, len(groups))
+	for i, group := range groups {
+		columns[i] = template.BoardColumn{
+			Name:       group.Name,
+			Items:      group.Items,
+			TotalCount: len(group.Items),
+		}
+		if len(group.Items) > opts.limit {
+			columns[i].Items = group.Items[:opts.limit]
+		}
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	return t.Board(project.Title, columns, terminalWidth(&opts.GlobalOptions))
+}
+
+// getField gets a field definition by name.
+func getField(client api.GQLClient, number int, name string, opts *GlobalOptions) (*models.ProjectField, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+		"name":   name,
+	}
+
+	var data struct {
+		Repository struct {
+			ProjectV2 struct {
+				Field *models.ProjectField
+			}
+		}
+	}
+
+	err := client.Do(queryRepositoryOwnerProjectV2Field, vars, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	field := data.Repository.ProjectV2.Field
+	if field == nil || field.ID == "" {
+		return nil, fmt.Errorf("field not found: %s", name)
+	}
+
+	return field, nil
+}
+
+const queryRepositoryOwnerProjectV2Field = `
+query RepositoryOwnerProjectV2Field($owner: String!, $number: Int!, $name: String!) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				field(name: $name) {
+					...on ProjectV2FieldCommon {
+						id
+						name
+						dataType
+					}
+					...on ProjectV2IterationField {
+						configuration {
+							iterations {
+								id
+								name: title
+								startDate
+								duration
+							}
+							completedIterations {
+								id
+								name: title
+								startDate
+								duration
+							}
+						}
+					}
+					...on ProjectV2SingleSelectField {
+						options {
+							id
+							name
+						}
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+// listProjectFields gets all field definitions for a project.
+func listProjectFields(client api.GQLClient, number int, opts *GlobalOptions) ([]models.ProjectField, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+	}
+
+	var fields []models.ProjectField
+	for {
+		// Decode each page into new values so fields already appended do not share slices with the next page.
+		var data struct {
+			Repository struct {
+				ProjectV2 struct {
+					Fields struct {
+						Nodes    []models.ProjectField
+						PageInfo struct {
+							HasNextPage bool
+							EndCursor   string
+						}
+					}
+				}
+			}
+		}
+
+		err := client.Do(queryRepositoryOwnerProjectV2Fields, vars, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, data.Repository.ProjectV2.Fields.Nodes...)
+		if data.Repository.ProjectV2.Fields.PageInfo.HasNextPage {
+			vars["after"] = data.Repository.ProjectV2.Fields.PageInfo.EndCursor
+		} else {
+			break
+		}
+	}
+
+	return fields, nil
+}
+
+const queryRepositoryOwnerProjectV2Fields = `
+query RepositoryOwnerProjectV2Fields($owner: String!, $number: Int!, $after: String) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				fields(first: 30, after: $after) {
+					nodes {
+						...on ProjectV2FieldCommon {
+							id
+							name
+							dataType
+						}
+						...on ProjectV2IterationField {
+							configuration {
+								iterations {
+									id
+									name: title
+									startDate
+									duration
+								}
+								completedIterations {
+									id
+									name: title
+									startDate
+									duration
+								}
+							}
+						}
+						...on ProjectV2SingleSelectField {
+							options {
+								id
+								name
+							}
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	}
+}
+`
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/heaths/go-console/pkg/colorscheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const statusFieldJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"field": {
+					"id": "PNF_Status",
+					"name": "Status",
+					"dataType": "SINGLE_SELECT",
+					"options": [
+						{"id": "1", "name": "Todo"},
+						{"id": "2", "name": "In Progress"},
+						{"id": "3", "name": "Done"}
+					]
+				}
+			}
+		}
+	}
+}`
+
+func TestViewBoard(t *testing.T) {
+	tests := []struct {
+		name       string
+		tty        bool
+		width      int
+		limit      int
+		items      string
+		wantStdout string
+	}{
+		{
+			name:  "tty",
+			tty:   true,
+			width: 80,
+			limit: 20,
+			wantStdout: heredoc.Doc(`
+				Todo 1                     In Progress 1              Done 1
+				─────────────────────────  ─────────────────────────  ─────────────────────────
+				#3 Write docs              #1 Fix the parser          #2 Add a feature
+			`),
+		},
+		{
+			name:  "tty wraps columns",
+			tty:   true,
+			width: 50,
+			limit: 20,
+			wantStdout: heredoc.Doc(`
+				Todo 1                    In Progress 1
+				────────────────────────  ────────────────────────
+				#3 Write docs             #1 Fix the parser
+
+				Done 1
+				────────────────────────
+				#2 Add a feature
+			`),
+		},
+		{
+			name:  "grouped lists",
+			limit: 20,
+			wantStdout: heredoc.Doc(`
+				Todo (1 item)
+				#3  Write docs
+
+				In Progress (1 item)
+				#1  Fix the parser
+
+				Done (1 item)
+				#2  Add a feature
+			`),
+		},
+		{
+			name:  "excludes archived items",
+			limit: 20,
+			items: strings.Replace(itemFieldsJSON, `"id": "PNI_2",`, `"id": "PNI_2", "isArchived": true,`, 1),
+			wantStdout: heredoc.Doc(`
+				Todo (1 item)
+				#3  Write docs
+
+				In Progress (1 item)
+				#1  Fix the parser
+
+				Done (0 items)
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.items == "" {
+				tt.items = itemFieldsJSON
+			}
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Field\(`).
+				Reply(200).
+				JSON(statusFieldJSON)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemFields`).
+				Reply(200).
+				JSON(tt.items)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			// Disable colors to compare layout.
+			fake := console.Fake(console.WithStdoutTTY(tt.tty), console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))))
+			opts := &viewOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					width:     tt.width,
+				},
+				number:  1,
+				limit:   tt.limit,
+				state:   "all",
+				board:   true,
+				groupBy: "Status",
+			}
+
+			err = viewBoard(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestListProjectFields_pages(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"number":1,"owner":"heaths"\}`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"projectV2":{"fields":{"nodes":[
+			{"id":"PNF_1","name":"Status","dataType":"SINGLE_SELECT","options":[{"id":"1","name":"Todo"},{"id":"2","name":"Done"}]}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"PAGE_1"}}}}}}`)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"after":"PAGE_1"`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"projectV2":{"fields":{"nodes":[
+			{"id":"PNF_2","name":"Priority","dataType":"SINGLE_SELECT","options":[{"id":"3","name":"P1"},{"id":"4","name":"P2"}]}
+		],"pageInfo":{"hasNextPage":false}}}}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	opts := &GlobalOptions{
+		Repo:      repo,
+		authToken: "***",
+		host:      "github.com",
+	}
+
+	client, err := newClient(opts)
+	require.NoError(t, err)
+
+	fields, err := listProjectFields(client, 1, opts)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+	require.Len(t, fields, 2)
+
+	// Decoding the second page must not change options of fields from the first page.
+	require.Len(t, fields[0].Options, 2)
+	assert.Equal(t, "Todo", fields[0].Options[0].Name)
+	assert.Equal(t, "Done", fields[0].Options[1].Name)
+	assert.Equal(t, "P1", fields[1].Options[0].Name)
+}
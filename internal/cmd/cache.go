@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/spf13/cobra"
+)
+
+func NewCacheCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage cached project metadata",
+		Long: heredoc.Docf(`
+			Project IDs, field definitions, and project items are cached under
+			%[1]s to reduce API requests.
+
+			Cached metadata is refreshed automatically when it expires or is out of date.
+			Pass --no-cache to any command to bypass the cache.
+		`, "`"+cache.DefaultDir()+"`"),
+		// Clearing the cache does not require authentication or a repository.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "clear",
+		Short: "Clear all cached project metadata",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := globalOpts.Cache
+			if c == nil {
+				c = cache.New(cache.DefaultDir())
+			}
+
+			if err := c.Clear(); err != nil {
+				return err
+			}
+
+			if globalOpts.Console.IsStdoutTTY() {
+				fmt.Fprintln(globalOpts.Console.Stdout(), "Cleared cached project metadata")
+			}
+
+			return nil
+		},
+	})
+
+	return cmd
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/MakeNowJust/heredoc"
-	"github.com/cli/go-gh"
-	"github.com/cli/go-gh/pkg/api"
 	"github.com/heaths/gh-projects/internal/models"
 	"github.com/heaths/gh-projects/internal/utils"
 	"github.com/spf13/cobra"
@@ -24,7 +22,7 @@ func NewCloneCmd(globalOpts *GlobalOptions, runFunc func(*cloneOptions) error) *
 			A new title is always required, and the visibility of the project being cloned is copied by default.
 			Pass --public or --public=false to override.
 
-			The number argument can begin with a "#" symbol.
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
 
 			Pass "-" to --body to read from standard input.
 			`),
@@ -37,7 +35,8 @@ func NewCloneCmd(globalOpts *GlobalOptions, runFunc func(*cloneOptions) error) *
 			  Ship our _subsequent update_!
 			  EOF
 		`),
-		Args: ProjectNumberArg(&opts.number),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.GlobalOptions = *globalOpts
 
@@ -83,12 +82,7 @@ type cloneOptions struct {
 }
 
 func clone(opts *cloneOptions) (err error) {
-	clientOpts := &api.ClientOptions{
-		AuthToken: opts.authToken,
-		Host:      opts.host,
-		Log:       opts.Log,
-	}
-	client, err := gh.GQLClient(clientOpts)
+	client, err := newClient(&opts.GlobalOptions)
 	if err != nil {
 		return
 	}
@@ -101,12 +95,27 @@ func clone(opts *cloneOptions) (err error) {
 		"drafts": opts.drafts,
 	}
 
+	// A project URL or alias for another owner has no repository.
+	query := queryRepositoryProjectV2ID
+	queryVars := vars
+	if opts.Repo.Name() == "" {
+		query = queryRepositoryOwnerProjectV2OwnerID
+		queryVars = map[string]interface{}{
+			"owner":  opts.Repo.Owner(),
+			"number": opts.number,
+		}
+	}
+
 	var projectData models.RepositoryProject
-	err = client.Do(queryRepositoryProjectV2ID, vars, &projectData)
+	err = client.Do(query, queryVars, &projectData)
 	if err != nil {
 		return
 	}
 
+	if projectData.Repository.ProjectV2 == nil {
+		return fmt.Errorf("project #%d not found", opts.number)
+	}
+
 	projectID := projectData.Repository.ProjectV2.ID
 	projectURL := projectData.Repository.ProjectV2.URL
 	public := projectData.Repository.ProjectV2.Public
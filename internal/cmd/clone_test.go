@@ -8,6 +8,7 @@ import (
 	"github.com/heaths/gh-projects/internal/utils"
 	"github.com/heaths/go-console"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/h2non/gock.v1"
 )
 
@@ -25,8 +26,8 @@ func TestNewCloneCmd(t *testing.T) {
 		},
 		{
 			name:    "invalid project number",
-			args:    []string{"test"},
-			wantErr: "invalid project number: test",
+			args:    []string{"#test"},
+			wantErr: "invalid project number: #test",
 		},
 		{
 			name:    "only project number",
@@ -514,3 +515,64 @@ func TestClone(t *testing.T) {
 		})
 	}
 }
+
+func TestClone_anotherOwner(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2OwnerID.*"variables":\{"number":12,"owner":"acme"\}`).
+		Reply(200).
+		JSON(`{
+			"data": {
+				"viewer": {
+					"id": "U_1"
+				},
+				"repository": {
+					"type": "Organization",
+					"projectV2": {
+						"id": "PN_12",
+						"url": "https://github.com/orgs/acme/projects/12"
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`CopyProjectV2.*"ownerId":"U_1","projectId":"PN_12"`).
+		Reply(200).
+		JSON(`{
+			"data": {
+				"copyProjectV2": {
+					"projectV2": {
+						"id": "PN_13",
+						"url": "https://github.com/users/heaths/projects/13"
+					}
+				}
+			}
+		}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	opts := &cloneOptions{
+		projectOptions: projectOptions{
+			GlobalOptions: GlobalOptions{
+				Console: console.Fake(),
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			},
+			title: "title",
+		},
+	}
+
+	opts.projectArg, err = parseProjectArg("https://github.com/orgs/acme/projects/12", &opts.number)
+	require.NoError(t, err)
+	require.NoError(t, ResolveProjectArg(&opts.GlobalOptions))
+
+	err = clone(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+}
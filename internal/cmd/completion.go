@@ -0,0 +1,421 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+type completionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// Field data types that can be set on items.
+var settableDataTypes = []string{"DATE", "ITERATION", "NUMBER", "SINGLE_SELECT", "TEXT"}
+
+// completer gets completions using a repository resolved from the --repo flag or current directory,
+// since completions are requested before the root command resolves the repository.
+type completer struct {
+	opts   GlobalOptions
+	client api.GQLClient
+}
+
+func newCompleter(cmd *cobra.Command, globalOpts *GlobalOptions) (*completer, error) {
+	c := &completer{
+		opts: *globalOpts,
+	}
+
+	if f := cmd.Flag("repo"); f != nil && f.Changed {
+		repo, err := repository.Parse(f.Value.String())
+		if err != nil {
+			return nil, err
+		}
+		c.opts.Repo = repo
+	} else if c.opts.Repo == nil {
+		repo, err := gh.CurrentRepository()
+		if owner := c.opts.config().Owner; repo == nil && owner != "" && os.Getenv("GH_REPO") == "" {
+			repo, err = OwnerRepository(owner)
+		}
+		if err != nil {
+			return nil, err
+		}
+		c.opts.Repo = repo
+	}
+
+	client, err := newClient(&c.opts)
+	if err != nil {
+		return nil, err
+	}
+	c.client = client
+
+	return c, nil
+}
+
+// completions gets cached completions by name, or calls get and caches the returned completions.
+func (c *completer) completions(name string, get func() ([]string, error)) ([]string, error) {
+	host, owner := c.opts.Repo.Host(), c.opts.Repo.Owner()
+	if values := c.opts.Cache.LoadCompletions(host, owner, name); values != nil {
+		return values, nil
+	}
+
+	values, err := get()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.opts.Cache.SaveCompletions(host, owner, name, values); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to cache completions: %v", err), false)
+	}
+
+	return values, nil
+}
+
+// projects gets the numbers and titles of open projects for the repository owner.
+func (c *completer) projects() ([]string, error) {
+	return c.completions("projects", func() ([]string, error) {
+		vars := map[string]interface{}{
+			"owner": c.opts.Repo.Owner(),
+			"first": 100,
+		}
+
+		var data models.RepositoryProjects
+		if err := c.client.Do(queryRepositoryOwnerProjectsV2, vars, &data); err != nil {
+			return nil, err
+		}
+
+		values := []string{}
+		for _, project := range data.Repository.ProjectsV2.Nodes {
+			values = append(values, fmt.Sprintf("%d\t%s", project.Number, project.Title))
+		}
+
+		return values, nil
+	})
+}
+
+// fields gets field definitions for a project from the cache, or fetches and caches them.
+func (c *completer) fields(number int) ([]models.ProjectField, error) {
+	host, owner := c.opts.Repo.Host(), c.opts.Repo.Owner()
+	cached := c.opts.Cache.Load(host, owner, number)
+	if cached.Fields != nil {
+		return cached.Fields, nil
+	}
+
+	fields, err := listProjectFields(c.client, number, &c.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	c.opts.Cache.SetFields(cached, fields)
+	if err := c.opts.Cache.Save(host, owner, number, cached); err != nil {
+		cobra.CompDebugln(fmt.Sprintf("failed to cache project #%d: %v", number, err), false)
+	}
+
+	return fields, nil
+}
+
+// issues gets the numbers and titles of recently updated open issues in the repository.
+func (c *completer) issues() ([]string, error) {
+	return c.completions("issues-"+c.opts.Repo.Name(), func() ([]string, error) {
+		vars := map[string]interface{}{
+			"owner": c.opts.Repo.Owner(),
+			"name":  c.opts.Repo.Name(),
+			"first": recentIssuesCount,
+		}
+
+		var data struct {
+			Repository struct {
+				Issues struct {
+					Nodes []struct {
+						Number int
+						Title  string
+					}
+				}
+			}
+		}
+
+		if err := c.client.Do(queryRepositoryRecentIssues, vars, &data); err != nil {
+			return nil, err
+		}
+
+		values := []string{}
+		for _, issue := range data.Repository.Issues.Nodes {
+			values = append(values, fmt.Sprintf("%d\t%s", issue.Number, issue.Title))
+		}
+
+		return values, nil
+	})
+}
+
+// items gets the numbers and titles of issues and pull requests from the repository in a project.
+func (c *completer) items(number int) ([]string, error) {
+	return c.completions(fmt.Sprintf("items-%d-%s", number, c.opts.Repo.Name()), func() ([]string, error) {
+		project, err := listProjectItems(c.client, number, 100, &c.opts)
+		if err != nil {
+			return nil, err
+		}
+
+		repo := fmt.Sprintf("%s/%s", c.opts.Repo.Owner(), c.opts.Repo.Name())
+		values := []string{}
+		for _, item := range project.Items {
+			if item.Content.Number == 0 {
+				continue
+			}
+
+			if r := item.Content.Repository; r != nil && !strings.EqualFold(r.NameWithOwner, repo) {
+				continue
+			}
+
+			values = append(values, fmt.Sprintf("%d\t%s", item.Content.Number, item.Content.Title))
+		}
+
+		return values, nil
+	})
+}
+
+// completeProjectNumbers completes the first argument with aliases, and project numbers and titles.
+func completeProjectNumbers(globalOpts *GlobalOptions) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		aliases := filterCompletions(aliasCompletions(globalOpts), toComplete, "")
+
+		c, err := newCompleter(cmd, globalOpts)
+		if err != nil {
+			if len(aliases) > 0 {
+				return aliases, cobra.ShellCompDirectiveNoFileComp
+			}
+			return completionError(err)
+		}
+
+		values, err := c.projects()
+		if err != nil {
+			return completionError(err)
+		}
+
+		return append(aliases, filterNumbers(values, toComplete, "")...), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeAliases completes the first argument with aliases.
+func completeAliases(globalOpts *GlobalOptions) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return filterCompletions(aliasCompletions(globalOpts), toComplete, ""), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// aliasCompletions gets the names and projects of aliases.
+func aliasCompletions(globalOpts *GlobalOptions) []string {
+	var values []string
+	for _, alias := range globalOpts.config().AliasList() {
+		values = append(values, alias.Key+"\t"+alias.Value)
+	}
+
+	return values
+}
+
+// completeFieldNames completes comma-separated names of fields with any of the data types, or any field if none are passed.
+func completeFieldNames(globalOpts *GlobalOptions, dataTypes ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		fields, err := completionFields(cmd, globalOpts, args)
+		if err != nil {
+			return completionError(err)
+		}
+
+		var names []string
+		for _, field := range fields {
+			if len(dataTypes) == 0 || utils.StringSliceContains(field.DataType, dataTypes) {
+				names = append(names, field.Name)
+			}
+		}
+
+		prefix, toComplete := splitList(toComplete)
+		return filterCompletions(names, toComplete, prefix), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFieldValues completes "name=value" pairs for settable fields, with options for single select fields
+// and titles for iteration fields.
+func completeFieldValues(globalOpts *GlobalOptions) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		fields, err := completionFields(cmd, globalOpts, args)
+		if err != nil {
+			return completionError(err)
+		}
+
+		name, value, found := strings.Cut(toComplete, "=")
+		if !found {
+			var names []string
+			for _, field := range fields {
+				if utils.StringSliceContains(field.DataType, settableDataTypes) {
+					names = append(names, field.Name+"=")
+				}
+			}
+
+			return filterCompletions(names, toComplete, ""), cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+		}
+
+		field, err := findField(fields, name)
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		return filterCompletions(fieldValues(*field), value, name+"="), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeFieldOptions completes values, and options or iteration titles of the field named by the value of fieldFlag.
+func completeFieldOptions(globalOpts *GlobalOptions, fieldFlag string, values ...string) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		fields, err := completionFields(cmd, globalOpts, args)
+		if err != nil {
+			return completionError(err)
+		}
+
+		completions := append([]string{}, values...)
+		if f := cmd.Flag(fieldFlag); f != nil {
+			if field, err := findField(fields, f.Value.String()); err == nil {
+				completions = append(completions, fieldValues(*field)...)
+			}
+		}
+
+		return filterCompletions(completions, toComplete, ""), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeIssues completes comma-separated issue numbers with recently updated open issues,
+// or issues and pull requests already in the project if inProject is true.
+func completeIssues(globalOpts *GlobalOptions, inProject bool) completionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		c, number, err := completionProject(cmd, globalOpts, args)
+		if err != nil {
+			return completionError(err)
+		} else if c == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var values []string
+		if inProject {
+			values, err = c.items(number)
+		} else {
+			values, err = c.issues()
+		}
+		if err != nil {
+			return completionError(err)
+		}
+
+		prefix, toComplete := splitList(toComplete)
+		return filterNumbers(values, toComplete, prefix), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionProject gets a completer and the number of the project passed as the first argument as a number or alias,
+// or a nil completer if not yet passed. An alias changes the owner of the completer.
+func completionProject(cmd *cobra.Command, globalOpts *GlobalOptions, args []string) (*completer, int, error) {
+	if len(args) == 0 {
+		return nil, 0, nil
+	}
+
+	var number int
+	var arg *projectArg
+	if project, ok := globalOpts.config().Alias(args[0]); ok {
+		var err error
+		if arg, err = parseAlias(args[0], project, &number); err != nil {
+			return nil, 0, err
+		}
+	} else if n, err := parseNumber(args[0], "invalid project number"); err == nil {
+		number = n
+	} else {
+		return nil, 0, nil
+	}
+
+	c, err := newCompleter(cmd, globalOpts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if arg != nil {
+		c.opts.projectArg = arg
+		if err := ResolveProjectArg(&c.opts); err != nil {
+			return nil, 0, err
+		}
+
+		if c.client, err = newClient(&c.opts); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return c, number, nil
+}
+
+// completionFields gets the fields of the project passed as the first argument, or nil if not yet passed.
+func completionFields(cmd *cobra.Command, globalOpts *GlobalOptions, args []string) ([]models.ProjectField, error) {
+	c, number, err := completionProject(cmd, globalOpts, args)
+	if err != nil || c == nil {
+		return nil, err
+	}
+
+	return c.fields(number)
+}
+
+func completionError(err error) ([]string, cobra.ShellCompDirective) {
+	cobra.CompErrorln(err.Error())
+	return nil, cobra.ShellCompDirectiveError
+}
+
+// fieldValues gets the names of options or current and upcoming iterations of a field.
+func fieldValues(field models.ProjectField) []string {
+	var values []string
+	switch field.DataType {
+	case "ITERATION":
+		for _, iteration := range field.Configuration.Iterations {
+			values = append(values, iteration.Name)
+		}
+	case "SINGLE_SELECT":
+		for _, option := range field.Options {
+			values = append(values, option.Name)
+		}
+	}
+
+	return values
+}
+
+// filterCompletions gets values beginning with toComplete with prefix prepended to each.
+func filterCompletions(values []string, toComplete, prefix string) []string {
+	var completions []string
+	for _, value := range values {
+		if strings.HasPrefix(value, toComplete) {
+			completions = append(completions, prefix+value)
+		}
+	}
+
+	return completions
+}
+
+// filterNumbers gets numbered values beginning with toComplete, which may begin with a "#" symbol.
+func filterNumbers(values []string, toComplete, prefix string) []string {
+	if strings.HasPrefix(toComplete, "#") {
+		return filterCompletions(values, toComplete[1:], prefix+"#")
+	}
+
+	return filterCompletions(values, toComplete, prefix)
+}
+
+// splitList splits a comma-separated list into the completed values with a trailing comma, and the value to complete.
+func splitList(toComplete string) (prefix, value string) {
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		return toComplete[:i+1], toComplete[i+1:]
+	}
+
+	return "", toComplete
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/go-console"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const ownerProjectsJSON = `{
+	"data": {
+		"repository": {
+			"projectsV2": {
+				"totalCount": 2,
+				"nodes": [
+					{"id": "PN_1", "number": 1, "title": "Roadmap"},
+					{"id": "PN_12", "number": 12, "title": "Backlog"}
+				],
+				"pageInfo": {
+					"hasNextPage": false
+				}
+			}
+		}
+	}
+}`
+
+func TestCompletions(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		mocks func()
+		want  string
+	}{
+		{
+			name: "project numbers",
+			args: []string{"view", ""},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectsV2.*"first":100,"owner":"heaths"`).
+					Reply(200).
+					JSON(ownerProjectsJSON)
+			},
+			want: heredoc.Doc(`
+				1	Roadmap
+				12	Backlog
+				:4
+			`),
+		},
+		{
+			name: "project numbers with hash prefix",
+			args: []string{"edit", "#1"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(ownerProjectsJSON)
+			},
+			want: heredoc.Doc(`
+				#1	Roadmap
+				#12	Backlog
+				:4
+			`),
+		},
+		{
+			name: "only first argument",
+			args: []string{"view", "1", ""},
+			want: ":4\n",
+		},
+		{
+			name: "field names",
+			args: []string{"edit", "1", "-f", "S"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2Fields.*"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(rankFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				Status=
+				:6
+			`),
+		},
+		{
+			name: "field values",
+			args: []string{"edit", "1", "--field", "Status="},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(rankFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				Status=Todo
+				Status=In Progress
+				Status=Done
+				:4
+			`),
+		},
+		{
+			name: "field names by type",
+			args: []string{"view", "1", "--group-by", ""},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(rankFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				Status
+				:4
+			`),
+		},
+		{
+			name: "field names in list",
+			args: []string{"item", "rank", "1", "--by", "Status,E"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(rankFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				Status,Estimate
+				:4
+			`),
+		},
+		{
+			name: "iterations",
+			args: []string{"report", "burndown", "1", "--iteration", ""},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(iterationFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				@current
+				@previous
+				@next
+				Iteration 2
+				Iteration 3
+				:4
+			`),
+		},
+		{
+			name: "recent issues",
+			args: []string{"edit", "1", "--add-issue", "4,"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryRecentIssues.*"first":30,"name":"gh-projects","owner":"heaths"`).
+					Reply(200).
+					JSON(`{"data":{"repository":{"issues":{"nodes":[{"number":5,"title":"Bug"},{"number":6,"title":"Feature"}]}}}}`)
+			},
+			want: heredoc.Doc(`
+				4,5	Bug
+				4,6	Feature
+				:4
+			`),
+		},
+		{
+			name: "project items",
+			args: []string{"edit", "1", "--remove-issue", "#"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(itemFieldsJSON)
+			},
+			want: heredoc.Doc(`
+				#1	Fix the parser
+				#2	Add a feature
+				#3	Write docs
+				:4
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			require.NoError(t, err)
+
+			opts := &GlobalOptions{
+				Console: console.Fake(),
+				Cache:   cache.New(t.TempDir()),
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			}
+
+			assert.Equal(t, tt.want, complete(t, opts, tt.args...))
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			// Completions are cached so they are not fetched again.
+			assert.Equal(t, tt.want, complete(t, opts, tt.args...))
+		})
+	}
+}
+
+func complete(t *testing.T, opts *GlobalOptions, args ...string) string {
+	root := &cobra.Command{Use: "projects"}
+	root.PersistentFlags().StringP("repo", "R", "", "")
+	root.AddCommand(NewEditCmd(opts, nil))
+	root.AddCommand(NewItemCmd(opts))
+	root.AddCommand(NewReportCmd(opts))
+	root.AddCommand(NewViewCmd(opts))
+
+	var stdout bytes.Buffer
+	root.SetOut(&stdout)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	require.NoError(t, root.Execute())
+
+	return stdout.String()
+}
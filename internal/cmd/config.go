@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/auth"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func NewConfigCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage configuration",
+		Long: heredoc.Docf(`
+			Set defaults in the user config file %[1]s, or in the repository
+			config file %[2]s which takes precedence. Environment variables take
+			precedence over config files, and flags take precedence over all settings.
+
+			The repository config file is read from the current directory, even if --repo is passed.
+
+			  owner    Default project owner as [HOST/]OWNER outside a repository when --repo is not passed (GH_PROJECTS_OWNER)
+			  project  Default project number, URL, or title when none is passed (GH_PROJECTS_PROJECT)
+			  fields   Default field values set when adding issues, as fields.NAME (GH_PROJECTS_FIELDS)
+			  workers  Number of concurrent workers making requests (GH_PROJECTS_WORKERS)
+			  format   Default output format of commands that support JSON: {table|json} (GH_PROJECTS_FORMAT)
+		`, "`"+config.UserPath()+"`", "`.github/gh-projects.yml`"),
+		Example: heredoc.Doc(`
+			# use projects owned by acme by default
+			$ gh projects config set owner acme
+
+			# use project 12 and set Status to Triage when adding issues in this repository
+			$ gh projects config set project 12 --local
+			$ gh projects config set fields.Status Triage --local
+		`),
+		// Configuration does not require authentication or a repository,
+		// and invalid settings are only a warning so they can be changed.
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			warnConfigError(globalOpts)
+			return nil
+		},
+	}
+
+	cmd.AddCommand(newConfigGetCmd(globalOpts))
+	cmd.AddCommand(newConfigListCmd(globalOpts))
+	cmd.AddCommand(newConfigSetCmd(globalOpts))
+
+	return cmd
+}
+
+func newConfigGetCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print the value of a setting",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := globalOpts.config().Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			if value != "" {
+				fmt.Fprintln(globalOpts.Console.Stdout(), value)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newConfigListCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print all settings",
+		Long: heredoc.Doc(`
+			Print all settings from config files and environment variables,
+			after settings with higher precedence are applied.
+		`),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, setting := range globalOpts.config().List() {
+				fmt.Fprintf(globalOpts.Console.Stdout(), "%s=%s\n", setting.Key, setting.Value)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func newConfigSetCmd(globalOpts *GlobalOptions) *cobra.Command {
+	var local bool
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Change the value of a setting",
+		Long: heredoc.Doc(`
+			Change the value of a setting in the user config file, or in the
+			repository config file with --local. Pass an empty value to remove a setting.
+		`),
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeConfigKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userPath, repoPath := configPaths(globalOpts)
+
+			path := userPath
+			if local {
+				if repoPath == "" {
+					return fmt.Errorf("--local requires a git repository")
+				}
+				path = repoPath
+			}
+
+			c, err := config.Read(path)
+			if err != nil {
+				return err
+			}
+
+			if err := c.Set(args[0], args[1]); err != nil {
+				return err
+			}
+
+			return c.Write(path)
+		},
+	}
+
+	cmd.Flags().BoolVar(&local, "local", false, "Change the setting in the repository config file")
+
+	return cmd
+}
+
+// completeConfigKeys completes the key of a setting as the first argument.
+func completeConfigKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return filterCompletions(config.Keys, toComplete, ""), cobra.ShellCompDirectiveNoFileComp
+}
+
+// LoadConfig loads settings from the user and repository config files, and environment variables.
+// The error is also returned by ConfigError.
+func LoadConfig(opts *GlobalOptions) (err error) {
+	userPath, repoPath := configPaths(opts)
+	opts.Config, err = config.Load(userPath, repoPath, os.Getenv)
+	opts.configErr = err
+	return
+}
+
+// ConfigError gets the error loading settings, if any.
+func (opts *GlobalOptions) ConfigError() error {
+	return opts.configErr
+}
+
+// warnConfigError prints the error loading settings, if any, as a warning.
+func warnConfigError(opts *GlobalOptions) {
+	if opts.configErr != nil {
+		fmt.Fprintf(opts.Console.Stderr(), "Warning: %v\n", opts.configErr)
+	}
+}
+
+// configPaths gets the paths to the user config file, and the repository config file if in a git repository.
+// Settings are loaded before flags are parsed, so the repository config is read from the working directory even if --repo is passed.
+func configPaths(opts *GlobalOptions) (userPath, repoPath string) {
+	userPath, repoPath = opts.userConfigPath, opts.repoConfigPath
+	if userPath == "" {
+		userPath = config.UserPath()
+	}
+	if repoPath == "" {
+		if dir, err := os.Getwd(); err == nil {
+			repoPath = config.RepoPath(dir)
+		}
+	}
+
+	return
+}
+
+// OwnerRepository gets a repository for the configured default owner as [HOST/]OWNER.
+// The repository has no name, so it is only used outside a repository and projects are queried by owner.
+func OwnerRepository(value string) (repository.Repository, error) {
+	host, owner, found := strings.Cut(value, "/")
+	if !found {
+		host, owner = "", host
+	}
+	if owner == "" || strings.Contains(owner, "/") {
+		return nil, fmt.Errorf("invalid owner %q; must be [HOST/]OWNER", value)
+	}
+
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+
+	return projectRepository{
+		host:  host,
+		owner: owner,
+	}, nil
+}
+
+// applyConfigFormat sets the named flag to the configured output format if the flag was not passed.
+// Boolean flags are set if the format is "json", and other flags are only set if the format is valid for the flag.
+func applyConfigFormat(cmd *cobra.Command, opts *GlobalOptions, name string) {
+	format := opts.config().Format
+	f := cmd.Flags().Lookup(name)
+	if format == "" || f == nil || f.Changed {
+		return
+	}
+
+	if f.Value.Type() == "bool" {
+		_ = f.Value.Set(fmt.Sprint(format == "json"))
+		return
+	}
+
+	_ = f.Value.Set(format)
+}
+
+// defaultFields adds configured default field values to fields that were not passed.
+func defaultFields(fields map[string]string, opts *GlobalOptions) map[string]string {
+	for name, value := range opts.config().Fields {
+		found := false
+		for k := range fields {
+			if strings.EqualFold(k, name) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			fields[name] = value
+		}
+	}
+
+	return fields
+}
@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/heaths/gh-projects/internal/config"
+	"github.com/heaths/go-console"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigCmd(t *testing.T) {
+	dir := t.TempDir()
+	globalOpts := &GlobalOptions{
+		userConfigPath: filepath.Join(dir, "config.yml"),
+		repoConfigPath: filepath.Join(dir, ".github", "gh-projects.yml"),
+	}
+
+	run := func(args ...string) (string, error) {
+		fake := console.Fake()
+		globalOpts.Console = fake
+		require.NoError(t, LoadConfig(globalOpts))
+
+		cmd := NewConfigCmd(globalOpts)
+		cmd.SetArgs(args)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+		cmd.SilenceUsage = true
+		err := cmd.Execute()
+
+		stdout, _, _ := fake.Buffers()
+		return stdout.String(), err
+	}
+
+	t.Setenv("GH_PROJECTS_WORKERS", "")
+
+	_, err := run("set", "owner", "heaths")
+	require.NoError(t, err)
+	_, err = run("set", "project", "1")
+	require.NoError(t, err)
+	_, err = run("set", "fields.Status", "Triage")
+	require.NoError(t, err)
+
+	// Repository settings take precedence over user settings.
+	_, err = run("set", "project", "12", "--local")
+	require.NoError(t, err)
+	_, err = run("set", "fields.Priority", "P2", "--local")
+	require.NoError(t, err)
+
+	stdout, err := run("get", "project")
+	require.NoError(t, err)
+	assert.Equal(t, "12\n", stdout)
+
+	// Environment variables take precedence over config files.
+	t.Setenv("GH_PROJECTS_WORKERS", "4")
+
+	stdout, err = run("list")
+	require.NoError(t, err)
+	assert.Equal(t, "owner=heaths\nproject=12\nfields.Priority=P2\nfields.Status=Triage\nworkers=4\n", stdout)
+
+	// Only the repository config file was changed with --local.
+	c, err := config.Read(globalOpts.userConfigPath)
+	require.NoError(t, err)
+	assert.Equal(t, "1", c.Project)
+
+	// Empty values remove settings.
+	_, err = run("set", "project", "", "--local")
+	require.NoError(t, err)
+	stdout, err = run("get", "project")
+	require.NoError(t, err)
+	assert.Equal(t, "1\n", stdout)
+
+	_, err = run("set", "color", "always")
+	assert.EqualError(t, err, "unknown config key: color")
+
+	_, err = run("get", "workers.count")
+	assert.EqualError(t, err, "unknown config key: workers.count")
+}
+
+func TestConfigCmd_invalid(t *testing.T) {
+	t.Setenv("GH_PROJECTS_FORMAT", "")
+
+	dir := t.TempDir()
+	globalOpts := &GlobalOptions{
+		userConfigPath: filepath.Join(dir, "config.yml"),
+		repoConfigPath: filepath.Join(dir, ".github", "gh-projects.yml"),
+	}
+	require.NoError(t, os.WriteFile(globalOpts.userConfigPath, []byte("format: xml\nproject: 12\n"), 0o600))
+
+	err := LoadConfig(globalOpts)
+	wantErr := "failed to read " + globalOpts.userConfigPath + `: invalid format "xml"; valid values are {table|json}`
+	assert.EqualError(t, err, wantErr)
+	assert.Equal(t, err, globalOpts.ConfigError())
+
+	// Commands that need a default project report the invalid settings.
+	var number int
+	err = ProjectNumberArg(globalOpts, &number)(&cobra.Command{}, nil)
+	assert.EqualError(t, err, wantErr)
+
+	// Invalid settings can still be changed, with a warning.
+	fake := console.Fake()
+	globalOpts.Console = fake
+
+	cmd := NewConfigCmd(globalOpts)
+	cmd.SetArgs([]string{"set", "format", "table"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SilenceUsage = true
+	require.NoError(t, cmd.Execute())
+
+	_, stderr, _ := fake.Buffers()
+	assert.Equal(t, "Warning: "+wantErr+"\n", stderr.String())
+
+	require.NoError(t, LoadConfig(globalOpts))
+	assert.NoError(t, globalOpts.ConfigError())
+	assert.Equal(t, "table", globalOpts.Config.Format)
+	assert.Equal(t, "12", globalOpts.Config.Project)
+}
+
+func TestProjectNumberArg_config(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		project    string
+		wantNumber int
+		wantArg    *projectArg
+		wantErr    string
+	}{
+		{
+			name:    "no default",
+			wantErr: "missing required project number",
+		},
+		{
+			name:       "default number",
+			project:    "#12",
+			wantNumber: 12,
+		},
+		{
+			name:    "default title",
+			project: "Roadmap",
+			wantArg: &projectArg{title: "Roadmap"},
+		},
+		{
+			name:       "argument takes precedence",
+			args:       []string{"1"},
+			project:    "12",
+			wantNumber: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalOpts := &GlobalOptions{
+				Config: &config.Config{Project: tt.project},
+			}
+
+			var number int
+			err := ProjectNumberArg(globalOpts, &number)(&cobra.Command{}, tt.args)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, number)
+
+			if tt.wantArg != nil {
+				tt.wantArg.number = &number
+			}
+			assert.Equal(t, tt.wantArg, globalOpts.projectArg)
+		})
+	}
+}
+
+func TestOwnerRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     string
+		wantHost  string
+		wantOwner string
+		wantErr   string
+	}{
+		{
+			name:      "host and owner",
+			value:     "GHE.example.com/acme",
+			wantHost:  "GHE.example.com",
+			wantOwner: "acme",
+		},
+		{
+			name:    "repository",
+			value:   "acme/tools/more",
+			wantErr: `invalid owner "acme/tools/more"; must be [HOST/]OWNER`,
+		},
+		{
+			name:    "no owner",
+			value:   "github.com/",
+			wantErr: `invalid owner "github.com/"; must be [HOST/]OWNER`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, err := OwnerRepository(tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, repo.Host())
+			assert.Equal(t, tt.wantOwner, repo.Owner())
+
+			// Projects are queried by owner, so the repository has no name.
+			assert.Equal(t, "", repo.Name())
+		})
+	}
+}
+
+func TestApplyConfigFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		args       []string
+		wantJSON   bool
+		wantFormat string
+	}{
+		{
+			name:       "not configured",
+			wantFormat: "table",
+		},
+		{
+			name:       "json",
+			format:     "json",
+			wantJSON:   true,
+			wantFormat: "json",
+		},
+		{
+			name:       "flags take precedence",
+			format:     "json",
+			args:       []string{"--json=false", "--format", "csv"},
+			wantFormat: "csv",
+		},
+		{
+			name:       "invalid for flag",
+			format:     "table",
+			wantFormat: "table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &GlobalOptions{
+				Config: &config.Config{Format: tt.format},
+			}
+
+			var json bool
+			var format string
+			cmd := &cobra.Command{}
+			cmd.Flags().BoolVar(&json, "json", false, "")
+			StringEnumVarP(cmd, &format, "format", "", "table", []string{"csv", "json", "table"}, "")
+			require.NoError(t, cmd.ParseFlags(tt.args))
+
+			applyConfigFormat(cmd, opts, "json")
+			applyConfigFormat(cmd, opts, "format")
+
+			assert.Equal(t, tt.wantJSON, json)
+			assert.Equal(t, tt.wantFormat, format)
+		})
+	}
+}
+
+func TestDefaultFields(t *testing.T) {
+	opts := &GlobalOptions{
+		Config: &config.Config{
+			Fields: map[string]string{"Status": "Triage", "Priority": "P2"},
+		},
+	}
+
+	assert.Equal(t, map[string]string{"Status": "Triage", "Priority": "P2"}, defaultFields(nil, opts))
+	assert.Equal(t,
+		map[string]string{"status": "Todo", "Priority": "P2"},
+		defaultFields(map[string]string{"status": "Todo"}, opts),
+	)
+	assert.Nil(t, defaultFields(nil, &GlobalOptions{}))
+}
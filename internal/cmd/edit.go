@@ -3,41 +3,53 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/MakeNowJust/heredoc"
-	"github.com/cli/go-gh"
 	"github.com/cli/go-gh/pkg/api"
 	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/cache"
 	"github.com/heaths/gh-projects/internal/models"
 	"github.com/heaths/gh-projects/internal/utils"
 	"github.com/heaths/go-console"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"golang.org/x/sync/errgroup"
 )
 
 func NewEditCmd(globalOpts *GlobalOptions, runFunc func(*editOptions) error) *cobra.Command {
 	var description, body string
 	var public bool
-	var addIssues, removeIssues []string
+	var addIssues, removeIssues, archiveIssues, unarchiveIssues []string
 	opts := editOptions{}
 	cmd := &cobra.Command{
 		Use:   "edit <number>",
 		Short: "Edit a project",
 		Long: heredoc.Doc(`
-			Updates project settings, and adds or removes draft issues, issues,
-			and pull requests.
+			Updates project settings, and adds, removes, archives, or unarchives
+			draft issues, issues, and pull requests.
 
-			The number argument can begin with a "#" symbol.
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
 
-			Pass "-" to --body to read from standard input.
+			Pass "-" to --body to read from standard input, or pass --edit-body to edit
+			the current body in your editor. The body is only saved if you changed it and
+			nobody else changed it while you were editing.
 
-			Issues and pull requests to add or remove from a project are referenced
+			Issues and pull requests to add, remove, archive, or unarchive are referenced
 			by their issue or pull request number for the specified repository. If a
 			repository is not specified, the current repository is used.
 
 			Issue and pull request number arguments can also begin with a "#" symbol.
+
+			Pass --with-subissues to also add sub-issues and tracked issues of added
+			issues, including those from other repositories, up to --depth levels below.
+			Pass --inherit with field names to copy their values from each parent item
+			to its sub-issues.
+
+			If no flags are passed in a terminal, you will be prompted for what to change.
 		`),
 		Example: heredoc.Doc(`
 			# make the project private
@@ -48,13 +60,23 @@ func NewEditCmd(globalOpts *GlobalOptions, runFunc func(*editOptions) error) *co
 			  Ship our _initial release_!
 			  EOF
 
+			# edit the body in your editor
+			$ gh projects edit 1 --edit-body
+
 			# add multiple issues to a project referenced by the current repository
 			$ gh projects edit 1 --add-issue 1 --add-issue 2
 
 			# add multiple issues to a project and set custom fields
 			$ gh projects edit 1 --add-issue 1,2 -f Status=Todo -f Iteration="Iteration 1"
+
+			# add an epic with its sub-issues, copying its iteration to them
+			$ gh projects edit 1 --add-issue 10 --with-subissues --inherit Iteration
+
+			# archive an issue without removing it from the project
+			$ gh projects edit 1 --archive-issue 3
 		`),
-		Args: ProjectNumberArg(&opts.number),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.GlobalOptions = *globalOpts
 
@@ -66,6 +88,14 @@ func NewEditCmd(globalOpts *GlobalOptions, runFunc func(*editOptions) error) *co
 				opts.body = &body
 			}
 
+			if opts.body != nil && opts.editBody {
+				return fmt.Errorf("specify only one of --body or --edit-body")
+			}
+
+			if opts.editBody && (!opts.Console.IsStdinTTY() || !opts.Console.IsStdoutTTY()) {
+				return fmt.Errorf("--edit-body requires a terminal")
+			}
+
 			if cmd.Flags().Changed("public") {
 				opts.public = &public
 			}
@@ -94,10 +124,50 @@ func NewEditCmd(globalOpts *GlobalOptions, runFunc func(*editOptions) error) *co
 				}
 			}
 
+			for _, issue := range archiveIssues {
+				issue, err := parseNumber(issue, "invalid issue number")
+				if err != nil {
+					return err
+				}
+
+				opts.archiveIssues = append(opts.archiveIssues, issue)
+			}
+
+			for _, issue := range unarchiveIssues {
+				issue, err := parseNumber(issue, "invalid issue number")
+				if err != nil {
+					return err
+				}
+
+				opts.unarchiveIssues = append(opts.unarchiveIssues, issue)
+			}
+
 			if len(opts.fields) > 0 && len(opts.addIssues) == 0 {
 				return fmt.Errorf("--field requires --add-issue")
 			}
 
+			if opts.withSubIssues && len(opts.addIssues) == 0 {
+				return fmt.Errorf("--with-subissues requires --add-issue")
+			}
+
+			if (cmd.Flags().Changed("depth") || len(opts.inherit) > 0) && !opts.withSubIssues {
+				return fmt.Errorf("--depth and --inherit require --with-subissues")
+			}
+
+			if len(opts.addIssues) > 0 {
+				opts.fields = defaultFields(opts.fields, &opts.GlobalOptions)
+			}
+
+			// Prompt for changes if only the project number was passed.
+			if opts.Console.IsStdinTTY() && opts.Console.IsStdoutTTY() {
+				opts.interactive = true
+				cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+					if f.Changed {
+						opts.interactive = false
+					}
+				})
+			}
+
 			if runFunc == nil {
 				runFunc = edit
 			}
@@ -112,13 +182,29 @@ func NewEditCmd(globalOpts *GlobalOptions, runFunc func(*editOptions) error) *co
 
 	// Need to pass globalOpts.Console since opts.GlobalOptions has not yet been set.
 	StdinStringVarP(cmd, globalOpts.Console.Stdin(), &body, "body", "b", "", "Set the new body")
+	cmd.Flags().BoolVarP(&opts.editBody, "edit-body", "e", false, "Edit the current body in your editor")
 	cmd.Flags().BoolVar(&public, "public", false, "Set the visibility")
 
 	cmd.Flags().StringSliceVar(&addIssues, "add-issue", nil, "Issues or pull requests to add")
 	cmd.Flags().StringSliceVar(&removeIssues, "remove-issue", nil, "Issues or pull requests to remove")
+	cmd.Flags().StringSliceVar(&archiveIssues, "archive-issue", nil, "Issues or pull requests to archive")
+	cmd.Flags().StringSliceVar(&unarchiveIssues, "unarchive-issue", nil, "Issues or pull requests to unarchive")
 
 	StringToStringVarP(cmd, &opts.fields, "field", "f", nil, "Set field values when adding issues")
 
+	cmd.Flags().BoolVar(&opts.withSubIssues, "with-subissues", false, "Also add sub-issues and tracked issues of added issues")
+	IntRangeVarP(cmd, &opts.depth, "depth", "", 3, 1, 10, "Levels of sub-issues to add")
+	cmd.Flags().StringSliceVar(&opts.inherit, "inherit", nil, "Copy values of `fields` from parent items to sub-issues")
+
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the project in the browser after editing")
+
+	_ = cmd.RegisterFlagCompletionFunc("add-issue", completeIssues(globalOpts, false))
+	_ = cmd.RegisterFlagCompletionFunc("remove-issue", completeIssues(globalOpts, true))
+	_ = cmd.RegisterFlagCompletionFunc("archive-issue", completeIssues(globalOpts, true))
+	_ = cmd.RegisterFlagCompletionFunc("unarchive-issue", completeIssues(globalOpts, true))
+	_ = cmd.RegisterFlagCompletionFunc("field", completeFieldValues(globalOpts))
+	_ = cmd.RegisterFlagCompletionFunc("inherit", completeFieldNames(globalOpts, settableDataTypes...))
+
 	return cmd
 }
 
@@ -134,21 +220,42 @@ type projectOptions struct {
 type editOptions struct {
 	projectOptions
 
-	addIssues    []int
-	removeIssues []int
+	addIssues       []int
+	removeIssues    []int
+	archiveIssues   []int
+	unarchiveIssues []int
+
+	editBody bool
 
 	fields map[string]string
 
+	withSubIssues bool
+	depth         int
+	inherit       []string
+
+	interactive bool
+	web         bool
 	workerCount int
 }
 
 func edit(opts *editOptions) (err error) {
-	clientOpts := &api.ClientOptions{
-		AuthToken: opts.authToken,
-		Host:      opts.host,
-		Log:       opts.Log,
+	for _, o := range []struct {
+		option string
+		set    bool
+	}{
+		{"--add-issue", len(opts.addIssues) > 0},
+		{"--remove-issue", len(opts.removeIssues) > 0},
+		{"--archive-issue", len(opts.archiveIssues) > 0},
+		{"--unarchive-issue", len(opts.unarchiveIssues) > 0},
+	} {
+		if o.set {
+			if err = requireRepository(&opts.GlobalOptions, o.option); err != nil {
+				return
+			}
+		}
 	}
-	client, err := gh.GQLClient(clientOpts)
+
+	client, err := newWorkerClient(&opts.GlobalOptions, opts.workerCount)
 	if err != nil {
 		return
 	}
@@ -159,15 +266,59 @@ func edit(opts *editOptions) (err error) {
 		"number": opts.number,
 	}
 
-	project, err := getProject(client, vars, opts)
+	cached := opts.Cache.Load(opts.Repo.Host(), opts.Repo.Owner(), opts.number)
+	fromCache := cached.ID != "" || cached.Fields != nil || cached.Items != nil
+
+	project, err := getProject(client, cached, vars, opts)
 	if err != nil {
 		return
 	}
 
-	projectID := project.ID
+	if opts.interactive {
+		err = promptEdit(client, project.ID, cached, opts)
+		if err != nil {
+			return
+		}
+	}
+
+	// Retry an operation once with fresh metadata if a cached ID was not found e.g., a field was recreated.
+	retryStale := func(op func() error) error {
+		err := op()
+		if err == nil || !fromCache || utils.AsGQLError(err, "NOT_FOUND") == nil {
+			return err
+		}
+
+		if opts.Log != nil {
+			fmt.Fprintf(opts.Log, "Refreshing cached project #%d: %v\n", opts.number, err)
+		}
+
+		fromCache = false
+		if err = opts.Cache.Remove(opts.Repo.Host(), opts.Repo.Owner(), opts.number); err != nil {
+			return err
+		}
+
+		*cached = cache.Project{}
+		if project, err = getProject(client, cached, vars, opts); err != nil {
+			return err
+		}
+
+		return op()
+	}
+
 	projectURL := project.URL
 
-	err = editProject(client, projectID, opts.title != "", &opts.projectOptions)
+	if opts.editBody {
+		err = retryStale(func() error {
+			return editBody(client, project.ID, opts)
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	err = retryStale(func() error {
+		return editProject(client, project.ID, opts.title != "", &opts.projectOptions)
+	})
 	if err != nil {
 		return
 	}
@@ -176,7 +327,9 @@ func edit(opts *editOptions) (err error) {
 		count := text.Pluralize(len(opts.addIssues), "issue")
 
 		opts.Console.StartProgress(fmt.Sprintf("Adding %s to %s", count, projectURL))
-		err = addIssues(client, projectID, opts)
+		err = retryStale(func() error {
+			return addIssues(client, project.ID, cached, opts)
+		})
 		opts.Console.StopProgress()
 
 		if err != nil {
@@ -192,7 +345,9 @@ func edit(opts *editOptions) (err error) {
 		count := text.Pluralize(len(opts.removeIssues), "issue")
 
 		opts.Console.StartProgress(fmt.Sprintf("Removing %s %s", count, projectURL))
-		err = removeItems(client, projectID, opts)
+		err = retryStale(func() error {
+			return removeItems(client, project.ID, cached, opts)
+		})
 		opts.Console.StopProgress()
 
 		if err != nil {
@@ -204,6 +359,50 @@ func edit(opts *editOptions) (err error) {
 		}
 	}
 
+	if len(opts.archiveIssues) > 0 {
+		count := text.Pluralize(len(opts.archiveIssues), "issue")
+
+		opts.Console.StartProgress(fmt.Sprintf("Archiving %s in %s", count, projectURL))
+		err = retryStale(func() error {
+			return archiveItems(client, project.ID, cached, &opts.archiveIssues, true, opts)
+		})
+		opts.Console.StopProgress()
+
+		if err != nil {
+			return
+		}
+
+		if opts.Verbose && opts.Console.IsStdoutTTY() {
+			fmt.Fprintf(opts.Console.Stdout(), "Archived %s\n", count)
+		}
+	}
+
+	if len(opts.unarchiveIssues) > 0 {
+		count := text.Pluralize(len(opts.unarchiveIssues), "issue")
+
+		opts.Console.StartProgress(fmt.Sprintf("Unarchiving %s in %s", count, projectURL))
+		err = retryStale(func() error {
+			return archiveItems(client, project.ID, cached, &opts.unarchiveIssues, false, opts)
+		})
+		opts.Console.StopProgress()
+
+		if err != nil {
+			return
+		}
+
+		if opts.Verbose && opts.Console.IsStdoutTTY() {
+			fmt.Fprintf(opts.Console.Stdout(), "Unarchived %s\n", count)
+		}
+	}
+
+	if err := opts.Cache.Save(opts.Repo.Host(), opts.Repo.Owner(), opts.number, cached); err != nil && opts.Log != nil {
+		fmt.Fprintf(opts.Log, "Failed to cache project #%d: %v\n", opts.number, err)
+	}
+
+	if opts.web {
+		return openInBrowser(&opts.GlobalOptions, projectURL)
+	}
+
 	if opts.Console.IsStdoutTTY() {
 		fmt.Fprintf(opts.Console.Stdout(), "%s\n", projectURL)
 	}
@@ -211,15 +410,37 @@ func edit(opts *editOptions) (err error) {
 	return
 }
 
-func getProject(client api.GQLClient, vars map[string]interface{}, opts *editOptions) (*models.Project, error) {
+func getProject(client api.GQLClient, cached *cache.Project, vars map[string]interface{}, opts *editOptions) (*models.Project, error) {
+	repo := fmt.Sprintf("%s/%s", vars["owner"], vars["name"])
+	if cached.ID != "" && (vars["name"] == "" || cached.HasRepository(repo)) {
+		return &models.Project{
+			ID:  cached.ID,
+			URL: cached.URL,
+		}, nil
+	}
+
+	// A project URL or alias for another owner has no repository to link.
+	if vars["name"] == "" {
+		project, err := getOwnerProject(client, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.Cache.SetProject(cached, project.ID, project.URL)
+		return project, nil
+	}
+
 	var projectData models.RepositoryProject
 	err := client.Do(queryRepositoryProjectV2ID, vars, &projectData)
 	if err != nil && utils.AsGQLError(err, "NOT_FOUND") == nil {
 		return nil, err
 	}
 
-	if projectData.Repository.ProjectV2 != nil {
-		return projectData.Repository.ProjectV2, nil
+	if project := projectData.Repository.ProjectV2; project != nil {
+		opts.Cache.SetProject(cached, project.ID, project.URL)
+		cached.AddRepository(repo)
+
+		return project, nil
 	}
 
 	// Link the project if defined by an organization or user.
@@ -228,10 +449,9 @@ func getProject(client api.GQLClient, vars map[string]interface{}, opts *editOpt
 		return nil, err
 	}
 
-	if projectData.Repository.ProjectV2 != nil {
-		repo := fmt.Sprintf("%s/%s", vars["owner"], vars["name"])
+	if project := projectData.Repository.ProjectV2; project != nil {
 		linkVars := map[string]interface{}{
-			"projectId":    projectData.Repository.ProjectV2.ID,
+			"projectId":    project.ID,
 			"repositoryId": projectData.Repository.Repository.ID,
 		}
 
@@ -247,12 +467,34 @@ func getProject(client api.GQLClient, vars map[string]interface{}, opts *editOpt
 			return nil, fmt.Errorf("failed to link project #%d to %q: %w", vars["number"], repo, err)
 		}
 
-		return projectData.Repository.ProjectV2, nil
+		opts.Cache.SetProject(cached, project.ID, project.URL)
+		cached.AddRepository(repo)
+
+		return project, nil
 	}
 
 	return nil, fmt.Errorf("project #%d not found for %s %q", vars["number"], projectData.Repository.Type, vars["owner"])
 }
 
+// getOwnerProject gets a project defined by an organization or user without a repository.
+func getOwnerProject(client api.GQLClient, vars map[string]interface{}) (*models.Project, error) {
+	var projectData models.RepositoryProject
+	err := client.Do(queryRepositoryOwnerProjectV2OwnerID, map[string]interface{}{
+		"owner":  vars["owner"],
+		"number": vars["number"],
+	}, &projectData)
+	if err != nil {
+		return nil, err
+	}
+
+	project := projectData.Repository.ProjectV2
+	if project == nil {
+		return nil, fmt.Errorf("project #%d not found for %s %q", vars["number"], projectData.Repository.Type, vars["owner"])
+	}
+
+	return project, nil
+}
+
 func editProject(client api.GQLClient, projectID string, requiresUpdate bool, opts *projectOptions) (err error) {
 	vars := map[string]interface{}{
 		"id": projectID,
@@ -285,68 +527,169 @@ func editProject(client api.GQLClient, projectID string, requiresUpdate bool, op
 	return
 }
 
-func addIssues(client api.GQLClient, projectID string, opts *editOptions) (err error) {
+// editBody opens the current project body in an editor and sets opts.body if changed.
+// If the body was changed by someone else while editing, the edited body is saved
+// to a temporary file and an error is returned instead of overwriting their changes.
+func editBody(client api.GQLClient, projectID string, opts *editOptions) error {
+	getBody := func() (string, error) {
+		var data struct {
+			Node *models.Project
+		}
+
+		err := client.Do(queryProjectV2Node, map[string]interface{}{"id": projectID}, &data)
+		if err != nil {
+			return "", err
+		}
+
+		if data.Node == nil {
+			return "", fmt.Errorf("project #%d not found", opts.number)
+		}
+
+		return data.Node.Body, nil
+	}
+
+	original, err := getBody()
+	if err != nil {
+		return err
+	}
+
+	body, err := newEditor(&opts.GlobalOptions).Edit("*.md", original)
+	if err != nil {
+		return err
+	}
+
+	if body == original {
+		fmt.Fprintln(opts.Console.Stderr(), "Body unchanged")
+		return nil
+	}
+
+	current, err := getBody()
+	if err != nil {
+		return err
+	}
+
+	if current != original {
+		f, err := os.CreateTemp("", "gh-projects-body-*.md")
+		if err != nil {
+			return fmt.Errorf("body was changed by someone else while editing: %w", err)
+		}
+		defer f.Close()
+
+		if _, err = f.WriteString(body); err != nil {
+			return fmt.Errorf("body was changed by someone else while editing: %w", err)
+		}
+
+		return fmt.Errorf("body was changed by someone else while editing; your changes were saved to %s", f.Name())
+	}
+
+	opts.body = &body
+	return nil
+}
+
+// issueRef is an issue or pull request to add to a project.
+type issueRef struct {
+	// number is the issue or pull request number in the current repository, or 0 if in another repository.
+	number int
+
+	// contentID is the ID of the issue or pull request, or empty if not yet resolved.
+	contentID string
+
+	// parentID is the content ID of the parent of a sub-issue.
+	parentID string
+
+	// depth is the number of levels a sub-issue is below an issue passed to --add-issue.
+	depth int
+}
+
+func addIssues(client api.GQLClient, projectID string, cached *cache.Project, opts *editOptions) (err error) {
 	var fields map[string]models.Field
 	if len(opts.fields) > 0 {
-		fields, err = getFields(client, opts)
+		fields, err = getFields(client, cached, opts)
 		if err != nil {
 			return
 		}
 	}
 
-	workerCount := opts.workerCount
-	if workerCount < 1 {
-		workerCount = DefaultWorkerCount
+	// Numbers passed to --add-issue are in the current repository.
+	repo := opts.Repo.Owner() + "/" + opts.Repo.Name()
+	refs := make([]issueRef, len(opts.addIssues))
+	for i, number := range opts.addIssues {
+		refs[i].number = number
 	}
-	if issueCount := len(opts.addIssues); workerCount > issueCount {
-		workerCount = issueCount
+
+	if opts.withSubIssues {
+		refs, err = withSubIssues(client, refs, opts)
+		if err != nil {
+			return
+		}
 	}
 
-	issues := make(chan int)
+	// Each worker resolves, adds, and updates a batch of issues using as few requests as possible.
+	batches := utils.Chunk(refs, BatchSize)
+
+	workerCount := effectiveWorkerCount(&opts.GlobalOptions, opts.workerCount)
+	if batchCount := len(batches); workerCount > batchCount {
+		workerCount = batchCount
+	}
+
+	// Project item IDs keyed by content ID are needed to copy fields from parent items.
+	var mu sync.Mutex
+	added := make(map[string]string, len(refs))
+
+	issues := make(chan []issueRef)
 	wg, ctx := errgroup.WithContext(context.Background())
 
 	for i := 0; i < workerCount; i++ {
 		wg.Go(func() error {
-			vars := map[string]interface{}{
-				"owner": opts.Repo.Owner(),
-				"name":  opts.Repo.Name(),
-				"id":    projectID,
-			}
-
 			for {
 				select {
 				case <-ctx.Done():
 					return nil
-				case n, ok := <-issues:
+				case batch, ok := <-issues:
 					if !ok {
 						return nil
 					}
 
-					vars["number"] = n
-
-					var data models.RepositoryIssueOrPullRequest
-					err := client.Do(queryRepositoryIssueOrPullRequestID, vars, &data)
-					if err != nil {
-						return err
+					var numbers []int
+					for _, ref := range batch {
+						if ref.contentID == "" {
+							numbers = append(numbers, ref.number)
+						}
 					}
 
-					contentID := data.Repository.IssueOrPullRequest.ID
-					vars["contentId"] = contentID
+					var resolved []string
+					if len(numbers) > 0 {
+						var err error
+						resolved, err = getContentIDs(client, &opts.GlobalOptions, numbers)
+						if err != nil {
+							return err
+						}
+					}
 
-					var mutationData struct {
-						AddProjectV2ItemByID struct {
-							Item models.ProjectItem
+					contentIDs := make([]string, len(batch))
+					for i, ref := range batch {
+						if ref.contentID == "" {
+							ref.contentID, resolved = resolved[0], resolved[1:]
 						}
+						contentIDs[i] = ref.contentID
 					}
 
-					err = client.Do(mutationAddProjectV2Item, vars, &mutationData)
+					itemIDs, err := addItems(client, projectID, contentIDs)
 					if err != nil {
 						return err
 					}
 
+					mu.Lock()
+					for i, ref := range batch {
+						if ref.number != 0 {
+							cached.SetItem(repo, ref.number, itemIDs[i])
+						}
+						added[contentIDs[i]] = itemIDs[i]
+					}
+					mu.Unlock()
+
 					if len(fields) > 0 {
-						itemID := mutationData.AddProjectV2ItemByID.Item.ID
-						err = updateFields(client, projectID, itemID, fields, opts)
+						err = updateItemsFields(client, projectID, itemIDs, fields)
 						if err != nil {
 							return err
 						}
@@ -356,128 +699,130 @@ func addIssues(client api.GQLClient, projectID string, opts *editOptions) (err e
 		})
 	}
 
-	for _, issue := range opts.addIssues {
-		issues <- issue
+	// Stop sending batches if any worker failed.
+send:
+	for _, batch := range batches {
+		select {
+		case <-ctx.Done():
+			break send
+		case issues <- batch:
+		}
 	}
 
 	close(issues)
 	err = wg.Wait()
+	if err != nil {
+		return
+	}
+
+	if len(opts.inherit) > 0 {
+		err = inheritFields(client, projectID, cached, refs, added, opts)
+	}
 
 	return
 }
 
-func getFields(client api.GQLClient, opts *editOptions) (map[string]models.Field, error) {
-	vars := map[string]interface{}{
-		"owner":  opts.Repo.Owner(),
-		"name":   opts.Repo.Name(),
-		"number": opts.number,
-	}
-
-	var data struct {
-		Repository struct {
-			ProjectV2 struct {
-				Fields struct {
-					Nodes    []models.ProjectField
-					PageInfo struct {
-						HasNextPage bool
-						EndCursor   string
-					}
-				}
+func getFields(client api.GQLClient, cached *cache.Project, opts *editOptions) (map[string]models.Field, error) {
+	// Fields or options may have been added since they were cached, so fetch them if not all are resolved.
+	if cached.Fields != nil {
+		fields := make(map[string]models.Field, len(opts.fields))
+		for name, value := range opts.fields {
+			field, err := resolveField(cached.Fields, name, value)
+			if err != nil || field == nil {
+				break
 			}
+
+			fields[name] = *field
+		}
+
+		if len(fields) == len(opts.fields) {
+			return fields, nil
 		}
 	}
 
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return nil, err
+	}
+	opts.Cache.SetFields(cached, projectFields)
+
 	fields := make(map[string]models.Field, len(opts.fields))
-	for {
-		err := client.Do(queryRepositoryProjectV2Fields, vars, &data)
+	for name, value := range opts.fields {
+		field, err := resolveField(projectFields, name, value)
 		if err != nil {
 			return nil, err
+		} else if field == nil {
+			return nil, fmt.Errorf("field %q not defined", name)
 		}
 
-		hasNextPage := data.Repository.ProjectV2.Fields.PageInfo.HasNextPage
-		for name, value := range opts.fields {
-			found := false
-			for _, projectField := range data.Repository.ProjectV2.Fields.Nodes {
-				if strings.EqualFold(name, projectField.Name) {
-					field, err := models.NewField(projectField, value)
-					if err != nil {
-						return nil, err
-					}
-
-					fields[name] = *field
-					found = true
-					delete(opts.fields, name)
-					break
-				}
-			}
+		fields[name] = *field
+	}
 
-			if !found && !hasNextPage {
-				return nil, fmt.Errorf("field %q not defined", name)
-			}
-		}
+	return fields, nil
+}
 
-		// Only fetch more pages if some specified fields haven't been found.
-		if hasNextPage && len(opts.fields) > 0 {
-			vars["after"] = data.Repository.ProjectV2.Fields.PageInfo.EndCursor
-		} else {
-			break
+// resolveField gets the named field with the given value, or nil if the field is not defined.
+func resolveField(projectFields []models.ProjectField, name, value string) (*models.Field, error) {
+	for _, projectField := range projectFields {
+		if strings.EqualFold(name, projectField.Name) {
+			return models.NewField(projectField, value)
 		}
 	}
 
-	return fields, nil
+	return nil, nil
 }
 
-func updateFields(client api.GQLClient, projectID, itemID string, fields map[string]models.Field, opts *editOptions) error {
+func removeItems(client api.GQLClient, projectID string, cached *cache.Project, opts *editOptions) (err error) {
+	projectItemIDs, err := getItemIDs(client, cached, opts.removeIssues, opts)
+	if err != nil {
+		return
+	}
+
 	vars := map[string]interface{}{
-		"projectId": projectID,
-		"itemId":    itemID,
+		"id": projectID,
 	}
 
-	// Fields should be indexed in both maps based on user-specified casing.
-	for name, field := range fields {
-		vars["fieldId"] = field.ID
-		vars["value"] = field.Value
+	for i, itemID := range projectItemIDs {
+		vars["itemId"] = itemID
 
-		var data interface{}
-		err := client.Do(mutationUpdateProjectV2ItemFieldValue, vars, &data)
+		var mutationData map[string]interface{}
+		err = client.Do(mutationDeleteProjectV2Item, vars, &mutationData)
 		if err != nil {
-			return fmt.Errorf("failed to update field %q: %w", name, err)
+			// Only retry removing items that were not yet removed.
+			opts.removeIssues = opts.removeIssues[i:]
+			return
 		}
+
+		cached.RemoveItem(opts.Repo.Owner()+"/"+opts.Repo.Name(), opts.removeIssues[i])
 	}
 
-	return nil
+	return
 }
 
-func removeItems(client api.GQLClient, projectID string, opts *editOptions) (err error) {
-	items, err := listItems(client, int(opts.number), &opts.GlobalOptions)
+// archiveItems archives or unarchives items for issues. Issues that were archived or unarchived are removed from issues.
+func archiveItems(client api.GQLClient, projectID string, cached *cache.Project, issues *[]int, archive bool, opts *editOptions) (err error) {
+	projectItemIDs, err := getItemIDs(client, cached, *issues, opts)
 	if err != nil {
 		return
 	}
 
-	itemIds := make(map[int]string, len(items))
-	for _, item := range items {
-		itemIds[item.Content.Number] = item.ID
-	}
-
-	projectItemIDs := make([]string, len(opts.removeIssues))
-	for i, issue := range opts.removeIssues {
-		if projectItemID, ok := itemIds[issue]; !ok {
-			return fmt.Errorf("project does not reference #%d", issue)
-		} else {
-			projectItemIDs[i] = projectItemID
-		}
+	mutation := mutationArchiveProjectV2Item
+	if !archive {
+		mutation = mutationUnarchiveProjectV2Item
 	}
 
 	vars := map[string]interface{}{
-		"id": projectID,
+		"projectId": projectID,
 	}
 
-	for _, itemID := range projectItemIDs {
+	for i, itemID := range projectItemIDs {
 		vars["itemId"] = itemID
 
 		var mutationData map[string]interface{}
-		err = client.Do(mutationDeleteProjectV2Item, vars, &mutationData)
+		err = client.Do(mutation, vars, &mutationData)
 		if err != nil {
+			// Only retry items that were not yet archived or unarchived.
+			*issues = (*issues)[i:]
 			return
 		}
 	}
@@ -485,6 +830,49 @@ func removeItems(client api.GQLClient, projectID string, opts *editOptions) (err
 	return
 }
 
+// getItemIDs gets the project item IDs for issues in the current repository from the cache, or lists items if any are not cached.
+func getItemIDs(client api.GQLClient, cached *cache.Project, issues []int, opts *editOptions) ([]string, error) {
+	repo := opts.Repo.Owner() + "/" + opts.Repo.Name()
+	projectItemIDs := make([]string, len(issues))
+	for i, issue := range issues {
+		if projectItemID, ok := cached.Item(repo, issue); ok {
+			projectItemIDs[i] = projectItemID
+		} else {
+			projectItemIDs = nil
+			break
+		}
+	}
+
+	if projectItemIDs != nil {
+		return projectItemIDs, nil
+	}
+
+	items, err := listItems(client, int(opts.number), &opts.GlobalOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Projects may contain issues with the same number from other repositories.
+	itemIds := make(map[string]string, len(items))
+	for _, item := range items {
+		if item.Content.Repository != nil {
+			itemIds[cache.ItemKey(item.Content.Repository.NameWithOwner, item.Content.Number)] = item.ID
+		}
+	}
+	opts.Cache.SetItems(cached, itemIds)
+
+	projectItemIDs = make([]string, len(issues))
+	for i, issue := range issues {
+		if projectItemID, ok := itemIds[cache.ItemKey(repo, issue)]; !ok {
+			return nil, fmt.Errorf("project does not reference #%d", issue)
+		} else {
+			projectItemIDs[i] = projectItemID
+		}
+	}
+
+	return projectItemIDs, nil
+}
+
 func listItems(client api.GQLClient, number int, opts *GlobalOptions) ([]models.ProjectItem, error) {
 	vars := map[string]interface{}{
 		"owner":  opts.Repo.Owner(),
@@ -526,16 +914,6 @@ func listItems(client api.GQLClient, number int, opts *GlobalOptions) ([]models.
 	return projectItems, nil
 }
 
-const mutationAddProjectV2Item = `
-mutation AddProjectV2ItemById($id: ID!, $contentId: ID!) {
-	addProjectV2ItemById(input: {projectId: $id, contentId: $contentId}) {
-		item {
-			id
-		}
-	}
-}
-`
-
 const mutationDeleteProjectV2Item = `
 mutation DeleteProjectV2Item($id: ID!, $itemId: ID!) {
 	deleteProjectV2Item(input: {projectId: $id, itemId: $itemId}) {
@@ -544,16 +922,21 @@ mutation DeleteProjectV2Item($id: ID!, $itemId: ID!) {
 }
 `
 
-const queryRepositoryIssueOrPullRequestID = `
-query RepositoryIssueOrPullRequestID($owner: String!, $name: String!, $number: Int!) {
-	repository(owner: $owner, name: $name) {
-		issueOrPullRequest(number: $number) {
-			... on Issue {
-				id
-			}
-			... on PullRequest {
-				id
-			}
+const mutationArchiveProjectV2Item = `
+mutation ArchiveProjectV2Item($projectId: ID!, $itemId: ID!) {
+	archiveProjectV2Item(input: {projectId: $projectId, itemId: $itemId}) {
+		item {
+			id
+		}
+	}
+}
+`
+
+const mutationUnarchiveProjectV2Item = `
+mutation UnarchiveProjectV2Item($projectId: ID!, $itemId: ID!) {
+	unarchiveProjectV2Item(input: {projectId: $projectId, itemId: $itemId}) {
+		item {
+			id
 		}
 	}
 }
@@ -561,6 +944,11 @@ query RepositoryIssueOrPullRequestID($owner: String!, $name: String!, $number: I
 
 const queryRepositoryProjectV2Items = `
 query RepositoryProjectV2Items($owner: String!, $name: String!, $number: Int!, $first: Int!, $after: String) {
+	rateLimit {
+		cost
+		remaining
+		resetAt
+	}
 	repository(owner: $owner, name: $name) {
 		projectV2(number: $number) {
 			items(first: $first, after: $after) {
@@ -571,10 +959,16 @@ query RepositoryProjectV2Items($owner: String!, $name: String!, $number: Int!, $
 						... on Issue {
 							id
 							number
+							repository {
+								nameWithOwner
+							}
 						}
 						... on PullRequest {
 							id
 							number
+							repository {
+								nameWithOwner
+							}
 						}
 					}
 				}
@@ -632,66 +1026,30 @@ query RepositoryOwnerProjectV2ID($owner: String!, $name: String!, $number: Int!)
 }
 `
 
-const mutationLinkProjectV2ToRepository = `
-mutation LinkProjectV2ToRepository($projectId: ID!, $repositoryId: ID!) {
-	linkProjectV2ToRepository(
-		input: {projectId: $projectId, repositoryId: $repositoryId}
-	) {
-		repository {
-			id
-		}
+const queryRepositoryOwnerProjectV2OwnerID = `
+query RepositoryOwnerProjectV2OwnerID($owner: String!, $number: Int!) {
+	viewer {
+		id
 	}
-}
-`
-
-const queryRepositoryProjectV2Fields = `
-query RepositoryProjectV2Fields($owner: String!, $name: String!, $number: Int!, $after: String) {
-	repository(owner: $owner, name: $name) {
-		projectV2(number: $number) {
-			fields(first: 30, after: $after) {
-				nodes {
-					...on ProjectV2Field {
-						id
-						name
-						dataType
-					}
-					...on ProjectV2IterationField {
-						id
-						name
-						dataType
-						configuration {
-							iterations {
-								id
-								name: title
-							}
-						}
-					}
-					...on ProjectV2SingleSelectField {
-						id
-						name
-						dataType
-						options {
-							id
-							name
-						}
-					}
-				}
-				pageInfo {
-					hasNextPage
-					endCursor
-				}
+	repository: repositoryOwner(login: $owner) {
+		type: __typename
+		... on ProjectV2Owner {
+			projectV2(number: $number) {
+				id
+				url
+				public
 			}
 		}
 	}
 }
 `
 
-const mutationUpdateProjectV2ItemFieldValue = `
-mutation UpdateProjectV2ItemFieldValue($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
-	updateProjectV2ItemFieldValue(
-		input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId, value: $value}
+const mutationLinkProjectV2ToRepository = `
+mutation LinkProjectV2ToRepository($projectId: ID!, $repositoryId: ID!) {
+	linkProjectV2ToRepository(
+		input: {projectId: $projectId, repositoryId: $repositoryId}
 	) {
-		projectV2Item {
+		repository {
 			id
 		}
 	}
@@ -3,14 +3,19 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/MakeNowJust/heredoc"
 	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/client"
+	"github.com/heaths/gh-projects/internal/config"
 	"github.com/heaths/gh-projects/internal/utils"
 	"github.com/heaths/go-console"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gopkg.in/h2non/gock.v1"
 )
 
@@ -19,6 +24,7 @@ func TestNewEditCmd(t *testing.T) {
 		name     string
 		args     []string
 		stdin    *bytes.Buffer
+		tty      bool
 		wantOpts *editOptions
 		wantErr  string
 	}{
@@ -28,8 +34,8 @@ func TestNewEditCmd(t *testing.T) {
 		},
 		{
 			name:    "invalid project number",
-			args:    []string{"test"},
-			wantErr: "invalid project number: test",
+			args:    []string{"#test"},
+			wantErr: "invalid project number: #test",
 		},
 		{
 			name: "only project number",
@@ -89,6 +95,22 @@ func TestNewEditCmd(t *testing.T) {
 				removeIssues: []int{2},
 			},
 		},
+		{
+			name: "archive and unarchive",
+			args: []string{"1", "--archive-issue", "2,#3", "--unarchive-issue", "4"},
+			wantOpts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+				},
+				archiveIssues:   []int{2, 3},
+				unarchiveIssues: []int{4},
+			},
+		},
+		{
+			name:    "invalid archive issue number",
+			args:    []string{"1", "--archive-issue", "test"},
+			wantErr: "invalid issue number: test",
+		},
 		{
 			name: "single field",
 			args: []string{"1", "--add-issue", "2", "-f", "Status=Done"},
@@ -117,16 +139,83 @@ func TestNewEditCmd(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "with sub-issues",
+			args: []string{"1", "--add-issue", "10", "--with-subissues", "--depth", "2", "--inherit", "Iteration"},
+			wantOpts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+				},
+				addIssues:     []int{10},
+				withSubIssues: true,
+				depth:         2,
+				inherit:       []string{"Iteration"},
+			},
+		},
+		{
+			name:    "sub-issues require issues",
+			args:    []string{"1", "--with-subissues"},
+			wantErr: "--with-subissues requires --add-issue",
+		},
+		{
+			name:    "inherit requires sub-issues",
+			args:    []string{"1", "--add-issue", "10", "--inherit", "Iteration"},
+			wantErr: "--depth and --inherit require --with-subissues",
+		},
 		{
 			name:    "fields require issues",
 			args:    []string{"1", "--field", "Status=Done"},
 			wantErr: "--field requires --add-issue",
 		},
+		{
+			name: "edit body",
+			args: []string{"1", "--edit-body"},
+			tty:  true,
+			wantOpts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+				},
+				editBody: true,
+			},
+		},
+		{
+			name:    "edit body with body",
+			args:    []string{"1", "--edit-body", "--body", "body"},
+			tty:     true,
+			wantErr: "specify only one of --body or --edit-body",
+		},
+		{
+			name:    "edit body requires terminal",
+			args:    []string{"1", "--edit-body"},
+			wantErr: "--edit-body requires a terminal",
+		},
+		{
+			name: "interactive",
+			args: []string{"1"},
+			tty:  true,
+			wantOpts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+				},
+				interactive: true,
+			},
+		},
+		{
+			name: "not interactive with flags",
+			args: []string{"1", "--public"},
+			tty:  true,
+			wantOpts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+					public: utils.Ptr(true),
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			fake := console.Fake()
+			fake := console.Fake(console.WithStdinTTY(tt.tty), console.WithStdoutTTY(tt.tty))
 			if tt.stdin != nil {
 				_, _, stdin := fake.Buffers()
 				*stdin = *tt.stdin
@@ -159,19 +248,29 @@ func TestNewEditCmd(t *testing.T) {
 			assert.Equal(t, tt.wantOpts.public, gotOpts.public)
 			assert.Equal(t, tt.wantOpts.addIssues, gotOpts.addIssues)
 			assert.Equal(t, tt.wantOpts.removeIssues, gotOpts.removeIssues)
+			assert.Equal(t, tt.wantOpts.archiveIssues, gotOpts.archiveIssues)
+			assert.Equal(t, tt.wantOpts.unarchiveIssues, gotOpts.unarchiveIssues)
+			assert.Equal(t, tt.wantOpts.editBody, gotOpts.editBody)
 			assert.Equal(t, tt.wantOpts.fields, gotOpts.fields)
+			assert.Equal(t, tt.wantOpts.withSubIssues, gotOpts.withSubIssues)
+			assert.Equal(t, tt.wantOpts.inherit, gotOpts.inherit)
+			if tt.wantOpts.withSubIssues {
+				assert.Equal(t, tt.wantOpts.depth, gotOpts.depth)
+			}
+			assert.Equal(t, tt.wantOpts.interactive, gotOpts.interactive)
 		})
 	}
 }
 
 func TestEdit(t *testing.T) {
 	tests := []struct {
-		name       string
-		opts       *editOptions
-		tty        bool
-		mocks      func()
-		wantStdout string
-		wantErr    string
+		name        string
+		opts        *editOptions
+		tty         bool
+		mocks       func()
+		wantStdout  string
+		wantBrowsed string
+		wantErr     string
 	}{
 		{
 			name: "change title",
@@ -209,6 +308,32 @@ func TestEdit(t *testing.T) {
 					}`)
 			},
 		},
+		{
+			name: "web",
+			opts: &editOptions{
+				projectOptions: projectOptions{
+					number: 1,
+				},
+				web: true,
+			},
+			tty: true,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"repository": {
+								"projectV2": {
+									"id": "PN_1",
+									"url": "https://github.com/users/heaths/projects/1"
+								}
+							}
+						}
+					}`)
+			},
+			wantBrowsed: "https://github.com/users/heaths/projects/1",
+		},
 		{
 			name: "change title (tty)",
 			opts: &editOptions{
@@ -277,7 +402,7 @@ func TestEdit(t *testing.T) {
 							}
 						}
 					}`)
-				// getFields also testing paging and short-circuiting subsequent page fetches
+				// getFields also testing paging
 				gock.New("https://api.github.com").
 					Post("/graphql").
 					Reply(200).
@@ -354,23 +479,25 @@ func TestEdit(t *testing.T) {
 											}
 										],
 										"pageInfo": {
-											"hasNextPage": true,
-											"endCursor": "PAGE3"
+											"hasNextPage": false
 										}
 									}
 								}
 							}
 						}
 					}`)
-				// addIssue 1
+				// addIssues resolves both issues in a single query
 				gock.New("https://api.github.com").
 					Post("/graphql").
 					Reply(200).
 					JSON(`{
 						"data": {
 							"repository": {
-								"issueOrPullRequest": {
+								"i0": {
 									"id": "I_2"
+								},
+								"i1": {
+									"id": "I_3"
 								}
 							}
 						}
@@ -380,21 +507,14 @@ func TestEdit(t *testing.T) {
 					Reply(200).
 					JSON(`{
 						"data": {
-							"addProjectV2ItemById": {
+							"a0": {
 								"item": {
 									"id": "PNI_2"
 								}
-							}
-						}
-					}`)
-				gock.New("https://api.github.com").
-					Post("/graphql").
-					Reply(200).
-					JSON(`{
-						"data": {
-							"updateProjectV2ItemFieldValue": {
-								"projectV2Item": {
-									"id": "PNI_1"
+							},
+							"a1": {
+								"item": {
+									"id": "PNI_3"
 								}
 							}
 						}
@@ -404,58 +524,24 @@ func TestEdit(t *testing.T) {
 					Reply(200).
 					JSON(`{
 						"data": {
-							"updateProjectV2ItemFieldValue": {
+							"u0": {
 								"projectV2Item": {
-									"id": "PNI_1"
-								}
-							}
-						}
-					}`)
-				// addIssue 2
-				gock.New("https://api.github.com").
-					Post("/graphql").
-					Reply(200).
-					JSON(`{
-						"data": {
-							"repository": {
-								"issueOrPullRequest": {
-									"id": "I_2"
+									"id": "PNI_2"
 								}
-							}
-						}
-					}`)
-				gock.New("https://api.github.com").
-					Post("/graphql").
-					Reply(200).
-					JSON(`{
-						"data": {
-							"addProjectV2ItemById": {
-								"item": {
+							},
+							"u1": {
+								"projectV2Item": {
 									"id": "PNI_2"
 								}
-							}
-						}
-					}`)
-				gock.New("https://api.github.com").
-					Post("/graphql").
-					Reply(200).
-					JSON(`{
-						"data": {
-							"updateProjectV2ItemFieldValue": {
+							},
+							"u2": {
 								"projectV2Item": {
-									"id": "PNI_1"
+									"id": "PNI_3"
 								}
-							}
-						}
-					}`)
-				gock.New("https://api.github.com").
-					Post("/graphql").
-					Reply(200).
-					JSON(`{
-						"data": {
-							"updateProjectV2ItemFieldValue": {
+							},
+							"u3": {
 								"projectV2Item": {
-									"id": "PNI_1"
+									"id": "PNI_3"
 								}
 							}
 						}
@@ -649,8 +735,7 @@ func TestEdit(t *testing.T) {
 											}
 										],
 										"pageInfo": {
-											"hasNextPage": true,
-											"endCursor": "PAGE2"
+											"hasNextPage": false
 										}
 									}
 								}
@@ -664,12 +749,13 @@ func TestEdit(t *testing.T) {
 					JSON(`{
 						"data": {
 							"repository": {
-								"issueOrPullRequest": null
+								"i0": null
 							}
 						},
 						"errors": [
 							{
 								"type": "NOT_FOUND",
+								"path": ["repository", "i0"],
 								"message": "Could not resolve to an issue or pull request with the number of 99."
 							}
 						]
@@ -784,14 +870,14 @@ func TestEdit(t *testing.T) {
 							}
 						}
 					}`)
-				// Add issue 2
+				// Add issue 2 in a batch
 				gock.New("https://api.github.com").
 					Post("/graphql").
 					Reply(200).
 					JSON(`{
 						"data": {
 							"repository": {
-								"issueOrPullRequest": {
+								"i0": {
 									"id": "I_2"
 								}
 							}
@@ -802,7 +888,7 @@ func TestEdit(t *testing.T) {
 					Reply(200).
 					JSON(`{
 						"data": {
-							"addProjectV2ItemById": {
+							"a0": {
 								"item": {
 									"id": "PNI_2"
 								}
@@ -870,14 +956,14 @@ func TestEdit(t *testing.T) {
 								}
 							}
 						}`)
-				// Add issue 2
+				// Add issue 2 in a batch
 				gock.New("https://api.github.com").
 					Post("/graphql").
 					Reply(200).
 					JSON(`{
 							"data": {
 								"repository": {
-									"issueOrPullRequest": {
+									"i0": {
 										"id": "I_2"
 									}
 								}
@@ -888,7 +974,7 @@ func TestEdit(t *testing.T) {
 					Reply(200).
 					JSON(`{
 							"data": {
-								"addProjectV2ItemById": {
+								"a0": {
 									"item": {
 										"id": "PNI_2"
 									}
@@ -918,6 +1004,7 @@ func TestEdit(t *testing.T) {
 
 				authToken: "***",
 				host:      "github.com",
+				browser:   &fakeBrowser{},
 			}
 
 			if tt.opts == nil {
@@ -941,10 +1028,729 @@ func TestEdit(t *testing.T) {
 
 			stdout, _, _ := fake.Buffers()
 			assert.Equal(t, tt.wantStdout, stdout.String())
+			assert.Equal(t, tt.wantBrowsed, globalOpts.browser.(*fakeBrowser).url)
+		})
+	}
+}
+
+func TestEdit_cache(t *testing.T) {
+	tests := []struct {
+		name  string
+		mocks func()
+	}{
+		{
+			name: "cached",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("DeleteProjectV2Item").
+					BodyString("PNI_2").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"deleteProjectV2Item": {
+								"deletedItemId": "PNI_2"
+							}
+						}
+					}`)
+			},
+		},
+		{
+			name: "stale",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("DeleteProjectV2Item").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"deleteProjectV2Item": null
+						},
+						"errors": [
+							{
+								"type": "NOT_FOUND",
+								"message": "Could not resolve to a node with the global id of 'PNI_2'"
+							}
+						]
+					}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("RepositoryProjectV2ID").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"repository": {
+								"projectV2": {
+									"id": "PN_2",
+									"url": "https://github.com/users/heaths/projects/1"
+								}
+							}
+						}
+					}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("RepositoryProjectV2Items").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"repository": {
+								"projectV2": {
+									"items": {
+										"totalCount": 2,
+										"nodes": [
+											{
+												"id": "PNI_4",
+												"content": {
+													"id": "I_4",
+													"number": 2,
+													"repository": {
+														"nameWithOwner": "heaths/other"
+													}
+												}
+											},
+											{
+												"id": "PNI_3",
+												"content": {
+													"id": "I_2",
+													"number": 2,
+													"repository": {
+														"nameWithOwner": "heaths/gh-projects"
+													}
+												}
+											}
+										],
+										"pageInfo": {
+											"hasNextPage": false
+										}
+									}
+								}
+							}
+						}
+					}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("DeleteProjectV2Item").
+					BodyString("PNI_3").
+					Reply(200).
+					JSON(`{
+						"data": {
+							"deleteProjectV2Item": {
+								"deletedItemId": "PNI_3"
+							}
+						}
+					}`)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			c := cache.New(t.TempDir())
+			cached := c.Load("github.com", "heaths", 1)
+			c.SetProject(cached, "PN_1", "https://github.com/users/heaths/projects/1")
+			cached.AddRepository("heaths/gh-projects")
+			c.SetItems(cached, map[string]string{cache.ItemKey("heaths/gh-projects", 2): "PNI_2"})
+			assert.NoError(t, c.Save("github.com", "heaths", 1, cached))
+
+			opts := &editOptions{
+				projectOptions: projectOptions{
+					GlobalOptions: GlobalOptions{
+						Console: console.Fake(),
+						Cache:   c,
+						Repo:    repo,
+
+						authToken: "***",
+						host:      "github.com",
+					},
+					number: 1,
+				},
+				removeIssues: []int{2},
+			}
+
+			tt.mocks()
+
+			err = edit(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			// Removed items should no longer be cached.
+			_, ok := c.Load("github.com", "heaths", 1).Item("heaths/gh-projects", 2)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestEdit_cacheOtherRepository(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	// Items for the same number in another repository are not used.
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryProjectV2Items.*"name":"gh-projects"`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"projectV2":{"items":{
+			"totalCount": 2,
+			"nodes": [
+				{"id": "PNI_4", "content": {"id": "I_4", "number": 2, "repository": {"nameWithOwner": "heaths/other"}}},
+				{"id": "PNI_2", "content": {"id": "I_2", "number": 2, "repository": {"nameWithOwner": "heaths/gh-projects"}}}
+			],
+			"pageInfo": {"hasNextPage": false}
+		}}}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`DeleteProjectV2Item.*"itemId":"PNI_2"`).
+		Reply(200).
+		JSON(`{"data":{"deleteProjectV2Item":{"deletedItemId":"PNI_2"}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	c := cache.New(t.TempDir())
+	cached := c.Load("github.com", "heaths", 1)
+	c.SetProject(cached, "PN_1", "https://github.com/users/heaths/projects/1")
+	cached.AddRepository("heaths/gh-projects")
+	c.SetItems(cached, map[string]string{cache.ItemKey("heaths/other", 2): "PNI_4"})
+	require.NoError(t, c.Save("github.com", "heaths", 1, cached))
+
+	opts := &editOptions{
+		projectOptions: projectOptions{
+			GlobalOptions: GlobalOptions{
+				Console: console.Fake(),
+				Cache:   c,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			},
+			number: 1,
+		},
+		removeIssues: []int{2},
+	}
+
+	err = edit(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	// Only the item in the current repository was removed.
+	cached = c.Load("github.com", "heaths", 1)
+	_, ok := cached.Item("heaths/gh-projects", 2)
+	assert.False(t, ok)
+	id, ok := cached.Item("heaths/other", 2)
+	assert.True(t, ok)
+	assert.Equal(t, "PNI_4", id)
+}
+
+func TestEdit_archive(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`ArchiveProjectV2Item`).
+		BodyString(`"itemId":"PNI_2","projectId":"PN_1"`).
+		Reply(200).
+		JSON(`{"data":{"archiveProjectV2Item":{"item":{"id":"PNI_2"}}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`UnarchiveProjectV2Item`).
+		BodyString(`"itemId":"PNI_3","projectId":"PN_1"`).
+		Reply(200).
+		JSON(`{"data":{"unarchiveProjectV2Item":{"item":{"id":"PNI_3"}}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	assert.NoError(t, err)
+
+	c := cache.New(t.TempDir())
+	cached := c.Load("github.com", "heaths", 1)
+	c.SetProject(cached, "PN_1", "https://github.com/users/heaths/projects/1")
+	cached.AddRepository("heaths/gh-projects")
+	c.SetItems(cached, map[string]string{
+		cache.ItemKey("heaths/gh-projects", 2): "PNI_2",
+		cache.ItemKey("heaths/gh-projects", 3): "PNI_3",
+	})
+	assert.NoError(t, c.Save("github.com", "heaths", 1, cached))
+
+	opts := &editOptions{
+		projectOptions: projectOptions{
+			GlobalOptions: GlobalOptions{
+				Console: console.Fake(),
+				Cache:   c,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			},
+			number: 1,
+		},
+		archiveIssues:   []int{2},
+		unarchiveIssues: []int{3},
+	}
+
+	err = edit(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	// Archived items are still in the project.
+	_, ok := c.Load("github.com", "heaths", 1).Item("heaths/gh-projects", 2)
+	assert.True(t, ok)
+}
+
+func TestEdit_anotherOwner(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *editOptions
+		mocks   func()
+		wantErr string
+	}{
+		{
+			name: "change title",
+			opts: &editOptions{
+				projectOptions: projectOptions{
+					title: "new title",
+				},
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2OwnerID.*"variables":\{"number":12,"owner":"acme"\}`).
+					Reply(200).
+					JSON(`{
+						"data": {
+							"repository": {
+								"type": "Organization",
+								"projectV2": {
+									"id": "PN_12",
+									"url": "https://github.com/orgs/acme/projects/12"
+								}
+							}
+						}
+					}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`UpdateProjectV2.*"id":"PN_12"`).
+					Reply(200).
+					JSON(`{
+						"data": {
+							"updateProjectV2": {
+								"projectV2": {
+									"url": "https://github.com/orgs/acme/projects/12"
+								}
+							}
+						}
+					}`)
+			},
+		},
+		{
+			name: "add issue",
+			opts: &editOptions{
+				addIssues: []int{1},
+			},
+			wantErr: "--add-issue requires -R OWNER/REPO",
+		},
+		{
+			name: "remove issue",
+			opts: &editOptions{
+				removeIssues: []int{1},
+			},
+			wantErr: "--remove-issue requires -R OWNER/REPO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			require.NoError(t, err)
+
+			tt.opts.GlobalOptions = GlobalOptions{
+				Console: console.Fake(),
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			}
+			tt.opts.workerCount = 1
+
+			tt.opts.projectArg, err = parseProjectArg("https://github.com/orgs/acme/projects/12", &tt.opts.number)
+			require.NoError(t, err)
+			require.NoError(t, ResolveProjectArg(&tt.opts.GlobalOptions))
+
+			err = edit(tt.opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
 		})
 	}
 }
 
+func TestEdit_interactive(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("RepositoryProjectV2ID").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"projectV2": {
+						"id": "PN_1",
+						"url": "https://github.com/users/heaths/projects/1"
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("ProjectV2Node").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"node": {
+					"title": "old title",
+					"description": "description",
+					"body": "old readme",
+					"public": false
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("RepositoryRecentIssues").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"issues": {
+						"nodes": [
+							{
+								"number": 2,
+								"title": "Bug"
+							},
+							{
+								"number": 3,
+								"title": "Feature"
+							}
+						]
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("RepositoryOwnerProjectV2Fields").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"projectV2": {
+						"fields": {
+							"nodes": [
+								{
+									"id": "PNF_Cost",
+									"name": "Cost",
+									"dataType": "NUMBER"
+								},
+								{
+									"id": "PNF_Status",
+									"name": "Status",
+									"dataType": "SINGLE_SELECT",
+									"options": [
+										{
+											"id": "PNF_Status_Todo",
+											"name": "Todo"
+										},
+										{
+											"id": "PNF_Status_Done",
+											"name": "Done"
+										}
+									]
+								}
+							],
+							"pageInfo": {
+								"hasNextPage": false
+							}
+						}
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("UpdateProjectV2").
+		BodyString("new title").
+		BodyString("new readme").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"updateProjectV2": {
+					"projectV2": {
+						"url": "https://github.com/users/heaths/projects/1"
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("RepositoryIssuesOrPullRequestsID").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"i0": {
+						"id": "I_3"
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("AddProjectV2ItemsById").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"a0": {
+					"item": {
+						"id": "PNI_3"
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString("UpdateProjectV2ItemFieldValues").
+		BodyString("PNF_Status_Done").
+		Reply(200).
+		JSON(`{
+			"data": {
+				"u0": {
+					"projectV2Item": {
+						"id": "PNI_3"
+					}
+				}
+			}
+		}`)
+
+	// Edit the title and readme, and add an issue with a status.
+	stdin := heredoc.Doc(`
+		1,3,5
+		new title
+		2
+		3
+	`)
+	fake := console.Fake(
+		console.WithStdin(bytes.NewBufferString(stdin)),
+		console.WithStdinTTY(true),
+		console.WithStdoutTTY(true),
+	)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	assert.NoError(t, err)
+
+	opts := &editOptions{
+		projectOptions: projectOptions{
+			GlobalOptions: GlobalOptions{
+				Console: fake,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+				editor:    &fakeEditor{text: "new readme"},
+			},
+			number: 1,
+		},
+		interactive: true,
+		workerCount: 1,
+	}
+
+	err = edit(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	assert.Equal(t, "new title", opts.title)
+	assert.Equal(t, utils.Ptr("new readme"), opts.body)
+	assert.Nil(t, opts.description)
+	assert.Nil(t, opts.public)
+	assert.Equal(t, []int{3}, opts.addIssues)
+	assert.Equal(t, map[string]string{"Status": "Done"}, opts.fields)
+
+	stdout, _, _ := fake.Buffers()
+	assert.Contains(t, stdout.String(), "#3 Feature")
+	assert.True(t, strings.HasSuffix(stdout.String(), "https://github.com/users/heaths/projects/1\n"))
+}
+
+func TestEdit_editBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		edited     string
+		current    string
+		mocks      func()
+		wantStderr string
+		wantErr    string
+	}{
+		{
+			name:    "changed",
+			edited:  "new readme",
+			current: "old readme",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("UpdateProjectV2").
+					BodyString(`"body":"new readme","id":"PN_1"`).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2":{"projectV2":{"url":"https://github.com/users/heaths/projects/1"}}}}`)
+			},
+		},
+		{
+			name:       "unchanged",
+			edited:     "old readme",
+			wantStderr: "Body unchanged\n",
+		},
+		{
+			name:    "changed by someone else",
+			edited:  "new readme",
+			current: "other readme",
+			wantErr: "body was changed by someone else while editing; your changes were saved to ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString("RepositoryProjectV2ID").
+				Reply(200).
+				JSON(`{"data":{"repository":{"projectV2":{"id":"PN_1","url":"https://github.com/users/heaths/projects/1"}}}}`)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`ProjectV2Node.*"id":"PN_1"`).
+				Reply(200).
+				JSON(`{"data":{"node":{"body":"old readme"}}}`)
+			if tt.current != "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString("ProjectV2Node").
+					Reply(200).
+					JSON(fmt.Sprintf(`{"data":{"node":{"body":%q}}}`, tt.current))
+			}
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake(console.WithStdinTTY(true), console.WithStdoutTTY(true))
+			opts := &editOptions{
+				projectOptions: projectOptions{
+					GlobalOptions: GlobalOptions{
+						Console: fake,
+						Repo:    repo,
+
+						authToken: "***",
+						host:      "github.com",
+						editor:    &fakeEditor{text: tt.edited},
+					},
+					number: 1,
+				},
+				editBody:    true,
+				workerCount: 1,
+			}
+
+			err = edit(opts)
+			if tt.wantErr != "" {
+				if assert.ErrorContains(t, err, tt.wantErr) {
+					path := strings.TrimPrefix(err.Error(), tt.wantErr)
+					t.Cleanup(func() { os.Remove(path) })
+
+					saved, err := os.ReadFile(path)
+					assert.NoError(t, err)
+					assert.Equal(t, tt.edited, string(saved))
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			_, stderr, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStderr, stderr.String())
+		})
+	}
+}
+
+type fakeBrowser struct {
+	url string
+}
+
+func (b *fakeBrowser) Browse(url string) error {
+	b.url = url
+	return nil
+}
+
+func TestNewWorkerClient(t *testing.T) {
+	tests := []struct {
+		name        string
+		workers     int
+		workerCount int
+		want        int
+	}{
+		{
+			name: "default",
+			want: DefaultWorkerCount,
+		},
+		{
+			name:    "configured",
+			workers: 4,
+			want:    4,
+		},
+		{
+			name:        "worker count",
+			workers:     4,
+			workerCount: 1,
+			want:        1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &GlobalOptions{
+				Config: &config.Config{Workers: tt.workers},
+
+				authToken: "***",
+				host:      "github.com",
+			}
+
+			c, err := newWorkerClient(opts, tt.workerCount)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c.(*client.Client).Concurrency())
+		})
+	}
+}
+
+type fakeEditor struct {
+	text string
+}
+
+func (e *fakeEditor) Edit(pattern, text string) (string, error) {
+	return e.text, nil
+}
+
 func pendingMocks(mocks []gock.Mock) string {
 	paths := make([]string, len(mocks))
 	for i, mock := range mocks {
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/editor"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/prompt"
+	"github.com/heaths/gh-projects/internal/utils"
+)
+
+const (
+	propertyTitle       = "Title"
+	propertyDescription = "Description"
+	propertyReadme      = "Readme"
+	propertyVisibility  = "Visibility"
+	propertyAddIssues   = "Add issues"
+
+	visibilityPublic  = "Public"
+	visibilityPrivate = "Private"
+
+	// The number of recently updated open issues to choose from.
+	recentIssuesCount = 30
+)
+
+type textEditor interface {
+	Edit(pattern, text string) (string, error)
+}
+
+func newEditor(opts *GlobalOptions) textEditor {
+	if opts.editor != nil {
+		return opts.editor
+	}
+
+	return editor.New("", opts.Console.Stdin(), opts.Console.Stdout(), opts.Console.Stderr())
+}
+
+// promptEdit prompts for which project properties to change and sets them in opts.
+func promptEdit(client api.GQLClient, projectID string, cached *cache.Project, opts *editOptions) error {
+	var data struct {
+		Node models.Project
+	}
+
+	err := client.Do(queryProjectV2Node, map[string]interface{}{"id": projectID}, &data)
+	if err != nil {
+		return err
+	}
+
+	project := data.Node
+	p := prompt.New(opts.Console)
+
+	properties := []string{propertyTitle, propertyDescription, propertyReadme, propertyVisibility, propertyAddIssues}
+	selected, err := p.MultiSelect("What would you like to edit?", properties)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range selected {
+		switch properties[i] {
+		case propertyTitle:
+			opts.title, err = p.Input("Title", project.Title)
+
+		case propertyDescription:
+			var description string
+			description, err = p.Input("Description", project.Description)
+			if err == nil && description != project.Description {
+				opts.description = &description
+			}
+
+		case propertyReadme:
+			var body string
+			body, err = newEditor(&opts.GlobalOptions).Edit("*.md", project.Body)
+			if err == nil && body != project.Body {
+				opts.body = &body
+			}
+
+		case propertyVisibility:
+			defaultIndex := 1
+			if project.Public {
+				defaultIndex = 0
+			}
+
+			var visibility int
+			visibility, err = p.Select("Visibility", []string{visibilityPublic, visibilityPrivate}, defaultIndex)
+			if err == nil && visibility != defaultIndex {
+				opts.public = utils.Ptr(visibility == 0)
+			}
+
+		case propertyAddIssues:
+			err = promptAddIssues(client, p, cached, opts)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promptAddIssues prompts for recent open issues to add, and values for single select and iteration fields.
+func promptAddIssues(client api.GQLClient, p *prompt.Prompter, cached *cache.Project, opts *editOptions) error {
+	if err := requireRepository(&opts.GlobalOptions, "adding issues"); err != nil {
+		return err
+	}
+
+	vars := map[string]interface{}{
+		"owner": opts.Repo.Owner(),
+		"name":  opts.Repo.Name(),
+		"first": recentIssuesCount,
+	}
+
+	var data struct {
+		Repository struct {
+			Issues struct {
+				Nodes []struct {
+					Number int
+					Title  string
+				}
+			}
+		}
+	}
+
+	err := client.Do(queryRepositoryRecentIssues, vars, &data)
+	if err != nil {
+		return err
+	}
+
+	issues := data.Repository.Issues.Nodes
+	if len(issues) == 0 {
+		fmt.Fprintln(opts.Console.Stdout(), "No open issues to add")
+		return nil
+	}
+
+	options := make([]string, len(issues))
+	for i, issue := range issues {
+		options[i] = fmt.Sprintf("#%d %s", issue.Number, issue.Title)
+	}
+
+	selected, err := p.MultiSelect("Which issues would you like to add?", options)
+	if err != nil || len(selected) == 0 {
+		return err
+	}
+
+	for _, i := range selected {
+		opts.addIssues = append(opts.addIssues, issues[i].Number)
+	}
+
+	projectFields, err := listFields(client, cached, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range projectFields {
+		var values []string
+		switch field.DataType {
+		case "ITERATION":
+			for _, iteration := range field.Configuration.Iterations {
+				values = append(values, iteration.Name)
+			}
+		case "SINGLE_SELECT":
+			for _, option := range field.Options {
+				values = append(values, option.Name)
+			}
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		i, err := p.Select(field.Name, append([]string{"(none)"}, values...), 0)
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			if opts.fields == nil {
+				opts.fields = make(map[string]string)
+			}
+			opts.fields[field.Name] = values[i-1]
+		}
+	}
+
+	return nil
+}
+
+// listFields gets all field definitions for a project.
+func listFields(client api.GQLClient, cached *cache.Project, opts *editOptions) ([]models.ProjectField, error) {
+	if cached.Fields != nil {
+		return cached.Fields, nil
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.Cache.SetFields(cached, projectFields)
+	return projectFields, nil
+}
+
+const queryProjectV2Node = `
+query ProjectV2Node($id: ID!) {
+	node(id: $id) {
+		... on ProjectV2 {
+			title
+			description: shortDescription
+			body: readme
+			public
+		}
+	}
+}
+`
+
+const queryRepositoryRecentIssues = `
+query RepositoryRecentIssues($owner: String!, $name: String!, $first: Int!) {
+	repository(owner: $owner, name: $name) {
+		issues(first: $first, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+			nodes {
+				number
+				title
+			}
+		}
+	}
+}
+`
@@ -0,0 +1,544 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func NewItemCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "item",
+		Short: "Manage project items",
+		Long: heredoc.Doc(`
+			Work with draft issues, issues, and pull requests in a project.
+		`),
+	}
+
+	cmd.AddCommand(newItemArchiveCmd(globalOpts))
+	cmd.AddCommand(newItemListCmd(globalOpts))
+	cmd.AddCommand(newItemMoveCmd(globalOpts))
+	cmd.AddCommand(newItemRankCmd(globalOpts))
+
+	return cmd
+}
+
+func newItemListCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := itemListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list <number>",
+		Short: "List project items",
+		Long: heredoc.Doc(`
+			List draft issues, issues, and pull requests in a project.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass --view with the name or number of a view to apply its filter and
+			sort, and show its visible fields.
+
+			Pass --watch to print changes to items as they happen, such as items added
+			or removed and field values changed. Items that begin or stop matching the
+			filter of --view are added or removed. The project is checked every --interval,
+			which doubles up to 8 times while nothing changes. Pass --json to print each
+			change as a line of JSON.
+		`),
+		Example: heredoc.Doc(`
+			# list items as shown in the "Current iteration" view
+			$ gh projects item list 1 --view "Current iteration"
+
+			# open the "Current iteration" view in the browser
+			$ gh projects item list 1 --view "Current iteration" --web
+
+			# print changes to items in the "Current iteration" view as they happen
+			$ gh projects item list 1 --view "Current iteration" --watch
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			if err := opts.watch.validate(cmd); err != nil {
+				return err
+			}
+
+			if opts.watch.watch && opts.web {
+				return fmt.Errorf("--watch cannot be used with --web")
+			}
+
+			return itemList(&opts)
+		},
+	}
+
+	IntRangeVarP(cmd, &opts.limit, "limit", "L", 30, 1, 1000, "Number of items to list")
+	cmd.Flags().StringVar(&opts.view, "view", "", "Apply the filter and sort of the view `name` or number")
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the project or view in the browser")
+	addWatchFlags(cmd, &opts.watch)
+
+	return cmd
+}
+
+type itemListOptions struct {
+	GlobalOptions
+
+	number int
+	limit  int
+	view   string
+	web    bool
+
+	watch watchOptions
+}
+
+func itemList(opts *itemListOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	var view *models.ProjectView
+	var url string
+	if opts.view != "" || opts.web {
+		var project *projectViews
+		project, err = listViews(client, opts.number, &opts.GlobalOptions)
+		if err != nil {
+			return
+		}
+
+		url = project.URL
+		if opts.view != "" {
+			view, err = findView(project.Views.Nodes, opts.view)
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	if opts.web {
+		if view != nil {
+			url = fmt.Sprintf("%s/views/%d", url, view.Number)
+		}
+
+		return openInBrowser(&opts.GlobalOptions, url)
+	}
+
+	// Views are filtered locally, so all items are needed.
+	limit := opts.limit
+	if view != nil {
+		limit = 0
+	}
+
+	project, err := listProjectItems(client, opts.number, limit, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := project.Items
+	totalCount := project.TotalCount
+
+	var fields []string
+	if view != nil {
+		items, err = applyView(items, view, filter.Env{Viewer: project.Viewer})
+		if err != nil {
+			return
+		}
+		totalCount = len(items)
+
+		for _, name := range view.Fields.Names() {
+			// Title is always shown.
+			if !strings.EqualFold(name, "Title") {
+				fields = append(fields, name)
+			}
+		}
+	}
+
+	if len(items) > opts.limit {
+		items = items[:opts.limit]
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	err = t.Items(items, fields, totalCount)
+	if err != nil || !opts.watch.watch {
+		return
+	}
+
+	return watchItems(&opts.GlobalOptions, &opts.watch, client, opts.number, func() ([]models.ProjectItem, error) {
+		project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		if view == nil {
+			return project.Items, nil
+		}
+		return applyView(project.Items, view, filter.Env{Viewer: project.Viewer})
+	})
+}
+
+func newItemArchiveCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := itemArchiveOptions{}
+	cmd := &cobra.Command{
+		Use:   "archive <number>",
+		Short: "Archive project items",
+		Long: heredoc.Doc(`
+			Archive draft issues, issues, and pull requests in a project that match
+			a filter like those used by views in the browser. Archived items are hidden
+			from views but not removed from the project.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			To archive or unarchive specific issues or pull requests, see
+			"gh projects edit --archive-issue" and "--unarchive-issue".
+		`),
+		Example: heredoc.Doc(`
+			# archive items done more than two weeks ago
+			$ gh projects item archive 1 --query "status:Done updated:<@today-14d"
+
+			# show which closed items would be archived
+			$ gh projects item archive 1 --query "is:closed" --dry-run
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			return itemArchive(&opts)
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.query, "query", "q", "", "Archive items matching the `filter`")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show which items would be archived without archiving them")
+	_ = cmd.MarkFlagRequired("query")
+
+	return cmd
+}
+
+type itemArchiveOptions struct {
+	GlobalOptions
+
+	number int
+	query  string
+	dryRun bool
+}
+
+func itemArchive(opts *itemArchiveOptions) (err error) {
+	f, err := filter.Parse(opts.query)
+	if err != nil {
+		return
+	}
+
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	env := filter.Env{
+		Viewer: project.Viewer,
+		Now:    timeNow(&opts.GlobalOptions),
+	}
+
+	var items []models.ProjectItem
+	for _, item := range project.Items {
+		if !item.IsArchived && f.Match(item, env) {
+			items = append(items, item)
+		}
+	}
+
+	if !opts.dryRun && len(items) > 0 {
+		opts.Console.StartProgress(fmt.Sprintf("Archiving %s in %s", text.Pluralize(len(items), "item"), project.URL))
+		for _, item := range items {
+			var data map[string]interface{}
+			err = client.Do(mutationArchiveProjectV2Item, map[string]interface{}{
+				"projectId": project.ID,
+				"itemId":    item.ID,
+			}, &data)
+			if err != nil {
+				break
+			}
+		}
+		opts.Console.StopProgress()
+
+		if err != nil {
+			return
+		}
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	return t.Archived(items, opts.dryRun)
+}
+
+// applyView filters and sorts items like the view does in the browser.
+func applyView(items []models.ProjectItem, view *models.ProjectView, env filter.Env) ([]models.ProjectItem, error) {
+	f, err := filter.Parse(view.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("view %q: %w", view.Name, err)
+	}
+
+	// Archived items are hidden from views.
+	matched := make([]models.ProjectItem, 0, len(items))
+	for _, item := range items {
+		if !item.IsArchived && f.Match(item, env) {
+			matched = append(matched, item)
+		}
+	}
+
+	filter.Sort(matched, view.SortByFields.Nodes)
+	return matched, nil
+}
+
+// unarchivedItems gets items that are not archived, which are hidden from views in the browser.
+func unarchivedItems(items []models.ProjectItem) []models.ProjectItem {
+	unarchived := make([]models.ProjectItem, 0, len(items))
+	for _, item := range items {
+		if !item.IsArchived {
+			unarchived = append(unarchived, item)
+		}
+	}
+
+	return unarchived
+}
+
+type projectItems struct {
+	ID         string
+	Title      string
+	URL        string
+	Items      []models.ProjectItem
+	TotalCount int
+
+	// Viewer is the login of the authenticated user.
+	Viewer string
+}
+
+// listProjectItems gets up to limit project items with their field values, or all items if limit is 0.
+func listProjectItems(client api.GQLClient, number, limit int, opts *GlobalOptions) (*projectItems, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+		"first":  100,
+	}
+
+	project := &projectItems{}
+	for {
+		if limit > 0 && limit-len(project.Items) < 100 {
+			vars["first"] = limit - len(project.Items)
+		}
+
+		// Decode each page into new values so items already appended do not share slices with the next page.
+		var data struct {
+			Viewer struct {
+				Login string
+			}
+			Repository struct {
+				ProjectV2 models.Project
+			}
+		}
+
+		err := client.Do(queryRepositoryOwnerProjectV2ItemFields+fragmentProjectV2ItemFields+fragmentProjectV2FieldName, vars, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		p := data.Repository.ProjectV2
+		project.ID = p.ID
+		project.Title = p.Title
+		project.URL = p.URL
+		project.Viewer = data.Viewer.Login
+		if p.Items == nil {
+			break
+		}
+
+		project.TotalCount = p.Items.TotalCount
+		project.Items = append(project.Items, p.Items.Nodes...)
+
+		if p.Items.PageInfo.HasNextPage && (limit == 0 || len(project.Items) < limit) {
+			vars["after"] = p.Items.PageInfo.EndCursor
+		} else {
+			break
+		}
+	}
+
+	return project, nil
+}
+
+const queryRepositoryOwnerProjectV2ItemFields = `
+query RepositoryOwnerProjectV2ItemFields($owner: String!, $number: Int!, $first: Int!, $after: String) {
+	viewer {
+		login
+	}
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				id
+				title
+				url
+				items(first: $first, after: $after) {
+					totalCount
+					nodes {
+						...itemFields
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const fragmentProjectV2ItemFields = `
+fragment itemFields on ProjectV2Item {
+	id
+	type
+	isArchived
+	content {
+		... on DraftIssue {
+			id
+			title
+			createdAt
+			updatedAt
+			assignees(first: 10) {
+				nodes {
+					login
+				}
+			}
+		}
+		... on Issue {
+			id
+			number
+			title
+			url
+			createdAt
+			updatedAt
+			closedAt
+			state
+			assignees(first: 10) {
+				nodes {
+					login
+				}
+			}
+			labels(first: 20) {
+				nodes {
+					name
+				}
+			}
+			repository {
+				nameWithOwner
+			}
+		}
+		... on PullRequest {
+			id
+			number
+			title
+			url
+			createdAt
+			updatedAt
+			closedAt
+			state
+			assignees(first: 10) {
+				nodes {
+					login
+				}
+			}
+			labels(first: 20) {
+				nodes {
+					name
+				}
+			}
+			repository {
+				nameWithOwner
+			}
+		}
+	}
+	fieldValues(first: 50) {
+		nodes {
+			... on ProjectV2ItemFieldTextValue {
+				text
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldNumberValue {
+				number
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldDateValue {
+				date
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldSingleSelectValue {
+				name
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldIterationValue {
+				title
+				startDate
+				duration
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldLabelValue {
+				labels(first: 20) {
+					nodes {
+						name
+					}
+				}
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldUserValue {
+				users(first: 10) {
+					nodes {
+						login
+					}
+				}
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldMilestoneValue {
+				milestone {
+					title
+				}
+				field {
+					...fieldName
+				}
+			}
+			... on ProjectV2ItemFieldRepositoryValue {
+				repository {
+					nameWithOwner
+				}
+				field {
+					...fieldName
+				}
+			}
+		}
+	}
+}
+`
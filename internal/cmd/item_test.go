@@ -0,0 +1,391 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const itemFieldsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 3,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"number": 1, "title": "Fix the parser", "state": "OPEN"},
+							"fieldValues": {
+								"nodes": [
+									{"text": "Fix the parser", "field": {"name": "Title", "dataType": "TITLE"}},
+									{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+									{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_2",
+							"type": "PULL_REQUEST",
+							"content": {"number": 2, "title": "Add a feature", "state": "MERGED"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+									{"number": 8, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_3",
+							"type": "ISSUE",
+							"content": {"number": 3, "title": "Write docs", "state": "OPEN"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+									{"number": 5, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestItemList(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *itemListOptions
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name: "all items",
+			opts: &itemListOptions{
+				limit: 2,
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"first":2,"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(itemFieldsJSON)
+			},
+			wantStdout: heredoc.Doc(`
+				Issue        #1  Fix the parser  open
+				PullRequest  #2  Add a feature   merged
+
+			`),
+		},
+		{
+			name: "view",
+			opts: &itemListOptions{
+				limit: 30,
+				view:  "board",
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2Views`).
+					Reply(200).
+					JSON(viewsJSON)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"first":100,"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(itemFieldsJSON)
+			},
+			wantStdout: heredoc.Doc(`
+				Issue  #3  Write docs      open  Todo         5
+				Issue  #1  Fix the parser  open  In Progress  3
+
+			`),
+		},
+		{
+			name: "view excludes archived items",
+			opts: &itemListOptions{
+				limit: 30,
+				view:  "board",
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2Views`).
+					Reply(200).
+					JSON(viewsJSON)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"first":100,"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(strings.Replace(itemFieldsJSON, `"id": "PNI_3",`, `"id": "PNI_3", "isArchived": true,`, 1))
+			},
+			wantStdout: heredoc.Doc(`
+				Issue  #1  Fix the parser  open  In Progress  3
+
+			`),
+		},
+		{
+			name: "view not found",
+			opts: &itemListOptions{
+				limit: 30,
+				view:  "missing",
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(viewsJSON)
+			},
+			wantErr: "view not found: missing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			tt.opts.GlobalOptions = GlobalOptions{
+				Console: fake,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			}
+			tt.opts.number = 1
+
+			err = itemList(tt.opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestListProjectItems_pages(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"first":100,"number":1,"owner":"heaths"\}`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"projectV2":{"id":"PN_1","items":{"totalCount":2,"nodes":[
+			{"id":"PNI_1","type":"ISSUE","content":{"number":1,"title":"Fix the parser"},"fieldValues":{"nodes":[
+				{"name":"Done","field":{"name":"Status","dataType":"SINGLE_SELECT"}}
+			]}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"PAGE_1"}}}}}}`)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"after":"PAGE_1"`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"projectV2":{"id":"PN_1","items":{"totalCount":2,"nodes":[
+			{"id":"PNI_2","type":"ISSUE","content":{"number":2,"title":"Add a feature"},"fieldValues":{"nodes":[
+				{"name":"Todo","field":{"name":"Status","dataType":"SINGLE_SELECT"}}
+			]}}
+		],"pageInfo":{"hasNextPage":false}}}}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	opts := &GlobalOptions{
+		Repo:      repo,
+		authToken: "***",
+		host:      "github.com",
+	}
+
+	client, err := newClient(opts)
+	require.NoError(t, err)
+
+	project, err := listProjectItems(client, 1, 0, opts)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+	require.Len(t, project.Items, 2)
+
+	// Decoding the second page must not change field values of items from the first page.
+	for i, want := range []string{"Done", "Todo"} {
+		value, ok := project.Items[i].FieldValue("Status")
+		require.True(t, ok)
+		assert.Equal(t, want, value.String())
+	}
+}
+
+func TestItemList_web(t *testing.T) {
+	tests := []struct {
+		name        string
+		view        string
+		wantBrowsed string
+	}{
+		{
+			name:        "project",
+			wantBrowsed: "https://github.com/users/heaths/projects/1",
+		},
+		{
+			name:        "view by name",
+			view:        "Board",
+			wantBrowsed: "https://github.com/users/heaths/projects/1/views/2",
+		},
+		{
+			name:        "view by number",
+			view:        "#1",
+			wantBrowsed: "https://github.com/users/heaths/projects/1/views/1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				Reply(200).
+				JSON(viewsJSON)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			browser := &fakeBrowser{}
+			opts := &itemListOptions{
+				GlobalOptions: GlobalOptions{
+					Console: console.Fake(),
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					browser:   browser,
+				},
+				number: 1,
+				view:   tt.view,
+				web:    true,
+			}
+
+			err = itemList(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			assert.Equal(t, tt.wantBrowsed, browser.url)
+		})
+	}
+}
+
+func TestItemArchive(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		dryRun     bool
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:   "dry run",
+			query:  "status:Done,Todo",
+			dryRun: true,
+			wantStdout: heredoc.Doc(`
+				#2  Add a feature
+				Would archive 1 item
+			`),
+		},
+		{
+			name:  "archive",
+			query: "status:Done,Todo",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"itemId":"PNI_2","projectId":"PN_1"`).
+					Reply(200).
+					JSON(`{"data":{"archiveProjectV2Item":{"item":{"id":"PNI_2"}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				#2  Add a feature
+				Archived 1 item
+			`),
+		},
+		{
+			name:       "no matches",
+			query:      "status:Missing",
+			wantStdout: "No items to archive\n",
+		},
+		{
+			name:    "invalid query",
+			query:   `title:"unterminated`,
+			wantErr: `unterminated quote in filter: title:"unterminated`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(strings.Replace(itemFieldsJSON, `"id": "PNI_3",`, `"id": "PNI_3", "isArchived": true,`, 1))
+			}
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &itemArchiveOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				query:  tt.query,
+				dryRun: tt.dryRun,
+			}
+
+			err = itemArchive(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
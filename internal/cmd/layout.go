@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func NewViewLayoutCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view-layout",
+		Short: "Manage project views",
+		Long: heredoc.Doc(`
+			Project views show items as a table, board, or roadmap, and may
+			filter, group, and sort items.
+
+			Views cannot be created or changed using the GitHub API, so views must
+			be created or changed in the browser.
+		`),
+	}
+
+	cmd.AddCommand(newViewLayoutListCmd(globalOpts))
+
+	return cmd
+}
+
+func newViewLayoutListCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := viewLayoutListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list <number>",
+		Short: "List project views",
+		Long: heredoc.Doc(`
+			List the views of a project including their layout, filter,
+			group-by, sort-by, and visible fields.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			return viewLayoutList(&opts)
+		},
+	}
+
+	return cmd
+}
+
+type viewLayoutListOptions struct {
+	GlobalOptions
+
+	number int
+}
+
+func viewLayoutList(opts *viewLayoutListOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listViews(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	return t.Views(project.Views.Nodes)
+}
+
+type projectViews struct {
+	URL   string
+	Views models.ProjectViewsNode
+}
+
+// listViews gets the project URL and all its views.
+func listViews(client api.GQLClient, number int, opts *GlobalOptions) (*projectViews, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+	}
+
+	project := &projectViews{}
+	for {
+		// Decode each page into new values so views already appended do not share slices with the next page.
+		var data struct {
+			Repository struct {
+				ProjectV2 projectViews
+			}
+		}
+
+		err := client.Do(queryRepositoryOwnerProjectV2Views+fragmentProjectV2FieldName, vars, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		views := data.Repository.ProjectV2.Views
+		project.URL = data.Repository.ProjectV2.URL
+		project.Views.Nodes = append(project.Views.Nodes, views.Nodes...)
+
+		if views.PageInfo.HasNextPage {
+			vars["after"] = views.PageInfo.EndCursor
+		} else {
+			break
+		}
+	}
+
+	return project, nil
+}
+
+// findView finds a view by case-insensitive name, or by number if the name begins with "#" or is a number
+// that does not match any view name.
+func findView(views []models.ProjectView, name string) (*models.ProjectView, error) {
+	for i := range views {
+		if strings.EqualFold(views[i].Name, name) {
+			return &views[i], nil
+		}
+	}
+
+	if number, err := parseNumber(name, "invalid view"); err == nil {
+		for i := range views {
+			if views[i].Number == number {
+				return &views[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("view not found: %s", name)
+}
+
+const queryRepositoryOwnerProjectV2Views = `
+query RepositoryOwnerProjectV2Views($owner: String!, $number: Int!, $after: String) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				url
+				views(first: 20, after: $after) {
+					nodes {
+						id
+						number
+						name
+						layout
+						filter
+						groupByFields(first: 5) {
+							nodes {
+								...fieldName
+							}
+						}
+						verticalGroupByFields(first: 5) {
+							nodes {
+								...fieldName
+							}
+						}
+						sortByFields(first: 5) {
+							nodes {
+								direction
+								field {
+									...fieldName
+									... on ProjectV2SingleSelectField {
+										options {
+											id
+											name
+										}
+									}
+								}
+							}
+						}
+						fields(first: 50) {
+							nodes {
+								...fieldName
+							}
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const fragmentProjectV2FieldName = `
+fragment fieldName on ProjectV2FieldConfiguration {
+	... on ProjectV2FieldCommon {
+		id
+		name
+		dataType
+	}
+}
+`
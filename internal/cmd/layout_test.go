@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const viewsJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"url": "https://github.com/users/heaths/projects/1",
+				"views": {
+					"nodes": [
+						{
+							"id": "PVV_1",
+							"number": 1,
+							"name": "All items",
+							"layout": "TABLE_LAYOUT",
+							"filter": "",
+							"groupByFields": {"nodes": []},
+							"verticalGroupByFields": {"nodes": []},
+							"sortByFields": {"nodes": []},
+							"fields": {"nodes": [{"name": "Title"}, {"name": "Status"}]}
+						},
+						{
+							"id": "PVV_2",
+							"number": 2,
+							"name": "Board",
+							"layout": "BOARD_LAYOUT",
+							"filter": "-status:Done",
+							"groupByFields": {"nodes": []},
+							"verticalGroupByFields": {"nodes": [{"name": "Status"}]},
+							"sortByFields": {
+								"nodes": [
+									{"direction": "DESC", "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							},
+							"fields": {"nodes": [{"name": "Title"}, {"name": "Status"}, {"name": "Estimate"}]}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestViewLayoutList(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"number":1,"owner":"heaths"`).
+		Reply(200).
+		JSON(viewsJSON)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	assert.NoError(t, err)
+
+	fake := console.Fake()
+	opts := &viewLayoutListOptions{
+		GlobalOptions: GlobalOptions{
+			Console: fake,
+			Repo:    repo,
+
+			authToken: "***",
+			host:      "github.com",
+		},
+		number: 1,
+	}
+
+	err = viewLayoutList(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	stdout, _, _ := fake.Buffers()
+	assert.Equal(t, heredoc.Doc(`
+		#1  All items  TABLE                                         Title, Status
+		#2  Board      BOARD  -status:Done  Status  Estimate (desc)  Title, Status, Estimate
+
+	`), stdout.String())
+}
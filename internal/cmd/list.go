@@ -1,9 +1,9 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
-	"github.com/cli/go-gh"
 	"github.com/cli/go-gh/pkg/api"
 	"github.com/heaths/gh-projects/internal/models"
 	"github.com/heaths/gh-projects/internal/template"
@@ -37,6 +37,7 @@ func NewListCmd(globalOpts *GlobalOptions) *cobra.Command {
 	StringEnumVarP(cmd, &opts.order, "order", "", orderDesc, []string{orderAsc, orderDesc}, "Order of results returned, ignored unless '--sort' flag is specified")
 	cmd.Flags().StringVarP(&opts.search, "search", "S", "", "Search projects")
 	StringEnumVarP(cmd, &opts.sort, "sort", "", "", []string{sortTitle, sortNumber, sortCreated, sortUpdated}, "Sort fetched results")
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the list of projects in the browser")
 
 	return cmd
 }
@@ -49,17 +50,31 @@ type listOptions struct {
 	order  string
 	search string
 	sort   string
+	web    bool
 }
 
 func list(opts *listOptions) (err error) {
-	clientOpts := &api.ClientOptions{
-		Log: opts.Log,
+	if !opts.all {
+		if err = requireRepository(&opts.GlobalOptions, "listing projects without --all"); err != nil {
+			return
+		}
 	}
-	client, err := gh.GQLClient(clientOpts)
+
+	client, err := newClient(&opts.GlobalOptions)
 	if err != nil {
 		return
 	}
 
+	if opts.web {
+		var url string
+		url, err = projectsURL(client, opts)
+		if err != nil {
+			return
+		}
+
+		return openInBrowser(&opts.GlobalOptions, url)
+	}
+
 	vars := map[string]interface{}{
 		"owner": opts.Repo.Owner(),
 		"name":  opts.Repo.Name(),
@@ -168,6 +183,43 @@ query RepositoryOwnerProjectsV2($owner: String!, $first: Int!, $after: String, $
 }
 `
 
+// projectsURL gets the URL to the repository projects, or all projects for the owner if --all was passed.
+func projectsURL(client api.GQLClient, opts *listOptions) (string, error) {
+	if !opts.all {
+		return fmt.Sprintf("https://%s/%s/%s/projects", opts.Repo.Host(), opts.Repo.Owner(), opts.Repo.Name()), nil
+	}
+
+	vars := map[string]interface{}{
+		"owner": opts.Repo.Owner(),
+	}
+
+	var data struct {
+		RepositoryOwner struct {
+			Type string
+		}
+	}
+
+	err := client.Do(queryRepositoryOwnerType, vars, &data)
+	if err != nil {
+		return "", err
+	}
+
+	ownerType := "users"
+	if data.RepositoryOwner.Type == "Organization" {
+		ownerType = "orgs"
+	}
+
+	return fmt.Sprintf("https://%s/%s/%s/projects", opts.Repo.Host(), ownerType, opts.Repo.Owner()), nil
+}
+
+const queryRepositoryOwnerType = `
+query RepositoryOwnerType($owner: String!) {
+	repositoryOwner(login: $owner) {
+		type: __typename
+	}
+}
+`
+
 func projectV2Order(sort string) string {
 	switch sort {
 	case "created":
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestList_web(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *listOptions
+		mocks       func()
+		wantBrowsed string
+	}{
+		{
+			name:        "repository",
+			opts:        &listOptions{},
+			wantBrowsed: "https://github.com/heaths/gh-projects/projects",
+		},
+		{
+			name: "user",
+			opts: &listOptions{
+				all: true,
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repositoryOwner":{"type":"User"}}}`)
+			},
+			wantBrowsed: "https://github.com/users/heaths/projects",
+		},
+		{
+			name: "organization",
+			opts: &listOptions{
+				all: true,
+			},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repositoryOwner":{"type":"Organization"}}}`)
+			},
+			wantBrowsed: "https://github.com/orgs/heaths/projects",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			browser := &fakeBrowser{}
+			tt.opts.GlobalOptions = GlobalOptions{
+				Console: console.Fake(),
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+				browser:   browser,
+			}
+			tt.opts.web = true
+
+			err = list(tt.opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			assert.Equal(t, tt.wantBrowsed, browser.url)
+		})
+	}
+}
+
+func TestList_anotherOwner(t *testing.T) {
+	opts := &listOptions{
+		GlobalOptions: GlobalOptions{
+			Console: console.Fake(),
+			Repo:    projectRepository{host: "github.com", owner: "acme"},
+
+			authToken: "***",
+			host:      "github.com",
+		},
+	}
+
+	err := list(opts)
+	assert.EqualError(t, err, "listing projects without --all requires -R OWNER/REPO")
+}
@@ -7,8 +7,15 @@ import (
 	"io"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/browser"
 	"github.com/cli/go-gh/pkg/repository"
+	"github.com/cli/go-gh/pkg/term"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/client"
+	"github.com/heaths/gh-projects/internal/config"
 	"github.com/heaths/gh-projects/internal/utils"
 	"github.com/heaths/go-console"
 	"github.com/spf13/cobra"
@@ -21,13 +28,135 @@ const (
 type GlobalOptions struct {
 	Console console.Console
 	Log     io.Writer
+	Cache   *cache.Cache
+	Config  *config.Config
 
 	Repo    repository.Repository
 	Verbose bool
 
+	// An error loading settings, returned by commands other than those that change settings.
+	configErr error
+
+	// A project URL or title to resolve after the repository is resolved.
+	projectArg *projectArg
+
 	// Test-only options.
-	host      string
-	authToken string
+	host           string
+	authToken      string
+	userConfigPath string
+	repoConfigPath string
+	editor         textEditor
+	browser        urlBrowser
+	width          int
+	height         int
+	now            time.Time
+	sleep          func(time.Duration) bool
+}
+
+// newClient creates a GraphQL client shared by all workers of a command that retries rate-limited requests.
+func newClient(opts *GlobalOptions) (api.GQLClient, error) {
+	return newWorkerClient(opts, 0)
+}
+
+// newWorkerClient creates a GraphQL client like newClient that makes up to workerCount concurrent requests,
+// or the configured number of workers if workerCount is less than 1.
+func newWorkerClient(opts *GlobalOptions, workerCount int) (api.GQLClient, error) {
+	host := opts.host
+	if host == "" && opts.Repo != nil {
+		host = opts.Repo.Host()
+	}
+
+	clientOpts := &api.ClientOptions{
+		AuthToken: opts.authToken,
+		Host:      host,
+		Log:       opts.Log,
+	}
+
+	return client.New(clientOpts, client.WithConcurrency(effectiveWorkerCount(opts, workerCount)))
+}
+
+// config gets the loaded settings, or empty settings if not loaded.
+func (opts *GlobalOptions) config() *config.Config {
+	if opts.Config == nil {
+		return &config.Config{}
+	}
+	return opts.Config
+}
+
+// defaultWorkerCount gets the configured number of concurrent workers, or DefaultWorkerCount.
+func defaultWorkerCount(opts *GlobalOptions) int {
+	if workers := opts.config().Workers; workers > 0 {
+		return workers
+	}
+	return DefaultWorkerCount
+}
+
+// effectiveWorkerCount gets workerCount, or the configured number of workers if workerCount is less than 1.
+func effectiveWorkerCount(opts *GlobalOptions, workerCount int) int {
+	if workerCount < 1 {
+		return defaultWorkerCount(opts)
+	}
+	return workerCount
+}
+
+// timeNow gets the current time.
+func timeNow(opts *GlobalOptions) time.Time {
+	if !opts.now.IsZero() {
+		return opts.now
+	}
+	return time.Now()
+}
+
+// sleep waits for d, and returns false if the command should stop.
+func sleep(opts *GlobalOptions, d time.Duration) bool {
+	if opts.sleep != nil {
+		return opts.sleep(d)
+	}
+	time.Sleep(d)
+	return true
+}
+
+// terminalWidth gets the width of the terminal, or 80 if not a terminal.
+func terminalWidth(opts *GlobalOptions) int {
+	width, _ := terminalSize(opts)
+	return width
+}
+
+// terminalSize gets the width and height of the terminal, or 80 by 24 if not a terminal.
+func terminalSize(opts *GlobalOptions) (width, height int) {
+	if opts.width > 0 && opts.height > 0 {
+		return opts.width, opts.height
+	}
+
+	width, height, err := term.FromEnv().Size()
+	if err != nil || width <= 0 || height <= 0 {
+		width, height = 80, 24
+	}
+
+	if opts.width > 0 {
+		width = opts.width
+	}
+
+	return width, height
+}
+
+type urlBrowser interface {
+	Browse(url string) error
+}
+
+// openInBrowser opens the url using the browser configured for gh, or from GH_BROWSER or BROWSER.
+func openInBrowser(opts *GlobalOptions, url string) error {
+	b := opts.browser
+	if b == nil {
+		launcher := browser.New("", opts.Console.Stdout(), opts.Console.Stderr())
+		b = &launcher
+	}
+
+	if opts.Console.IsStderrTTY() {
+		fmt.Fprintf(opts.Console.Stderr(), "Opening %s in your browser.\n", url)
+	}
+
+	return b.Browse(url)
 }
 
 func IntRangeVarP(cmd *cobra.Command, p *int, name, shorthand string, defaultValue int, min, max int, usage string) {
@@ -144,13 +273,27 @@ func (v *enumValue) Type() string {
 	return "string"
 }
 
-func ProjectNumberArg(number *int) cobra.PositionalArgs {
+// ProjectNumberArg parses the first argument as a project number, URL, alias, or title, or uses the configured default project.
+// URLs, aliases, and titles are resolved by ResolveProjectArg after the repository is resolved.
+func ProjectNumberArg(globalOpts *GlobalOptions, number *int) cobra.PositionalArgs {
 	return func(cmd *cobra.Command, args []string) (err error) {
-		if len(args) == 0 {
+		value := globalOpts.config().Project
+		if len(args) > 0 {
+			value = args[0]
+		} else if value == "" {
+			// A default project may not have been loaded from invalid settings.
+			if err = globalOpts.configErr; err != nil {
+				return
+			}
 			return fmt.Errorf("missing required project number")
 		}
 
-		*number, err = parseNumber(args[0], "invalid project number")
+		if project, ok := globalOpts.config().Alias(value); ok {
+			globalOpts.projectArg, err = parseAlias(value, project, number)
+			return
+		}
+
+		globalOpts.projectArg, err = parseProjectArg(value, number)
 		return
 	}
 }
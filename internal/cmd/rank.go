@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func newItemMoveCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := itemMoveOptions{}
+	cmd := &cobra.Command{
+		Use:   "move <number> <item>",
+		Short: "Move a project item",
+		Long: heredoc.Doc(`
+			Move an item to the top of a project, or after another item. Boards and
+			tables sorted manually in the browser show items in this order.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Items are referenced by their issue or pull request number, which can
+			begin with a "#" symbol, or by their project item ID. Issues and pull
+			requests from the current repository are preferred.
+		`),
+		Example: heredoc.Doc(`
+			# move issue 4 after issue 2
+			$ gh projects item move 1 4 --after 2
+
+			# move issue 4 to the top
+			$ gh projects item move 1 4 --top
+		`),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := ProjectNumberArg(globalOpts, &opts.number)(cmd, args); err != nil {
+				return err
+			}
+			if len(args) < 2 {
+				return fmt.Errorf("missing required item")
+			}
+			opts.item = args[1]
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			if (opts.after == "") == !opts.top {
+				return fmt.Errorf("specify only one of --after or --top")
+			}
+
+			return itemMove(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.after, "after", "", "Move after the `item`")
+	cmd.Flags().BoolVar(&opts.top, "top", false, "Move to the top")
+
+	return cmd
+}
+
+type itemMoveOptions struct {
+	GlobalOptions
+
+	number int
+	item   string
+	after  string
+	top    bool
+}
+
+func itemMove(opts *itemMoveOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	item, err := findItem(project.Items, opts.item, opts.Repo.Owner()+"/"+opts.Repo.Name())
+	if err != nil {
+		return
+	}
+
+	move := filter.Move{ItemID: item.ID}
+	if opts.after != "" {
+		var after *models.ProjectItem
+		after, err = findItem(project.Items, opts.after, opts.Repo.Owner()+"/"+opts.Repo.Name())
+		if err != nil {
+			return
+		}
+		if after.ID == item.ID {
+			return fmt.Errorf("cannot move an item after itself")
+		}
+		move.AfterID = after.ID
+	}
+
+	err = moveItem(client, project.ID, move)
+	if err != nil {
+		return
+	}
+
+	if opts.Console.IsStdoutTTY() {
+		fmt.Fprintf(opts.Console.Stdout(), "Moved %s\n", itemTitle(*item))
+	}
+
+	return
+}
+
+func newItemRankCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := itemRankOptions{}
+	cmd := &cobra.Command{
+		Use:   "rank <number>",
+		Short: "Reorder project items by field values",
+		Long: heredoc.Doc(`
+			Reorder all items in a project by the values of one or more fields, and
+			show the resulting order with moved items marked by "*".
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Items are sorted by each field in order like views in the browser, e.g.,
+			single select fields by the order of their options. Append ":desc" to a
+			field name to sort in descending order. Items with equal values keep their
+			current order, and only the fewest items necessary are moved. Archived
+			items are not moved.
+		`),
+		Example: heredoc.Doc(`
+			# preview the order by priority, then largest estimate first
+			$ gh projects item rank 1 --by Priority,Estimate:desc --dry-run
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			return itemRank(&opts)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.by, "by", nil, "Sort by the `fields` in order")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show the resulting order without moving items")
+	_ = cmd.MarkFlagRequired("by")
+	_ = cmd.RegisterFlagCompletionFunc("by", completeFieldNames(globalOpts))
+
+	return cmd
+}
+
+type itemRankOptions struct {
+	GlobalOptions
+
+	number int
+	by     []string
+	dryRun bool
+}
+
+func itemRank(opts *itemRankOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	sortBy := make([]models.ProjectViewSortBy, len(opts.by))
+	fields := make([]string, len(opts.by))
+	for i, by := range opts.by {
+		name, direction, _ := strings.Cut(by, ":")
+		switch strings.ToLower(direction) {
+		case "", "asc":
+			sortBy[i].Direction = "ASC"
+		case "desc":
+			sortBy[i].Direction = "DESC"
+		default:
+			return fmt.Errorf("invalid sort direction %q; must be asc or desc", direction)
+		}
+
+		var field *models.ProjectField
+		field, err = findField(projectFields, name)
+		if err != nil {
+			return
+		}
+		sortBy[i].Field = *field
+		fields[i] = field.Name
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := make([]models.ProjectItem, 0, len(project.Items))
+	for _, item := range project.Items {
+		if !item.IsArchived {
+			items = append(items, item)
+		}
+	}
+
+	current := make([]string, len(items))
+	for i, item := range items {
+		current[i] = item.ID
+	}
+
+	filter.Sort(items, sortBy)
+
+	target := make([]string, len(items))
+	for i, item := range items {
+		target[i] = item.ID
+	}
+
+	moves := filter.Moves(current, target)
+	moved := make(map[string]bool, len(moves))
+	for _, move := range moves {
+		moved[move.ItemID] = true
+	}
+
+	if !opts.dryRun && len(moves) > 0 {
+		opts.Console.StartProgress(fmt.Sprintf("Moving %d of %d items in %s", len(moves), len(items), project.URL))
+		for _, move := range moves {
+			if err = moveItem(client, project.ID, move); err != nil {
+				break
+			}
+		}
+		opts.Console.StopProgress()
+
+		if err != nil {
+			return
+		}
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	return t.Ranked(items, fields, moved, opts.dryRun)
+}
+
+// findItem finds an item by issue or pull request number, preferring those from repo, or by project item ID.
+func findItem(items []models.ProjectItem, ref, repo string) (*models.ProjectItem, error) {
+	if number, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		var found []*models.ProjectItem
+		for i, item := range items {
+			if item.Content.Number != number {
+				continue
+			}
+			if item.Content.Repository != nil && strings.EqualFold(item.Content.Repository.NameWithOwner, repo) {
+				return &items[i], nil
+			}
+			found = append(found, &items[i])
+		}
+
+		switch len(found) {
+		case 0:
+			return nil, fmt.Errorf("project does not reference #%d", number)
+		case 1:
+			return found[0], nil
+		default:
+			return nil, fmt.Errorf("project references #%d in multiple repositories; use the item ID", number)
+		}
+	}
+
+	for i, item := range items {
+		if item.ID == ref {
+			return &items[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("item not found: %s", ref)
+}
+
+// moveItem moves an item after another item, or to the top.
+func moveItem(client api.GQLClient, projectID string, move filter.Move) error {
+	vars := map[string]interface{}{
+		"projectId": projectID,
+		"itemId":    move.ItemID,
+	}
+	if move.AfterID != "" {
+		vars["afterId"] = move.AfterID
+	}
+
+	var data map[string]interface{}
+	return client.Do(mutationUpdateProjectV2ItemPosition, vars, &data)
+}
+
+const mutationUpdateProjectV2ItemPosition = `
+mutation UpdateProjectV2ItemPosition($projectId: ID!, $itemId: ID!, $afterId: ID) {
+	updateProjectV2ItemPosition(input: {projectId: $projectId, itemId: $itemId, afterId: $afterId}) {
+		clientMutationId
+	}
+}
+`
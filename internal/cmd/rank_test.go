@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const rankFieldsJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"fields": {
+					"nodes": [
+						{"id": "PNF_Title", "name": "Title", "dataType": "TITLE"},
+						{
+							"id": "PNF_Status",
+							"name": "Status",
+							"dataType": "SINGLE_SELECT",
+							"options": [
+								{"id": "1", "name": "Todo"},
+								{"id": "2", "name": "In Progress"},
+								{"id": "3", "name": "Done"}
+							]
+						},
+						{"id": "PNF_Estimate", "name": "Estimate", "dataType": "NUMBER"}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestNewItemMoveCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "no item",
+			args:    []string{"1"},
+			wantErr: "missing required item",
+		},
+		{
+			name:    "no position",
+			args:    []string{"1", "2"},
+			wantErr: "specify only one of --after or --top",
+		},
+		{
+			name:    "after and top",
+			args:    []string{"1", "2", "--after", "3", "--top"},
+			wantErr: "specify only one of --after or --top",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newItemMoveCmd(&GlobalOptions{
+				Console: console.Fake(),
+			})
+			cmd.SilenceUsage = true
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestItemMove(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     string
+		after    string
+		wantBody string
+		wantErr  string
+	}{
+		{
+			name:     "top",
+			item:     "#3",
+			wantBody: `\{"itemId":"PNI_3","projectId":"PN_1"\}`,
+		},
+		{
+			name:     "after",
+			item:     "1",
+			after:    "PNI_3",
+			wantBody: `\{"afterId":"PNI_3","itemId":"PNI_1","projectId":"PN_1"\}`,
+		},
+		{
+			name:    "not found",
+			item:    "4",
+			wantErr: "project does not reference #4",
+		},
+		{
+			name:    "after itself",
+			item:    "1",
+			after:   "#1",
+			wantErr: "cannot move an item after itself",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemFields`).
+				Reply(200).
+				JSON(itemFieldsJSON)
+			if tt.wantBody != "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`UpdateProjectV2ItemPosition`).
+					BodyString(tt.wantBody).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2ItemPosition":{"clientMutationId":null}}}`)
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake(console.WithStdoutTTY(true))
+			opts := &itemMoveOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				item:   tt.item,
+				after:  tt.after,
+				top:    tt.after == "",
+			}
+
+			err = itemMove(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+		})
+	}
+}
+
+func TestItemRank(t *testing.T) {
+	tests := []struct {
+		name       string
+		by         []string
+		dryRun     bool
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:   "dry run",
+			by:     []string{"Status"},
+			dryRun: true,
+			wantStdout: heredoc.Doc(`
+				*  1  #3  Write docs      Todo
+				   2  #1  Fix the parser  In Progress
+				   3  #2  Add a feature   Done
+				Would move 1 item
+			`),
+		},
+		{
+			name: "descending",
+			by:   []string{"Estimate:desc"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`\{"afterId":"PNI_3","itemId":"PNI_1","projectId":"PN_1"\}`).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2ItemPosition":{"clientMutationId":null}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				   1  #2  Add a feature   8
+				   2  #3  Write docs      5
+				*  3  #1  Fix the parser  3
+				Moved 1 item
+			`),
+		},
+		{
+			name:    "invalid direction",
+			by:      []string{"Status:up"},
+			wantErr: `invalid sort direction "up"; must be asc or desc`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(rankFieldsJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(itemFieldsJSON)
+			}
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &itemRankOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				by:     tt.by,
+				dryRun: tt.dryRun,
+			}
+
+			err = itemRank(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
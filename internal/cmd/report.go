@@ -0,0 +1,627 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/report"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func NewReportCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Report on project progress",
+		Long: heredoc.Doc(`
+			Report on the progress of items in a project.
+		`),
+	}
+
+	cmd.AddCommand(newReportBurndownCmd(globalOpts))
+	cmd.AddCommand(newReportFlowCmd(globalOpts))
+	cmd.AddCommand(newReportStaleCmd(globalOpts))
+
+	return cmd
+}
+
+func newReportBurndownCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := reportBurndownOptions{}
+	cmd := &cobra.Command{
+		Use:   "burndown <number>",
+		Short: "Report points remaining in an iteration",
+		Long: heredoc.Doc(`
+			Report the points remaining on each day of an iteration, or the points
+			completed with --burnup.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass --iteration with the name of an iteration, or "@current", "@previous",
+			or "@next". Pass --points-field with the name of a number field to sum its
+			values; otherwise, items are counted.
+
+			Items are completed on the day their issue or pull request was closed, or
+			their status changed to --done. Status changes are read from issue and pull
+			request timelines, so items that are done but still open are completed until
+			their status changes again.
+
+			Archived items are not included unless --include-archived is passed.
+
+			A chart is shown in a terminal; otherwise, CSV is written. Pass --format to
+			write CSV, JSON, or a Mermaid xychart.
+		`),
+		Example: heredoc.Doc(`
+			# show the estimate remaining in the current iteration
+			$ gh projects report burndown 1 --points-field Estimate
+
+			# export the estimate completed in the previous iteration for documentation
+			$ gh projects report burndown 1 --iteration @previous --points-field Estimate --burnup --format mermaid
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			applyConfigFormat(cmd, globalOpts, "format")
+
+			if opts.format == "" {
+				opts.format = "csv"
+				if opts.Console.IsStdoutTTY() {
+					opts.format = "chart"
+				}
+			}
+
+			return reportBurndown(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.iterationField, "iteration-field", "Iteration", "Iteration `field` of items")
+	cmd.Flags().StringVar(&opts.iteration, "iteration", "@current", "Iteration `name` to report")
+	cmd.Flags().StringVar(&opts.pointsField, "points-field", "", "Number `field` to sum instead of counting items")
+	cmd.Flags().StringVar(&opts.statusField, "status-field", "Status", "Single select `field` of item status")
+	cmd.Flags().StringVar(&opts.done, "done", "Done", "The `status` of completed items")
+	cmd.Flags().BoolVar(&opts.burnup, "burnup", false, "Report points completed instead of remaining")
+	cmd.Flags().BoolVar(&opts.includeArchived, "include-archived", false, "Include archived items")
+	StringEnumVarP(cmd, &opts.format, "format", "", "", []string{"chart", "csv", "json", "mermaid"}, "Output format")
+
+	_ = cmd.RegisterFlagCompletionFunc("iteration-field", completeFieldNames(globalOpts, "ITERATION"))
+	_ = cmd.RegisterFlagCompletionFunc("iteration", completeFieldOptions(globalOpts, "iteration-field", "@current", "@previous", "@next"))
+	_ = cmd.RegisterFlagCompletionFunc("points-field", completeFieldNames(globalOpts, "NUMBER"))
+	_ = cmd.RegisterFlagCompletionFunc("status-field", completeFieldNames(globalOpts, "SINGLE_SELECT"))
+	_ = cmd.RegisterFlagCompletionFunc("done", completeFieldOptions(globalOpts, "status-field"))
+
+	return cmd
+}
+
+type reportBurndownOptions struct {
+	GlobalOptions
+
+	number          int
+	iterationField  string
+	iteration       string
+	pointsField     string
+	statusField     string
+	done            string
+	burnup          bool
+	includeArchived bool
+	format          string
+}
+
+func reportBurndown(opts *reportBurndownOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	fields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	iterationField, err := findField(fields, opts.iterationField)
+	if err != nil {
+		return
+	}
+	if iterationField.DataType != "ITERATION" {
+		return fmt.Errorf("field %q is not an iteration field", iterationField.Name)
+	}
+
+	if opts.pointsField != "" {
+		pointsField, err := findField(fields, opts.pointsField)
+		if err != nil {
+			return err
+		}
+		if pointsField.DataType != "NUMBER" {
+			return fmt.Errorf("field %q is not a number field", pointsField.Name)
+		}
+	}
+
+	statusField, err := findField(fields, opts.statusField)
+	if err != nil {
+		return
+	}
+	if statusField.DataType != "SINGLE_SELECT" {
+		return fmt.Errorf("field %q is not a single select field", statusField.Name)
+	}
+
+	// Make sure the done status is an option.
+	if _, err = models.NewField(*statusField, opts.done); err != nil {
+		return
+	}
+
+	now := timeNow(&opts.GlobalOptions)
+	iteration, err := iterationField.FindIteration(opts.iteration, now)
+	if err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := project.Items
+	if !opts.includeArchived {
+		items = unarchivedItems(items)
+	}
+
+	// Only get timelines for issues and pull requests in the iteration.
+	var ids []string
+	for _, item := range items {
+		if item.Type == "DRAFT_ISSUE" || item.Content.ID == "" {
+			continue
+		}
+
+		if value, ok := item.FieldValue(iterationField.Name); ok && strings.EqualFold(value.Title, iteration.Name) {
+			ids = append(ids, item.Content.ID)
+		}
+	}
+
+	events, err := listProjectEvents(client, project.ID, ids)
+	if err != nil {
+		return
+	}
+
+	burndown := report.NewBurndown(items, events, *iteration, report.Options{
+		IterationField: iterationField.Name,
+		PointsField:    opts.pointsField,
+		Done:           opts.done,
+		Now:            now,
+	})
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	switch opts.format {
+	case "csv":
+		return t.BurndownCSV(burndown)
+	case "json":
+		return t.BurndownJSON(burndown)
+	case "mermaid":
+		return t.BurndownMermaid(burndown, opts.burnup)
+	default:
+		return t.BurndownChart(burndown, opts.burnup, terminalWidth(&opts.GlobalOptions))
+	}
+}
+
+func newReportFlowCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := reportFlowOptions{}
+	cmd := &cobra.Command{
+		Use:   "flow <number>",
+		Short: "Report throughput, lead time, and cycle time",
+		Long: heredoc.Doc(`
+			Report the number of items completed each week, and percentiles and a
+			histogram of how long items took to complete.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Lead time is from when an issue or pull request was created until it was
+			closed. Cycle time is from when the status of an item first changed from the
+			first status e.g., "Todo", until the status changed to --done.
+
+			Status changes are read from issue and pull request timelines. If none are
+			available, items are completed when closed and cycle time starts when items
+			were added to the project.
+
+			Pass --since with a number of days or weeks e.g., "30d" or "4w", or a date
+			e.g., "2022-08-01". Archived items are not included unless --include-archived
+			is passed.
+		`),
+		Example: heredoc.Doc(`
+			# report flow over the last 30 days
+			$ gh projects report flow 1
+
+			# report flow over the last 12 weeks as JSON
+			$ gh projects report flow 1 --since 12w --format json
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			applyConfigFormat(cmd, globalOpts, "format")
+
+			return reportFlow(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.statusField, "status-field", "Status", "Single select `field` of item status")
+	cmd.Flags().StringVar(&opts.done, "done", "Done", "The `status` of completed items")
+	cmd.Flags().StringVar(&opts.since, "since", "30d", "Report items completed since a number of days or weeks ago, or a date")
+	cmd.Flags().BoolVar(&opts.includeArchived, "include-archived", false, "Include archived items")
+	StringEnumVarP(cmd, &opts.format, "format", "", "table", []string{"table", "json"}, "Output format")
+
+	_ = cmd.RegisterFlagCompletionFunc("status-field", completeFieldNames(globalOpts, "SINGLE_SELECT"))
+	_ = cmd.RegisterFlagCompletionFunc("done", completeFieldOptions(globalOpts, "status-field"))
+
+	return cmd
+}
+
+type reportFlowOptions struct {
+	GlobalOptions
+
+	number          int
+	statusField     string
+	done            string
+	since           string
+	includeArchived bool
+	format          string
+}
+
+func reportFlow(opts *reportFlowOptions) (err error) {
+	now := timeNow(&opts.GlobalOptions)
+	since, err := report.ParseSince(opts.since, now)
+	if err != nil {
+		return
+	}
+
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	statusField, err := getField(client, opts.number, opts.statusField, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+	if statusField.DataType != "SINGLE_SELECT" {
+		return fmt.Errorf("field %q is not a single select field", statusField.Name)
+	}
+
+	// Make sure the done status is an option.
+	if _, err = models.NewField(*statusField, opts.done); err != nil {
+		return
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := project.Items
+	if !opts.includeArchived {
+		items = unarchivedItems(items)
+	}
+
+	// Only get timelines for items that may have been completed within the period.
+	var ids []string
+	for _, item := range items {
+		if item.Type == "DRAFT_ISSUE" || item.Content.ID == "" {
+			continue
+		}
+
+		status, _ := item.FieldValue(statusField.Name)
+		closedAt := item.Content.ClosedAt
+		if strings.EqualFold(status.Name, opts.done) || closedAt != nil && !closedAt.Before(since) {
+			ids = append(ids, item.Content.ID)
+		}
+	}
+
+	events, err := listProjectEvents(client, project.ID, ids)
+	if err != nil {
+		return
+	}
+
+	todo := ""
+	if len(statusField.Options) > 0 {
+		todo = statusField.Options[0].Name
+	}
+
+	flow := report.NewFlow(items, events, report.FlowOptions{
+		StatusField: statusField.Name,
+		Todo:        todo,
+		Done:        opts.done,
+		Since:       since,
+		Now:         now,
+	})
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if opts.format == "json" {
+		return t.FlowJSON(flow)
+	}
+
+	return t.FlowTable(flow)
+}
+
+func newReportStaleCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := reportStaleOptions{}
+	cmd := &cobra.Command{
+		Use:   "stale <number>",
+		Short: "Report stale items",
+		Long: heredoc.Doc(`
+			Report items whose issue or pull request has not been updated, or whose
+			status has not changed, within a number of days; and items whose issue or
+			pull request is closed or merged but whose status is not --done.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass --fix to set the status of closed and merged items to --done, or pass
+			--field to set other field values instead.
+		`),
+		Example: heredoc.Doc(`
+			# report items in progress that have not changed in 2 weeks
+			$ gh projects report stale 1 --days 14 --status "In Progress"
+
+			# set the status of closed and merged items to Done
+			$ gh projects report stale 1 --fix
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			if len(opts.fields) > 0 && !opts.fix {
+				return fmt.Errorf("--field requires --fix")
+			}
+
+			return reportStale(&opts)
+		},
+	}
+
+	IntRangeVarP(cmd, &opts.days, "days", "", 14, 1, 365, "Number of days without activity before items are stale")
+	cmd.Flags().StringVar(&opts.status, "status", "", "Only report items with the `status`")
+	cmd.Flags().StringVar(&opts.statusField, "status-field", "Status", "Single select `field` of item status")
+	cmd.Flags().StringVar(&opts.done, "done", "Done", "The `status` of completed items")
+	cmd.Flags().BoolVar(&opts.fix, "fix", false, "Set the status of closed and merged items to --done")
+	StringToStringVarP(cmd, &opts.fields, "field", "f", nil, "Set field values of closed and merged items with --fix")
+
+	_ = cmd.RegisterFlagCompletionFunc("status", completeFieldOptions(globalOpts, "status-field"))
+	_ = cmd.RegisterFlagCompletionFunc("status-field", completeFieldNames(globalOpts, "SINGLE_SELECT"))
+	_ = cmd.RegisterFlagCompletionFunc("done", completeFieldOptions(globalOpts, "status-field"))
+	_ = cmd.RegisterFlagCompletionFunc("field", completeFieldValues(globalOpts))
+
+	return cmd
+}
+
+type reportStaleOptions struct {
+	GlobalOptions
+
+	number      int
+	days        int
+	status      string
+	statusField string
+	done        string
+	fix         bool
+	fields      map[string]string
+}
+
+func reportStale(opts *reportStaleOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	statusField, err := findField(projectFields, opts.statusField)
+	if err != nil {
+		return
+	}
+	if statusField.DataType != "SINGLE_SELECT" {
+		return fmt.Errorf("field %q is not a single select field", statusField.Name)
+	}
+
+	// Resolve fields to fix before finding stale items.
+	var fields map[string]models.Field
+	if opts.fix {
+		values := opts.fields
+		if len(values) == 0 {
+			values = map[string]string{statusField.Name: opts.done}
+		}
+
+		fields = make(map[string]models.Field, len(values))
+		for name, value := range values {
+			var field *models.Field
+			field, err = resolveField(projectFields, name, value)
+			if err != nil {
+				return
+			}
+			if field == nil {
+				return fmt.Errorf("field %q not defined", name)
+			}
+			fields[name] = *field
+		}
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	// Archived items are not stale.
+	items := unarchivedItems(project.Items)
+
+	// Only get timelines for open issues and pull requests that may be stale.
+	var ids []string
+	for _, item := range items {
+		if item.Type == "DRAFT_ISSUE" || item.Content.ID == "" || item.Content.State != "OPEN" {
+			continue
+		}
+
+		status, _ := item.FieldValue(statusField.Name)
+		if strings.EqualFold(status.Name, opts.done) || opts.status != "" && !strings.EqualFold(status.Name, opts.status) {
+			continue
+		}
+
+		ids = append(ids, item.Content.ID)
+	}
+
+	events, err := listProjectEvents(client, project.ID, ids)
+	if err != nil {
+		return
+	}
+
+	stale := report.FindStale(items, events, report.StaleOptions{
+		StatusField: statusField.Name,
+		Status:      opts.status,
+		Done:        opts.done,
+		Days:        opts.days,
+		Now:         timeNow(&opts.GlobalOptions),
+	})
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	err = t.Stale(stale)
+	if err != nil || !opts.fix {
+		return
+	}
+
+	var itemIDs []string
+	for _, s := range stale {
+		if s.Closed() {
+			itemIDs = append(itemIDs, s.Item.ID)
+		}
+	}
+	if len(itemIDs) == 0 {
+		return
+	}
+
+	count := text.Pluralize(len(itemIDs), "item")
+	opts.Console.StartProgress(fmt.Sprintf("Updating %s", count))
+	err = updateItemsFields(client, project.ID, itemIDs, fields)
+	opts.Console.StopProgress()
+
+	if err != nil {
+		return
+	}
+
+	if opts.Console.IsStdoutTTY() {
+		fmt.Fprintf(opts.Console.Stdout(), "Updated %s\n", count)
+	}
+
+	return
+}
+
+// listProjectEvents gets the project timeline events of issues and pull requests keyed by content ID.
+func listProjectEvents(client api.GQLClient, projectID string, ids []string) (map[string][]report.Event, error) {
+	const batchSize = 50
+
+	events := make(map[string][]report.Event, len(ids))
+	for start := 0; start < len(ids); start += batchSize {
+		end := start + batchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var data struct {
+			Nodes []struct {
+				ID            string
+				TimelineItems struct {
+					Nodes []struct {
+						Type      string `json:"__typename"`
+						Status    string
+						CreatedAt time.Time
+						Project   struct {
+							ID string
+						}
+					}
+				}
+			}
+		}
+
+		vars := map[string]interface{}{
+			"ids": ids[start:end],
+		}
+
+		err := client.Do(queryProjectV2ItemEvents, vars, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range data.Nodes {
+			for _, event := range node.TimelineItems.Nodes {
+				if event.Project.ID != projectID {
+					continue
+				}
+
+				events[node.ID] = append(events[node.ID], report.Event{
+					Type:      event.Type,
+					Status:    event.Status,
+					CreatedAt: event.CreatedAt,
+				})
+			}
+
+			sort.SliceStable(events[node.ID], func(i, j int) bool {
+				return events[node.ID][i].CreatedAt.Before(events[node.ID][j].CreatedAt)
+			})
+		}
+	}
+
+	return events, nil
+}
+
+const queryProjectV2ItemEvents = `
+query ProjectV2ItemEvents($ids: [ID!]!) {
+	nodes(ids: $ids) {
+		...on Issue {
+			id
+			timelineItems(first: 100, itemTypes: [ADDED_TO_PROJECT_V2_EVENT, PROJECT_V2_ITEM_STATUS_CHANGED_EVENT]) {
+				nodes {
+					...projectEvents
+				}
+			}
+		}
+		...on PullRequest {
+			id
+			timelineItems(first: 100, itemTypes: [ADDED_TO_PROJECT_V2_EVENT, PROJECT_V2_ITEM_STATUS_CHANGED_EVENT]) {
+				nodes {
+					...projectEvents
+				}
+			}
+		}
+	}
+}
+
+fragment projectEvents on Node {
+	__typename
+	...on AddedToProjectV2Event {
+		createdAt
+		project {
+			id
+		}
+	}
+	...on ProjectV2ItemStatusChangedEvent {
+		createdAt
+		status
+		project {
+			id
+		}
+	}
+}
+`
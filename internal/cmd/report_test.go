@@ -0,0 +1,789 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/heaths/go-console/pkg/colorscheme"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const iterationFieldsJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"fields": {
+					"nodes": [
+						{"id": "PNF_Title", "name": "Title", "dataType": "TITLE"},
+						{
+							"id": "PNF_Iteration",
+							"name": "Iteration",
+							"dataType": "ITERATION",
+							"configuration": {
+								"iterations": [
+									{"id": "2", "name": "Iteration 2", "startDate": "2026-10-12", "duration": 7},
+									{"id": "3", "name": "Iteration 3", "startDate": "2026-10-19", "duration": 7}
+								],
+								"completedIterations": [
+									{"id": "1", "name": "Iteration 1", "startDate": "2026-10-05", "duration": 7}
+								]
+							}
+						},
+						{"id": "PNF_Estimate", "name": "Estimate", "dataType": "NUMBER"},
+						{
+							"id": "PNF_Status",
+							"name": "Status",
+							"dataType": "SINGLE_SELECT",
+							"options": [
+								{"id": "1", "name": "Todo"},
+								{"id": "2", "name": "Done"}
+							]
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+const iterationItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Project",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 4,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "CLOSED", "closedAt": "2026-10-13T15:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+									{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_2",
+							"type": "ISSUE",
+							"content": {"id": "I_2", "number": 2, "title": "Add a feature", "state": "OPEN"},
+							"fieldValues": {
+								"nodes": [
+									{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+									{"number": 5, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_3",
+							"type": "ISSUE",
+							"content": {"id": "I_3", "number": 3, "title": "Plan the next release", "state": "OPEN"},
+							"fieldValues": {
+								"nodes": [
+									{"title": "Iteration 3", "startDate": "2026-10-19", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+									{"number": 8, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_4",
+							"type": "ISSUE",
+							"isArchived": true,
+							"content": {"id": "I_4", "number": 4, "title": "Fix a typo", "state": "CLOSED", "closedAt": "2026-10-14T15:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+									{"number": 2, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+								]
+							}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestReportBurndown(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       reportBurndownOptions
+		tty        bool
+		events     string
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name: "chart",
+			opts: reportBurndownOptions{
+				format: "chart",
+			},
+			tty: true,
+			wantStdout: heredoc.Doc(`
+				Iteration 2 burndown of Estimate
+				2026-10-12 to 2026-10-18
+
+				5 of 8 remaining  █▅▅▅
+
+				Mon 10-12  ██████████████████████████████████████████████  8
+				Tue 10-13  █████████████████████████████         ┆         5
+				Wed 10-14  █████████████████████████████  ┆                5
+				Thu 10-15  █████████████████████████████                   5
+			`),
+		},
+		{
+			name: "burnup chart",
+			opts: reportBurndownOptions{
+				format: "chart",
+				burnup: true,
+			},
+			tty: true,
+			wantStdout: heredoc.Doc(`
+				Iteration 2 burnup of Estimate
+				2026-10-12 to 2026-10-18
+
+				3 of 8 completed  ▁▄▄▄
+
+				Mon 10-12  ┆                                               0
+				Tue 10-13  █████████████████                               3
+				Wed 10-14  █████████████████                               3
+				Thu 10-15  █████████████████      ┆                        3
+			`),
+		},
+		{
+			name: "csv",
+			opts: reportBurndownOptions{
+				format: "csv",
+			},
+			wantStdout: heredoc.Doc(`
+				date,remaining,completed,ideal
+				2026-10-12,8,0,8
+				2026-10-13,5,3,6.7
+				2026-10-14,5,3,5.3
+				2026-10-15,5,3,4
+			`),
+		},
+		{
+			name: "done but open",
+			opts: reportBurndownOptions{
+				format: "csv",
+			},
+			events: `{"data":{"nodes":[{"id":"I_2","timelineItems":{"nodes":[
+				{"__typename":"ProjectV2ItemStatusChangedEvent","status":"Done","createdAt":"2026-10-14T10:00:00Z","project":{"id":"PN_1"}}
+			]}}]}}`,
+			wantStdout: heredoc.Doc(`
+				date,remaining,completed,ideal
+				2026-10-12,8,0,8
+				2026-10-13,5,3,6.7
+				2026-10-14,0,8,5.3
+				2026-10-15,0,8,4
+			`),
+		},
+		{
+			name: "include archived",
+			opts: reportBurndownOptions{
+				format:          "csv",
+				includeArchived: true,
+			},
+			wantStdout: heredoc.Doc(`
+				date,remaining,completed,ideal
+				2026-10-12,10,0,10
+				2026-10-13,7,3,8.3
+				2026-10-14,5,5,6.7
+				2026-10-15,5,5,5
+			`),
+		},
+		{
+			name: "json",
+			opts: reportBurndownOptions{
+				format: "json",
+			},
+			wantStdout: heredoc.Doc(`
+				{
+				  "iteration": "Iteration 2",
+				  "field": "Estimate",
+				  "startDate": "2026-10-12",
+				  "endDate": "2026-10-18",
+				  "total": 8,
+				  "days": [
+				    {
+				      "date": "2026-10-12",
+				      "remaining": 8,
+				      "completed": 0,
+				      "ideal": 8
+				    },
+				    {
+				      "date": "2026-10-13",
+				      "remaining": 5,
+				      "completed": 3,
+				      "ideal": 6.7
+				    },
+				    {
+				      "date": "2026-10-14",
+				      "remaining": 5,
+				      "completed": 3,
+				      "ideal": 5.3
+				    },
+				    {
+				      "date": "2026-10-15",
+				      "remaining": 5,
+				      "completed": 3,
+				      "ideal": 4
+				    }
+				  ]
+				}
+			`),
+		},
+		{
+			name: "mermaid",
+			opts: reportBurndownOptions{
+				format: "mermaid",
+			},
+			wantStdout: heredoc.Doc(`
+				` + "```" + `mermaid
+				xychart-beta
+				    title "Iteration 2 burndown"
+				    x-axis ["10-12", "10-13", "10-14", "10-15"]
+				    y-axis "Estimate" 0 --> 8
+				    bar [8, 5, 5, 5]
+				    line [8, 6.7, 5.3, 4]
+				` + "```" + `
+			`),
+		},
+		{
+			name: "iteration not found",
+			opts: reportBurndownOptions{
+				iteration: "Iteration 9",
+			},
+			wantErr: `iteration not defined for field "Iteration": Iteration 9`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(iterationFieldsJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(iterationItemsJSON)
+
+				if tt.events == "" {
+					tt.events = `{"data":{"nodes":[]}}`
+				}
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`ProjectV2ItemEvents`).
+					Reply(200).
+					JSON(tt.events)
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			// Disable colors to compare layout.
+			fake := console.Fake(console.WithStdoutTTY(tt.tty), console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))))
+			opts := &tt.opts
+			opts.GlobalOptions = GlobalOptions{
+				Console: fake,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+				width:     60,
+				now:       time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+			}
+			opts.number = 1
+			opts.iterationField = "Iteration"
+			opts.pointsField = "Estimate"
+			opts.statusField = "Status"
+			opts.done = "Done"
+			if opts.iteration == "" {
+				opts.iteration = "@current"
+			}
+
+			err = reportBurndown(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+const flowItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Project",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 4,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "CLOSED", "createdAt": "2026-10-01T00:00:00Z", "closedAt": "2026-10-06T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_2",
+							"type": "PULL_REQUEST",
+							"content": {"id": "PR_2", "number": 2, "title": "Add a feature", "state": "MERGED", "createdAt": "2026-10-10T00:00:00Z", "closedAt": "2026-10-13T00:00:00Z"},
+							"fieldValues": {
+								"nodes": []
+							}
+						},
+						{
+							"id": "PNI_3",
+							"type": "DRAFT_ISSUE",
+							"content": {"id": "DI_3", "title": "Write docs", "createdAt": "2026-10-01T00:00:00Z"},
+							"fieldValues": {
+								"nodes": []
+							}
+						},
+						{
+							"id": "PNI_4",
+							"type": "ISSUE",
+							"isArchived": true,
+							"content": {"id": "I_4", "number": 4, "title": "Fix a typo", "state": "CLOSED", "createdAt": "2026-10-02T00:00:00Z", "closedAt": "2026-10-09T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+const flowEventsJSON = `{
+	"data": {
+		"nodes": [
+			{
+				"id": "I_1",
+				"timelineItems": {
+					"nodes": [
+						{"__typename": "AddedToProjectV2Event", "createdAt": "2026-10-01T00:00:00Z", "project": {"id": "PN_1"}},
+						{"__typename": "ProjectV2ItemStatusChangedEvent", "status": "In Progress", "createdAt": "2026-10-03T00:00:00Z", "project": {"id": "PN_1"}},
+						{"__typename": "ProjectV2ItemStatusChangedEvent", "status": "Done", "createdAt": "2026-10-05T00:00:00Z", "project": {"id": "PN_1"}},
+						{"__typename": "ProjectV2ItemStatusChangedEvent", "status": "In Progress", "createdAt": "2026-10-07T00:00:00Z", "project": {"id": "PN_2"}}
+					]
+				}
+			},
+			{
+				"id": "PR_2",
+				"timelineItems": {
+					"nodes": [
+						{"__typename": "AddedToProjectV2Event", "createdAt": "2026-10-11T00:00:00Z", "project": {"id": "PN_1"}}
+					]
+				}
+			}
+		]
+	}
+}`
+
+func TestReportFlow(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		done       string
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:   "table",
+			format: "table",
+			done:   "Done",
+			wantStdout: heredoc.Doc(`
+				Flow 2026-10-01 to 2026-10-15
+
+				WEEK        THROUGHPUT
+				2026-09-28  0
+				2026-10-05  1
+				2026-10-12  1
+
+				            ITEMS  P50   P85   P95
+				Lead time   2      3.0d  5.0d  5.0d
+				Cycle time  2      2.0d  2.0d  2.0d
+
+				CYCLE TIME  ITEMS
+				< 1d        0
+				1-2d        0
+				2-4d        2  ████████████████████████████████████████
+				4-7d        0
+				1-2w        0
+				2-4w        0
+				4w+         0
+			`),
+		},
+		{
+			name:   "json",
+			format: "json",
+			done:   "done",
+			wantStdout: heredoc.Doc(`
+				{
+				  "since": "2026-10-01",
+				  "until": "2026-10-15",
+				  "throughput": [
+				    {
+				      "start": "2026-09-28",
+				      "count": 0
+				    },
+				    {
+				      "start": "2026-10-05",
+				      "count": 1
+				    },
+				    {
+				      "start": "2026-10-12",
+				      "count": 1
+				    }
+				  ],
+				  "leadTime": {
+				    "count": 2,
+				    "p50": 3,
+				    "p85": 5,
+				    "p95": 5
+				  },
+				  "cycleTime": {
+				    "count": 2,
+				    "p50": 2,
+				    "p85": 2,
+				    "p95": 2
+				  },
+				  "histogram": [
+				    {
+				      "label": "< 1d",
+				      "count": 0
+				    },
+				    {
+				      "label": "1-2d",
+				      "count": 0
+				    },
+				    {
+				      "label": "2-4d",
+				      "count": 2
+				    },
+				    {
+				      "label": "4-7d",
+				      "count": 0
+				    },
+				    {
+				      "label": "1-2w",
+				      "count": 0
+				    },
+				    {
+				      "label": "2-4w",
+				      "count": 0
+				    },
+				    {
+				      "label": "4w+",
+				      "count": 0
+				    }
+				  ],
+				  "items": [
+				    {
+				      "number": 1,
+				      "title": "Fix the parser",
+				      "done": "2026-10-05T00:00:00Z",
+				      "leadTime": 5,
+				      "cycleTime": 2
+				    },
+				    {
+				      "number": 2,
+				      "title": "Add a feature",
+				      "done": "2026-10-13T00:00:00Z",
+				      "leadTime": 3,
+				      "cycleTime": 2
+				    }
+				  ]
+				}
+			`),
+		},
+		{
+			name:    "done not defined",
+			done:    "Shipped",
+			wantErr: `option not defined for field "Status": Shipped`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Field\(`).
+				Reply(200).
+				JSON(statusFieldJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(flowItemsJSON)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"ids":\["I_1","PR_2"\]`).
+					Reply(200).
+					JSON(flowEventsJSON)
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake(console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))))
+			opts := &reportFlowOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					now:       time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+				},
+				number:      1,
+				statusField: "Status",
+				done:        tt.done,
+				since:       "2w",
+				format:      tt.format,
+			}
+
+			err = reportFlow(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+const staleItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Project",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 4,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN", "updatedAt": "2026-09-01T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_2",
+							"type": "PULL_REQUEST",
+							"content": {"id": "PR_2", "number": 2, "title": "Add a feature", "state": "MERGED", "updatedAt": "2026-10-14T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_3",
+							"type": "ISSUE",
+							"content": {"id": "I_3", "number": 3, "title": "Write docs", "state": "CLOSED", "updatedAt": "2026-08-01T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_4",
+							"type": "ISSUE",
+							"isArchived": true,
+							"content": {"id": "I_4", "number": 4, "title": "Fix a typo", "state": "OPEN", "updatedAt": "2026-08-01T00:00:00Z"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+								]
+							}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+const staleEventsJSON = `{
+	"data": {
+		"nodes": [
+			{
+				"id": "I_1",
+				"timelineItems": {
+					"nodes": [
+						{"__typename": "AddedToProjectV2Event", "createdAt": "2026-09-01T00:00:00Z", "project": {"id": "PN_1"}}
+					]
+				}
+			}
+		]
+	}
+}`
+
+func TestReportStale(t *testing.T) {
+	tests := []struct {
+		name       string
+		fix        bool
+		fields     map[string]string
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name: "report",
+			wantStdout: heredoc.Doc(`
+				#1  Fix the parser  Todo  no activity, status unchanged
+				#2  Add a feature   Todo  closed but not done
+			`),
+		},
+		{
+			name: "fix",
+			fix:  true,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","i0":"PNI_2","projectId":"PN_1","v0":\{"singleSelectOptionId":"2"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_2"}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				#1  Fix the parser  Todo  no activity, status unchanged
+				#2  Add a feature   Todo  closed but not done
+				Updated 1 item
+			`),
+		},
+		{
+			name:    "fix undefined field",
+			fix:     true,
+			fields:  map[string]string{"Missing": "value"},
+			wantErr: `field "Missing" not defined`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(roadmapFieldsJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(staleItemsJSON)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"ids":\["I_1"\]`).
+					Reply(200).
+					JSON(staleEventsJSON)
+			}
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake(console.WithStdoutTTY(true), console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))))
+			opts := &reportStaleOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					now:       time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+				},
+				number:      1,
+				days:        14,
+				statusField: "Status",
+				done:        "Done",
+				fix:         tt.fix,
+				fields:      tt.fields,
+			}
+
+			err = reportStale(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/auth"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// The maximum number of projects matching a title to consider.
+const maxTitleMatches = 20
+
+// projectArg is a project URL, alias, or title passed instead of a project number, which is resolved
+// by ResolveProjectArg after the repository is resolved.
+type projectArg struct {
+	number *int
+
+	// Set from a project URL or alias. The host may be empty for an alias.
+	host  string
+	owner string
+	n     int
+
+	// Set from a project title.
+	title string
+}
+
+// projectRepository is a repository for the owner of a project referenced by URL.
+// The name is empty unless the current repository has the same owner.
+type projectRepository struct {
+	host  string
+	owner string
+	name  string
+}
+
+func (r projectRepository) Host() string {
+	return r.host
+}
+
+func (r projectRepository) Owner() string {
+	return r.owner
+}
+
+func (r projectRepository) Name() string {
+	return r.name
+}
+
+// parseProjectArg parses a project number, URL, or title. Only numbers are resolved immediately.
+func parseProjectArg(value string, number *int) (*projectArg, error) {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") {
+		host, owner, n, err := parseProjectURL(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return &projectArg{number: number, host: host, owner: owner, n: n}, nil
+	}
+
+	// A "#" prefix or all digits must be a number.
+	if strings.HasPrefix(value, "#") || strings.Trim(value, "0123456789") == "" {
+		n, err := parseNumber(value, "invalid project number")
+		if err != nil {
+			return nil, err
+		}
+
+		*number = n
+		return nil, nil
+	}
+
+	return &projectArg{number: number, title: value}, nil
+}
+
+// parseAlias parses the project of an alias as [HOST/]OWNER/NUMBER or a project URL.
+func parseAlias(name, project string, number *int) (*projectArg, error) {
+	if strings.HasPrefix(project, "http://") || strings.HasPrefix(project, "https://") {
+		return parseProjectArg(project, number)
+	}
+
+	arg := &projectArg{number: number}
+	parts := strings.Split(project, "/")
+	switch len(parts) {
+	case 2:
+		arg.owner = parts[0]
+	case 3:
+		arg.host, arg.owner = strings.ToLower(parts[0]), parts[1]
+	}
+
+	n, err := parseNumber(parts[len(parts)-1], "invalid project number")
+	if arg.owner == "" || err != nil {
+		return nil, fmt.Errorf("invalid project %q for alias %q; must be [HOST/]OWNER/NUMBER or a project URL", project, name)
+	}
+	arg.n = n
+
+	return arg, nil
+}
+
+// parseProjectURL parses a URL like https://github.com/orgs/acme/projects/12, optionally followed by a view.
+func parseProjectURL(value string) (host, owner string, number int, err error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid project URL: %s", value)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || (parts[0] != "orgs" && parts[0] != "users") || parts[2] != "projects" {
+		return "", "", 0, fmt.Errorf("invalid project URL: %s", value)
+	}
+
+	number, err = parseNumber(parts[3], "invalid project URL")
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid project URL: %s", value)
+	}
+
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www."), parts[1], number, nil
+}
+
+// ProjectOwner gets the host and owner of a project URL or alias passed instead of a project number, if any.
+// The host may be empty for an alias.
+func (opts *GlobalOptions) ProjectOwner() (host, owner string) {
+	if opts.projectArg == nil {
+		return "", ""
+	}
+
+	return opts.projectArg.host, opts.projectArg.owner
+}
+
+// ResolveProjectArg resolves a project URL, alias, or title passed instead of a project number.
+// A URL or alias sets the host and owner of opts.Repo, and a title is matched against projects of the owner.
+func ResolveProjectArg(opts *GlobalOptions) error {
+	arg := opts.projectArg
+	if arg == nil {
+		return nil
+	}
+	opts.projectArg = nil
+
+	if arg.owner != "" {
+		if arg.host == "" {
+			if opts.Repo != nil {
+				arg.host = opts.Repo.Host()
+			} else {
+				arg.host, _ = auth.DefaultHost()
+			}
+		}
+
+		repo := projectRepository{
+			host:  arg.host,
+			owner: arg.owner,
+		}
+		if opts.Repo != nil && strings.EqualFold(opts.Repo.Host(), arg.host) && strings.EqualFold(opts.Repo.Owner(), arg.owner) {
+			repo.name = opts.Repo.Name()
+		}
+
+		opts.Repo = repo
+		*arg.number = arg.n
+		return nil
+	}
+
+	number, err := findProjectByTitle(opts, arg.title)
+	if err != nil {
+		return err
+	}
+
+	*arg.number = number
+	return nil
+}
+
+// requireRepository returns an error if opts.Repo has no name e.g., when a project URL or alias for another owner was passed.
+// Options like issue numbers are scoped to a repository and cannot be resolved without one.
+func requireRepository(opts *GlobalOptions, option string) error {
+	if opts.Repo == nil || opts.Repo.Name() == "" {
+		return fmt.Errorf("%s requires -R OWNER/REPO", option)
+	}
+
+	return nil
+}
+
+// findProjectByTitle gets the number of the only project with the title, or the only project matching the title.
+func findProjectByTitle(opts *GlobalOptions, title string) (int, error) {
+	client, err := newClient(opts)
+	if err != nil {
+		return 0, err
+	}
+
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"first":  maxTitleMatches,
+		"search": title,
+	}
+
+	var data models.RepositoryProjects
+	err = client.Do(queryRepositoryOwnerProjectsV2, vars, &data)
+	if err != nil {
+		return 0, err
+	}
+
+	candidates := data.Repository.ProjectsV2.Nodes
+
+	var exact []models.Project
+	for _, project := range candidates {
+		if strings.EqualFold(project.Title, title) {
+			exact = append(exact, project)
+		}
+	}
+	if len(exact) > 0 {
+		candidates = exact
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, fmt.Errorf("no projects found matching %q for %q", title, opts.Repo.Owner())
+	case 1:
+		return candidates[0].Number, nil
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "multiple projects match %q; pass a number instead:", title)
+	for _, project := range candidates {
+		fmt.Fprintf(&sb, "\n  #%d  %s", project.Number, project.Title)
+	}
+
+	return 0, errors.New(sb.String())
+}
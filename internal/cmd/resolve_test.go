@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestParseProjectArg(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		wantNumber int
+		want       *projectArg
+		wantErr    string
+	}{
+		{
+			name:       "number",
+			value:      "12",
+			wantNumber: 12,
+		},
+		{
+			name:       "number with hash",
+			value:      "#12",
+			wantNumber: 12,
+		},
+		{
+			name:    "invalid number",
+			value:   "#twelve",
+			wantErr: "invalid project number: #twelve",
+		},
+		{
+			name:  "organization URL",
+			value: "https://github.com/orgs/acme/projects/12",
+			want:  &projectArg{host: "github.com", owner: "acme", n: 12},
+		},
+		{
+			name:  "user URL with view",
+			value: "https://www.github.com/users/heaths/projects/3/views/2",
+			want:  &projectArg{host: "github.com", owner: "heaths", n: 3},
+		},
+		{
+			name:  "enterprise URL",
+			value: "https://GHE.example.com/orgs/acme/projects/7/",
+			want:  &projectArg{host: "ghe.example.com", owner: "acme", n: 7},
+		},
+		{
+			name:    "repository URL",
+			value:   "https://github.com/heaths/gh-projects/projects/1",
+			wantErr: "invalid project URL: https://github.com/heaths/gh-projects/projects/1",
+		},
+		{
+			name:    "invalid URL number",
+			value:   "https://github.com/orgs/acme/projects/new",
+			wantErr: "invalid project URL: https://github.com/orgs/acme/projects/new",
+		},
+		{
+			name:  "title",
+			value: "Roadmap",
+			want:  &projectArg{title: "Roadmap"},
+		},
+		{
+			name:  "title beginning with number",
+			value: "2024 Roadmap",
+			want:  &projectArg{title: "2024 Roadmap"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var number int
+			got, err := parseProjectArg(tt.value, &number)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, number)
+
+			if tt.want != nil {
+				tt.want.number = &number
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveProjectArg(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		mocks      func()
+		wantNumber int
+		wantHost   string
+		wantOwner  string
+		wantName   string
+		wantErr    string
+	}{
+		{
+			name:       "number",
+			value:      "1",
+			wantNumber: 1,
+			wantHost:   "github.com",
+			wantOwner:  "heaths",
+			wantName:   "gh-projects",
+		},
+		{
+			name:       "URL for same owner",
+			value:      "https://github.com/users/heaths/projects/12",
+			wantNumber: 12,
+			wantHost:   "github.com",
+			wantOwner:  "heaths",
+			wantName:   "gh-projects",
+		},
+		{
+			name:       "URL for another owner",
+			value:      "https://ghe.example.com/orgs/acme/projects/7",
+			wantNumber: 7,
+			wantHost:   "ghe.example.com",
+			wantOwner:  "acme",
+		},
+		{
+			name:  "exact title",
+			value: "roadmap",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectsV2.*"first":20,"owner":"heaths","search":"roadmap"`).
+					Reply(200).
+					JSON(`{"data":{"repository":{"projectsV2":{"nodes":[
+						{"id":"PN_1","number":1,"title":"Roadmap"},
+						{"id":"PN_2","number":2,"title":"Roadmap 2025"}
+					]}}}}`)
+			},
+			wantNumber: 1,
+			wantHost:   "github.com",
+			wantOwner:  "heaths",
+			wantName:   "gh-projects",
+		},
+		{
+			name:  "single match",
+			value: "back",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repository":{"projectsV2":{"nodes":[
+						{"id":"PN_12","number":12,"title":"Backlog"}
+					]}}}}`)
+			},
+			wantNumber: 12,
+			wantHost:   "github.com",
+			wantOwner:  "heaths",
+			wantName:   "gh-projects",
+		},
+		{
+			name:  "ambiguous title",
+			value: "road",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repository":{"projectsV2":{"nodes":[
+						{"id":"PN_1","number":1,"title":"Roadmap"},
+						{"id":"PN_2","number":2,"title":"Roadmap 2025"}
+					]}}}}`)
+			},
+			wantErr: "multiple projects match \"road\"; pass a number instead:\n  #1  Roadmap\n  #2  Roadmap 2025",
+		},
+		{
+			name:  "no match",
+			value: "launch",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repository":{"projectsV2":{"nodes":[]}}}}`)
+			},
+			wantErr: `no projects found matching "launch" for "heaths"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			require.NoError(t, err)
+
+			opts := &GlobalOptions{
+				Console: console.Fake(),
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			}
+
+			var number int
+			opts.projectArg, err = parseProjectArg(tt.value, &number)
+			require.NoError(t, err)
+
+			err = ResolveProjectArg(opts)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNumber, number)
+			assert.Equal(t, tt.wantHost, opts.Repo.Host())
+			assert.Equal(t, tt.wantOwner, opts.Repo.Owner())
+			assert.Equal(t, tt.wantName, opts.Repo.Name())
+			assert.Nil(t, opts.projectArg)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+)
+
+func viewRoadmap(opts *viewOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	fields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	groupBy, err := findField(fields, opts.groupBy)
+	if err != nil {
+		return
+	}
+
+	dateFields := []string{opts.startField, opts.endField}
+	if opts.iterationField != "" {
+		dateFields = []string{opts.iterationField}
+	}
+	for _, name := range dateFields {
+		var field *models.ProjectField
+		field, err = findField(fields, name)
+		if err != nil {
+			return
+		}
+
+		want := "DATE"
+		if opts.iterationField != "" {
+			want = "ITERATION"
+		}
+		if field.DataType != want {
+			return fmt.Errorf("field %q is not a %s field", field.Name, strings.ToLower(want))
+		}
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	items := make([]models.ProjectItem, 0, len(project.Items))
+	for _, item := range unarchivedItems(project.Items) {
+		if item.Type == "DRAFT_ISSUE" || equalItemState(item.Content.State, opts.state) {
+			items = append(items, item)
+		}
+	}
+
+	groups, err := filter.GroupBy(items, groupBy)
+	if err != nil {
+		return
+	}
+
+	roadmap := make([]template.RoadmapGroup, 0, len(groups))
+	for _, group := range groups {
+		g := template.RoadmapGroup{Name: group.Name}
+		for _, item := range group.Items {
+			if bar, ok := roadmapBar(item, opts); ok {
+				g.Bars = append(g.Bars, bar)
+			}
+		}
+		roadmap = append(roadmap, g)
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if opts.mermaid {
+		return t.Mermaid(project.Title, roadmap)
+	}
+
+	return t.Roadmap(project.Title, roadmap, timeNow(&opts.GlobalOptions), terminalWidth(&opts.GlobalOptions))
+}
+
+// roadmapBar gets the dates of an item from either an iteration field, or start and end date fields.
+// Items with only a start or end date are shown as milestones.
+func roadmapBar(item models.ProjectItem, opts *viewOptions) (template.RoadmapBar, bool) {
+	bar := template.RoadmapBar{
+		Label: item.Content.Title,
+	}
+	if item.Content.Number != 0 {
+		bar.Label = fmt.Sprintf("#%d %s", item.Content.Number, item.Content.Title)
+	}
+
+	if opts.iterationField != "" {
+		value, ok := item.FieldValue(opts.iterationField)
+		if !ok {
+			return bar, false
+		}
+
+		start, end, ok := value.Iteration()
+		if !ok {
+			return bar, false
+		}
+
+		// Iterations end the day before the next iteration starts.
+		bar.Start, bar.End = start, end.AddDate(0, 0, -1)
+		return bar, true
+	}
+
+	start, hasStart := fieldDate(item, opts.startField)
+	end, hasEnd := fieldDate(item, opts.endField)
+	switch {
+	case hasStart && hasEnd:
+		if end.Before(start) {
+			end = start
+		}
+		bar.Start, bar.End = start, end
+	case hasStart:
+		bar.Start, bar.End = start, start
+	case hasEnd:
+		bar.Start, bar.End = end, end
+	default:
+		return bar, false
+	}
+
+	return bar, true
+}
+
+func fieldDate(item models.ProjectItem, name string) (time.Time, bool) {
+	value, ok := item.FieldValue(name)
+	if !ok || value.Date == "" {
+		return time.Time{}, false
+	}
+
+	date, err := time.Parse("2006-01-02", value.Date)
+	return date, err == nil
+}
+
+// findField finds a field by case-insensitive name.
+func findField(fields []models.ProjectField, name string) (*models.ProjectField, error) {
+	for i := range fields {
+		if strings.EqualFold(fields[i].Name, name) {
+			return &fields[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("field not found: %s", name)
+}
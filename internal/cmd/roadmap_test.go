@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/heaths/go-console/pkg/colorscheme"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const roadmapFieldsJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"fields": {
+					"nodes": [
+						{"id": "PNF_Title", "name": "Title", "dataType": "TITLE"},
+						{
+							"id": "PNF_Status",
+							"name": "Status",
+							"dataType": "SINGLE_SELECT",
+							"options": [
+								{"id": "1", "name": "Todo"},
+								{"id": "2", "name": "Done"}
+							]
+						},
+						{"id": "PNF_Start", "name": "Start", "dataType": "DATE"},
+						{"id": "PNF_Target", "name": "Target", "dataType": "DATE"}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+const roadmapItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Roadmap",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 3,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"number": 1, "title": "Fix the parser", "state": "OPEN"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+									{"date": "2026-10-01", "field": {"name": "Start", "dataType": "DATE"}},
+									{"date": "2026-10-10", "field": {"name": "Target", "dataType": "DATE"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_2",
+							"type": "ISSUE",
+							"content": {"number": 2, "title": "Ship it", "state": "CLOSED"},
+							"fieldValues": {
+								"nodes": [
+									{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+									{"date": "2026-10-20", "field": {"name": "Target", "dataType": "DATE"}}
+								]
+							}
+						},
+						{
+							"id": "PNI_3",
+							"type": "DRAFT_ISSUE",
+							"content": {"title": "Someday"},
+							"fieldValues": {
+								"nodes": []
+							}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestViewRoadmap(t *testing.T) {
+	tests := []struct {
+		name       string
+		tty        bool
+		mermaid    bool
+		endField   string
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:     "tty",
+			tty:      true,
+			endField: "Target",
+			wantStdout: heredoc.Doc(`
+				Roadmap
+
+				                    2026-10-01                                        2026-10-20
+				                                               ▼ today
+				Todo
+				  #1 Fix the parser ████████████████████████████
+				Done
+				  #2 Ship it                                   │                             ◆
+			`),
+		},
+		{
+			name:     "mermaid",
+			mermaid:  true,
+			endField: "Target",
+			wantStdout: heredoc.Doc(`
+				` + "```" + `mermaid
+				gantt
+				    title Roadmap
+				    dateFormat YYYY-MM-DD
+				    section Todo
+				    #1 Fix the parser :2026-10-01, 2026-10-11
+				    section Done
+				    #2 Ship it :milestone, 2026-10-20, 0d
+				` + "```" + `
+			`),
+		},
+		{
+			name:     "field not found",
+			endField: "Missing",
+			wantErr:  "field not found: Missing",
+		},
+		{
+			name:     "not a date",
+			endField: "Status",
+			wantErr:  `field "Status" is not a date field`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(roadmapFieldsJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(roadmapItemsJSON)
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			// Disable colors to compare layout.
+			fake := console.Fake(console.WithStdoutTTY(tt.tty), console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))))
+			opts := &viewOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					width:     80,
+					now:       time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC),
+				},
+				number:     1,
+				state:      "all",
+				groupBy:    "Status",
+				roadmap:    true,
+				startField: "Start",
+				endField:   tt.endField,
+				mermaid:    tt.mermaid,
+			}
+
+			err = viewRoadmap(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
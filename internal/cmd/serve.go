@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/automate"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/webhook"
+	"github.com/spf13/cobra"
+)
+
+func NewServeCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := serveOptions{}
+	cmd := &cobra.Command{
+		Use:   "serve <number>",
+		Short: "Run rules when webhook events are received",
+		Long: heredoc.Docf(`
+			Listen for webhook events for issues, pull requests, and project items,
+			and run rules from a YAML file on the project item for each event. See
+			%[1]sgh projects automate --help%[1]s for rules.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Configure a webhook for the repository or organization to send "Issues",
+			"Pull requests", or "Projects v2 items" events as JSON to this server. Pass
+			--secret or set the GH_PROJECTS_WEBHOOK_SECRET environment variable to the
+			webhook secret to validate the X-Hub-Signature-256 header. A secret is
+			required unless you pass --insecure to accept events without validating them.
+
+			Pass --add to add issues and pull requests to the project when opened or
+			reopened before running rules.
+
+			Structured logs are written as JSON lines to stderr. GET /healthz returns
+			200 OK while the server is running.
+		`, "`"),
+		Example: heredoc.Doc(`
+			$ gh projects serve 1 --listen :8080 --secret $SECRET -c rules.yaml --add
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			if opts.secret == "" {
+				opts.secret = os.Getenv("GH_PROJECTS_WEBHOOK_SECRET")
+			}
+
+			f, err := os.Open(opts.configPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			opts.config, err = automate.Load(f)
+			if err != nil {
+				return err
+			}
+
+			return serve(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.listen, "listen", ":8080", "The `address` to listen on")
+	cmd.Flags().StringVar(&opts.secret, "secret", "", "The webhook `secret` to validate signatures")
+	cmd.Flags().StringVarP(&opts.configPath, "config", "c", "", "Path to a YAML `file` of rules")
+	cmd.Flags().BoolVar(&opts.add, "add", false, "Add opened and reopened issues and pull requests to the project")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Log actions without taking them")
+	cmd.Flags().BoolVar(&opts.insecure, "insecure", false, "Accept events without a secret to validate signatures")
+	_ = cmd.MarkFlagRequired("config")
+	_ = cmd.MarkFlagFilename("config", "yaml", "yml")
+
+	return cmd
+}
+
+type serveOptions struct {
+	GlobalOptions
+
+	number     int
+	listen     string
+	secret     string
+	configPath string
+	config     *automate.Config
+	add        bool
+	dryRun     bool
+	insecure   bool
+}
+
+// Timeouts for webhook requests. Events are handled one at a time, so writing a response may wait for previous events.
+const (
+	serveReadHeaderTimeout = 10 * time.Second
+	serveReadTimeout       = 30 * time.Second
+	serveWriteTimeout      = 2 * time.Minute
+)
+
+func serve(opts *serveOptions) error {
+	if opts.secret == "" && !opts.insecure {
+		return errors.New("a webhook secret is required; pass --secret, set GH_PROJECTS_WEBHOOK_SECRET, or pass --insecure")
+	}
+
+	log := webhook.NewLogger(opts.Console.Stderr())
+	handler, err := newWebhookHandler(opts, log)
+	if err != nil {
+		return err
+	}
+
+	if len(handler.Secret) == 0 {
+		log.Info("no secret configured; signatures will not be validated")
+	}
+
+	server := &http.Server{
+		Addr:              opts.listen,
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+		ReadTimeout:       serveReadTimeout,
+		WriteTimeout:      serveWriteTimeout,
+	}
+
+	log.Info("listening", "address", opts.listen, "project", opts.number)
+	return server.ListenAndServe()
+}
+
+func newWebhookHandler(opts *serveOptions, log *webhook.Logger) (*webhook.Handler, error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	runner, err := newRuleRunner(client, opts.config, projectFields)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolve the viewer once so rules can match "@me".
+	var data struct {
+		Viewer struct {
+			Login string
+		}
+		Repository struct {
+			ProjectV2 *struct {
+				ID string
+			}
+		}
+	}
+	err = client.Do(queryRepositoryOwnerProjectV2Viewer, map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": opts.number,
+	}, &data)
+	if err != nil {
+		return nil, err
+	}
+	if data.Repository.ProjectV2 == nil {
+		return nil, fmt.Errorf("project #%d not found", opts.number)
+	}
+	runner.projectID = data.Repository.ProjectV2.ID
+
+	s := &server{
+		opts:   opts,
+		client: client,
+		runner: runner,
+		log:    log,
+		viewer: data.Viewer.Login,
+	}
+
+	return &webhook.Handler{
+		Secret: []byte(opts.secret),
+		Handle: s.handle,
+		Log:    log,
+	}, nil
+}
+
+// server runs rules on the project item for each webhook event.
+type server struct {
+	// mu serializes events so rules see changes from previous events.
+	mu sync.Mutex
+
+	opts   *serveOptions
+	client api.GQLClient
+	runner *ruleRunner
+	log    *webhook.Logger
+	viewer string
+}
+
+func (s *server) handle(event *webhook.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	projectID := s.runner.projectID
+
+	var item *models.ProjectItem
+	var err error
+	switch event.Name {
+	case "projects_v2_item":
+		if event.ProjectID != projectID || event.Action == "deleted" {
+			s.log.Info("ignored project item", "delivery", event.Delivery, "item", event.ItemID)
+			return nil
+		}
+
+		item, err = getProjectItem(s.client, event.ItemID)
+		if err != nil {
+			return err
+		}
+
+	default:
+		item, err = findContentItem(s.client, projectID, event.ContentID)
+		if err != nil {
+			return err
+		}
+
+		if item == nil && s.opts.add && (event.Action == "opened" || event.Action == "reopened") {
+			if s.opts.dryRun {
+				s.log.Info("would add item", "delivery", event.Delivery, "content", event.ContentID)
+				return nil
+			}
+
+			var itemIDs []string
+			itemIDs, err = addItems(s.client, projectID, []string{event.ContentID})
+			if err != nil {
+				return err
+			}
+			s.log.Info("added item", "delivery", event.Delivery, "content", event.ContentID, "item", itemIDs[0])
+
+			item, err = getProjectItem(s.client, itemIDs[0])
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if item == nil {
+		s.log.Info("not in project", "delivery", event.Delivery, "content", event.ContentID)
+		return nil
+	}
+
+	actions := automate.Plan(s.opts.config, []models.ProjectItem{*item}, filter.Env{
+		Viewer: s.viewer,
+		Now:    timeNow(&s.opts.GlobalOptions),
+	})
+
+	actions, err = skipCommented(s.client, actions)
+	if err != nil {
+		return err
+	}
+
+	for _, action := range actions {
+		fields := []interface{}{"delivery", event.Delivery, "rule", action.Rule, "action", action.String(), "item", item.ID, "title", itemTitle(*item)}
+		if s.opts.dryRun {
+			s.log.Info("would take action", fields...)
+			continue
+		}
+
+		if err := s.runner.take(action); err != nil {
+			return err
+		}
+		s.log.Info("took action", fields...)
+	}
+
+	return nil
+}
+
+type projectItemWithProject struct {
+	models.ProjectItem
+	Project struct {
+		ID string
+	}
+}
+
+// getProjectItem gets a project item with its field values by ID.
+func getProjectItem(client api.GQLClient, itemID string) (*models.ProjectItem, error) {
+	var data struct {
+		Node *projectItemWithProject
+	}
+
+	err := client.Do(queryProjectV2Item+fragmentProjectV2ItemFields+fragmentProjectV2FieldName, map[string]interface{}{
+		"id": itemID,
+	}, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Node == nil {
+		return nil, nil
+	}
+
+	return &data.Node.ProjectItem, nil
+}
+
+// findContentItem finds the item in a project for an issue or pull request, or nil if not in the project.
+func findContentItem(client api.GQLClient, projectID, contentID string) (*models.ProjectItem, error) {
+	var data struct {
+		Node *struct {
+			ProjectItems struct {
+				Nodes []projectItemWithProject
+			}
+		}
+	}
+
+	err := client.Do(queryContentProjectV2Items+fragmentProjectV2ItemFields+fragmentProjectV2FieldName, map[string]interface{}{
+		"id": contentID,
+	}, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Node != nil {
+		for _, item := range data.Node.ProjectItems.Nodes {
+			if item.Project.ID == projectID {
+				return &item.ProjectItem, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+const queryProjectV2Item = `
+query ProjectV2Item($id: ID!) {
+	node(id: $id) {
+		...on ProjectV2Item {
+			...itemFields
+			project {
+				id
+			}
+		}
+	}
+}
+`
+
+const queryContentProjectV2Items = `
+query ContentProjectV2Items($id: ID!) {
+	node(id: $id) {
+		...on Issue {
+			projectItems(first: 50) {
+				nodes {
+					...itemFields
+					project {
+						id
+					}
+				}
+			}
+		}
+		...on PullRequest {
+			projectItems(first: 50) {
+				nodes {
+					...itemFields
+					project {
+						id
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const queryRepositoryOwnerProjectV2Viewer = `
+query RepositoryOwnerProjectV2Viewer($owner: String!, $number: Int!) {
+	viewer {
+		login
+	}
+	repository: repositoryOwner(login: $owner) {
+		... on ProjectV2Owner {
+			projectV2(number: $number) {
+				id
+			}
+		}
+	}
+}
+`
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/automate"
+	"github.com/heaths/gh-projects/internal/webhook"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const serveRulesYAML = `
+rules:
+  - name: Done when merged
+    if:
+      state: merged
+    then:
+      set:
+        Status: Done
+  - name: Triage open issues
+    if:
+      state: open
+    then:
+      set:
+        Status: Todo
+`
+
+const serveMergedItemJSON = `{
+	"id": "PNI_2",
+	"type": "PULL_REQUEST",
+	"content": {"id": "PR_2", "number": 2, "title": "Add a feature", "state": "MERGED", "repository": {"nameWithOwner": "heaths/gh-projects"}},
+	"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]},
+	"project": {"id": "PN_1"}
+}`
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name       string
+		event      string
+		fixture    string
+		rules      string
+		add        bool
+		dryRun     bool
+		mocks      func()
+		wantLogs   []string
+		wantNoLogs []string
+	}{
+		{
+			name:    "merged pull request",
+			event:   "pull_request",
+			fixture: "pull_request_closed.json",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`ContentProjectV2Items`).
+					Reply(200).
+					JSON(`{"data":{"node":{"projectItems":{"nodes":[` + serveMergedItemJSON + `]}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","i0":"PNI_2","projectId":"PN_1","v0":\{"singleSelectOptionId":"2"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_2"}}}}`)
+			},
+			wantLogs: []string{
+				`"action":"set Status to \"Done\"","delivery":"1","item":"PNI_2","level":"info","msg":"took action","rule":"Done when merged"`,
+			},
+		},
+		{
+			name:    "opened issue not in project",
+			event:   "issues",
+			fixture: "issues_opened.json",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`ContentProjectV2Items`).
+					Reply(200).
+					JSON(`{"data":{"node":{"projectItems":{"nodes":[{"id":"PNI_9","project":{"id":"PN_9"}}]}}}}`)
+			},
+			wantLogs: []string{
+				`"content":"I_1","delivery":"1","level":"info","msg":"not in project"`,
+			},
+		},
+		{
+			name:    "add opened issue",
+			event:   "issues",
+			fixture: "issues_opened.json",
+			add:     true,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`ContentProjectV2Items`).
+					Reply(200).
+					JSON(`{"data":{"node":{"projectItems":{"nodes":[]}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"c0":"I_1","id":"PN_1"`).
+					Reply(200).
+					JSON(`{"data":{"a0":{"item":{"id":"PNI_1"}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`query ProjectV2Item\(`).
+					Reply(200).
+					JSON(`{"data":{"node":{
+						"id": "PNI_1",
+						"type": "ISSUE",
+						"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN", "repository": {"nameWithOwner": "heaths/gh-projects"}},
+						"fieldValues": {"nodes": []},
+						"project": {"id": "PN_1"}
+					}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","i0":"PNI_1","projectId":"PN_1","v0":\{"singleSelectOptionId":"1"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_1"}}}}`)
+			},
+			wantLogs: []string{
+				`"content":"I_1","delivery":"1","item":"PNI_1","level":"info","msg":"added item"`,
+				`"action":"set Status to \"Todo\"","delivery":"1","item":"PNI_1","level":"info","msg":"took action","rule":"Triage open issues"`,
+			},
+		},
+		{
+			name:    "dry run project item",
+			event:   "projects_v2_item",
+			fixture: "projects_v2_item_edited.json",
+			dryRun:  true,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`query ProjectV2Item\(`).
+					Reply(200).
+					JSON(`{"data":{"node":` + serveMergedItemJSON + `}}`)
+			},
+			wantLogs: []string{
+				`"action":"set Status to \"Done\"","delivery":"1","item":"PNI_2","level":"info","msg":"would take action","rule":"Done when merged"`,
+			},
+		},
+		{
+			name:    "assigned to viewer",
+			event:   "projects_v2_item",
+			fixture: "projects_v2_item_edited.json",
+			rules: heredoc.Doc(`
+				rules:
+				  - name: Mine
+				    if:
+				      filter: assignee:@me
+				    then:
+				      set:
+				        Status: Done
+				  - name: Others
+				    if:
+				      filter: -assignee:@me
+				    then:
+				      set:
+				        Status: Todo
+			`),
+			dryRun: true,
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`query ProjectV2Item\(`).
+					Reply(200).
+					JSON(`{"data":{"node":{
+						"id": "PNI_2",
+						"type": "ISSUE",
+						"content": {"id": "I_2", "number": 2, "title": "Fix the parser", "state": "OPEN", "assignees": {"nodes": [{"login": "heaths"}]}, "repository": {"nameWithOwner": "heaths/gh-projects"}},
+						"fieldValues": {"nodes": []},
+						"project": {"id": "PN_1"}
+					}}}`)
+			},
+			wantLogs: []string{
+				`"action":"set Status to \"Done\"","delivery":"1","item":"PNI_2","level":"info","msg":"would take action","rule":"Mine"`,
+			},
+			wantNoLogs: []string{
+				`"rule":"Others"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(roadmapFieldsJSON)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Viewer.*"variables":\{"number":1,"owner":"heaths"\}`).
+				Reply(200).
+				JSON(`{"data":{"viewer":{"login":"heaths"},"repository":{"projectV2":{"id":"PN_1"}}}}`)
+			tt.mocks()
+
+			rules := tt.rules
+			if rules == "" {
+				rules = serveRulesYAML
+			}
+
+			config, err := automate.Load(strings.NewReader(rules))
+			require.NoError(t, err)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			require.NoError(t, err)
+
+			opts := &serveOptions{
+				GlobalOptions: GlobalOptions{
+					Console: console.Fake(),
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				config: config,
+				add:    tt.add,
+				dryRun: tt.dryRun,
+			}
+
+			var log bytes.Buffer
+			handler, err := newWebhookHandler(opts, webhook.NewLogger(&log))
+			require.NoError(t, err)
+
+			body, err := os.ReadFile(filepath.Join("..", "webhook", "testdata", tt.fixture))
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", tt.event)
+			req.Header.Set("X-GitHub-Delivery", "1")
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code, log.String())
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			for _, want := range tt.wantLogs {
+				assert.Contains(t, log.String(), want)
+			}
+			for _, want := range tt.wantNoLogs {
+				assert.NotContains(t, log.String(), want)
+			}
+		})
+	}
+}
+
+func TestServe_secretRequired(t *testing.T) {
+	opts := &serveOptions{
+		GlobalOptions: GlobalOptions{
+			Console: console.Fake(),
+		},
+		number: 1,
+	}
+
+	err := serve(opts)
+	assert.EqualError(t, err, "a webhook secret is required; pass --secret, set GH_PROJECTS_WEBHOOK_SECRET, or pass --insecure")
+}
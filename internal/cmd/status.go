@@ -0,0 +1,500 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var statusUpdateStatuses = []string{"INACTIVE", "ON_TRACK", "AT_RISK", "OFF_TRACK", "COMPLETE"}
+
+func NewStatusUpdateCmd(globalOpts *GlobalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status-update",
+		Short: "Manage project status updates",
+		Long: heredoc.Doc(`
+			Post and read status updates that summarize the health of a project.
+		`),
+	}
+
+	cmd.AddCommand(newStatusUpdateCreateCmd(globalOpts))
+	cmd.AddCommand(newStatusUpdateListCmd(globalOpts))
+	cmd.AddCommand(newStatusUpdateViewCmd(globalOpts))
+
+	return cmd
+}
+
+func newStatusUpdateCreateCmd(globalOpts *GlobalOptions) *cobra.Command {
+	var status, body, startDate, targetDate string
+	opts := statusUpdateCreateOptions{}
+	cmd := &cobra.Command{
+		Use:   "create <number>",
+		Short: "Post a project status update",
+		Long: heredoc.Doc(`
+			Post a status update to a project.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass "-" to --body to read from standard input. If --body is not passed in
+			a terminal, your editor is opened to write the body.
+
+			Dates are formatted as YYYY-MM-DD.
+
+			Pass --edit to change the latest status update instead of posting a new one.
+			Only the status, body, and dates that are passed are changed, and your editor
+			is only opened if none are passed.
+		`),
+		Example: heredoc.Doc(`
+			# post that the project is at risk with a body read from stdin
+			$ gh projects status-update create 1 --status AT_RISK --body - --target-date 2026-11-30 <<EOF
+			  Waiting on a _design review_.
+			  EOF
+
+			# mark the latest status update as on track
+			$ gh projects status-update create 1 --edit --status ON_TRACK
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			applyConfigFormat(cmd, globalOpts, "json")
+
+			if cmd.Flags().Changed("status") {
+				opts.status = &status
+			}
+
+			if cmd.Flags().Changed("body") {
+				opts.body = &body
+			}
+
+			for _, date := range []struct {
+				name  string
+				value string
+				p     **string
+			}{
+				{"start-date", startDate, &opts.startDate},
+				{"target-date", targetDate, &opts.targetDate},
+			} {
+				if !cmd.Flags().Changed(date.name) {
+					continue
+				}
+
+				if _, err := time.Parse("2006-01-02", date.value); err != nil {
+					return fmt.Errorf("invalid --%s %q; must be YYYY-MM-DD", date.name, date.value)
+				}
+
+				value := date.value
+				*date.p = &value
+			}
+
+			if opts.startDate != nil && opts.targetDate != nil && *opts.startDate > *opts.targetDate {
+				return fmt.Errorf("--start-date must not be after --target-date")
+			}
+
+			return statusUpdateCreate(&opts)
+		},
+	}
+
+	StringEnumVarP(cmd, &status, "status", "s", "", statusUpdateStatuses, "Set the status")
+	StdinStringVarP(cmd, globalOpts.Console.Stdin(), &body, "body", "b", "", "Set the body")
+	cmd.Flags().StringVar(&startDate, "start-date", "", "Set the start `date`")
+	cmd.Flags().StringVar(&targetDate, "target-date", "", "Set the target `date`")
+	cmd.Flags().BoolVarP(&opts.edit, "edit", "e", false, "Edit the latest status update instead of posting a new one")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output the status update as JSON")
+
+	return cmd
+}
+
+type statusUpdateCreateOptions struct {
+	GlobalOptions
+
+	number     int
+	status     *string
+	body       *string
+	startDate  *string
+	targetDate *string
+	edit       bool
+	json       bool
+}
+
+func statusUpdateCreate(opts *statusUpdateCreateOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listStatusUpdates(client, opts.number, 1, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	var latest *models.ProjectStatusUpdate
+	if opts.edit {
+		if len(project.StatusUpdates.Nodes) == 0 {
+			return fmt.Errorf("no status updates to edit")
+		}
+		latest = &project.StatusUpdates.Nodes[0]
+	}
+
+	// When editing, only open the editor if nothing else would be changed.
+	editBody := opts.body == nil
+	if latest != nil {
+		editBody = editBody && opts.status == nil && opts.startDate == nil && opts.targetDate == nil
+	}
+
+	if editBody && opts.Console.IsStdinTTY() && opts.Console.IsStdoutTTY() {
+		var body, original string
+		if latest != nil {
+			original = latest.Body
+		}
+
+		body, err = newEditor(&opts.GlobalOptions).Edit("*.md", original)
+		if err != nil {
+			return
+		}
+
+		if latest == nil || body != original {
+			opts.body = &body
+		}
+	}
+
+	vars := map[string]interface{}{}
+	if opts.status != nil {
+		vars["status"] = *opts.status
+	}
+	if opts.body != nil {
+		vars["body"] = *opts.body
+	}
+	if opts.startDate != nil {
+		vars["startDate"] = *opts.startDate
+	}
+	if opts.targetDate != nil {
+		vars["targetDate"] = *opts.targetDate
+	}
+
+	var update models.ProjectStatusUpdate
+	if latest != nil {
+		if len(vars) == 0 {
+			return fmt.Errorf("nothing to edit")
+		}
+
+		vars["id"] = latest.ID
+
+		var data struct {
+			UpdateProjectV2StatusUpdate struct {
+				StatusUpdate models.ProjectStatusUpdate
+			}
+		}
+
+		err = client.Do(mutationUpdateProjectV2StatusUpdate+fragmentProjectV2StatusUpdate, vars, &data)
+		if err != nil {
+			return
+		}
+
+		update = data.UpdateProjectV2StatusUpdate.StatusUpdate
+	} else {
+		vars["projectId"] = project.ID
+
+		var data struct {
+			CreateProjectV2StatusUpdate struct {
+				StatusUpdate models.ProjectStatusUpdate
+			}
+		}
+
+		err = client.Do(mutationCreateProjectV2StatusUpdate+fragmentProjectV2StatusUpdate, vars, &data)
+		if err != nil {
+			return
+		}
+
+		update = data.CreateProjectV2StatusUpdate.StatusUpdate
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if opts.json {
+		return t.StatusUpdateJSON(update)
+	}
+
+	return t.StatusUpdate(update)
+}
+
+func newStatusUpdateListCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := statusUpdateListOptions{}
+	cmd := &cobra.Command{
+		Use:   "list <number>",
+		Short: "List project status updates",
+		Long: heredoc.Doc(`
+			List status updates for a project, most recent first.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			applyConfigFormat(cmd, globalOpts, "json")
+
+			return statusUpdateList(&opts)
+		},
+	}
+
+	IntRangeVarP(cmd, &opts.limit, "limit", "L", 10, 1, 100, "Number of status updates to list")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output status updates as JSON")
+
+	return cmd
+}
+
+type statusUpdateListOptions struct {
+	GlobalOptions
+
+	number int
+	limit  int
+	json   bool
+}
+
+func statusUpdateList(opts *statusUpdateListOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	project, err := listStatusUpdates(client, opts.number, opts.limit, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if opts.json {
+		return t.StatusUpdatesJSON(project.StatusUpdates.Nodes)
+	}
+
+	return t.StatusUpdates(project.Title, project.StatusUpdates.Nodes, project.StatusUpdates.TotalCount)
+}
+
+func newStatusUpdateViewCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := statusUpdateViewOptions{}
+	cmd := &cobra.Command{
+		Use:   "view <number> [<id>]",
+		Short: "View a project status update",
+		Long: heredoc.Doc(`
+			View a status update for a project. The latest status update is shown unless
+			the ID of a status update is passed, as shown by the list command.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+		`),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := ProjectNumberArg(globalOpts, &opts.number)(cmd, args); err != nil {
+				return err
+			}
+
+			if len(args) > 2 {
+				return fmt.Errorf("too many arguments")
+			}
+
+			if len(args) == 2 {
+				opts.id = args[1]
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+			applyConfigFormat(cmd, globalOpts, "json")
+
+			return statusUpdateView(&opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Output the status update as JSON")
+
+	return cmd
+}
+
+type statusUpdateViewOptions struct {
+	GlobalOptions
+
+	number int
+	id     string
+	json   bool
+}
+
+func statusUpdateView(opts *statusUpdateViewOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	var update models.ProjectStatusUpdate
+	if opts.id != "" {
+		var data struct {
+			Node *struct {
+				models.ProjectStatusUpdate
+				Project *struct {
+					Number int
+					Owner  struct {
+						Login string
+					}
+				}
+			}
+		}
+
+		err = client.Do(queryProjectV2StatusUpdate+fragmentProjectV2StatusUpdate, map[string]interface{}{"id": opts.id}, &data)
+		if err != nil {
+			return
+		}
+
+		if data.Node == nil || data.Node.ID == "" {
+			return fmt.Errorf("status update not found: %s", opts.id)
+		}
+
+		// Any status update can be fetched by ID, so make sure it belongs to the project.
+		if project := data.Node.Project; project == nil || project.Number != opts.number || !strings.EqualFold(project.Owner.Login, opts.Repo.Owner()) {
+			return fmt.Errorf("status update %s not found for project %d", opts.id, opts.number)
+		}
+
+		update = data.Node.ProjectStatusUpdate
+	} else {
+		var project *models.ProjectStatusUpdates
+		project, err = listStatusUpdates(client, opts.number, 1, &opts.GlobalOptions)
+		if err != nil {
+			return
+		}
+
+		if len(project.StatusUpdates.Nodes) == 0 {
+			return fmt.Errorf("no status updates for project %d", opts.number)
+		}
+
+		update = project.StatusUpdates.Nodes[0]
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if opts.json {
+		return t.StatusUpdateJSON(update)
+	}
+
+	return t.StatusUpdate(update)
+}
+
+// listStatusUpdates gets the most recent status updates for a project.
+func listStatusUpdates(client api.GQLClient, number, first int, opts *GlobalOptions) (*models.ProjectStatusUpdates, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+		"first":  first,
+	}
+
+	var data struct {
+		Repository struct {
+			ProjectV2 *models.ProjectStatusUpdates
+		}
+	}
+
+	err := client.Do(queryRepositoryOwnerProjectV2StatusUpdates+fragmentProjectV2StatusUpdate, vars, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Repository.ProjectV2 == nil {
+		return nil, fmt.Errorf("project not found: %d", number)
+	}
+
+	return data.Repository.ProjectV2, nil
+}
+
+const fragmentProjectV2StatusUpdate = `
+fragment statusUpdate on ProjectV2StatusUpdate {
+	id
+	status
+	body
+	startDate
+	targetDate
+	creator {
+		login
+	}
+	createdAt
+	updatedAt
+}
+`
+
+const queryRepositoryOwnerProjectV2StatusUpdates = `
+query RepositoryOwnerProjectV2StatusUpdates($owner: String!, $number: Int!, $first: Int!) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				id
+				title
+				url
+				statusUpdates(first: $first, orderBy: {field: CREATED_AT, direction: DESC}) {
+					totalCount
+					nodes {
+						...statusUpdate
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const queryProjectV2StatusUpdate = `
+query ProjectV2StatusUpdate($id: ID!) {
+	node(id: $id) {
+		...statusUpdate
+		...on ProjectV2StatusUpdate {
+			project {
+				number
+				owner {
+					...on Organization {
+						login
+					}
+					...on User {
+						login
+					}
+				}
+			}
+		}
+	}
+}
+`
+
+const mutationCreateProjectV2StatusUpdate = `
+mutation CreateProjectV2StatusUpdate($projectId: ID!, $status: ProjectV2StatusUpdateStatus, $body: String, $startDate: Date, $targetDate: Date) {
+	createProjectV2StatusUpdate(
+		input: {projectId: $projectId, status: $status, body: $body, startDate: $startDate, targetDate: $targetDate}
+	) {
+		statusUpdate {
+			...statusUpdate
+		}
+	}
+}
+`
+
+const mutationUpdateProjectV2StatusUpdate = `
+mutation UpdateProjectV2StatusUpdate($id: ID!, $status: ProjectV2StatusUpdateStatus, $body: String, $startDate: Date, $targetDate: Date) {
+	updateProjectV2StatusUpdate(
+		input: {statusUpdateId: $id, status: $status, body: $body, startDate: $startDate, targetDate: $targetDate}
+	) {
+		statusUpdate {
+			...statusUpdate
+		}
+	}
+}
+`
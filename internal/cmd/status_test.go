@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const statusUpdatesJSON = `{
+	"data": {
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Roadmap",
+				"url": "https://github.com/users/heaths/projects/1",
+				"statusUpdates": {
+					"totalCount": 2,
+					"nodes": [
+						{
+							"id": "PVTSU_2",
+							"status": "AT_RISK",
+							"body": "Waiting on a design review.\n\nMore details.",
+							"startDate": "2026-10-01",
+							"targetDate": "2026-11-30",
+							"creator": {"login": "heaths"},
+							"createdAt": "2026-10-15T00:00:00Z",
+							"updatedAt": "2026-10-15T00:00:00Z"
+						},
+						{
+							"id": "PVTSU_1",
+							"status": "ON_TRACK",
+							"body": "Kicked off.",
+							"startDate": "2026-10-01",
+							"targetDate": null,
+							"creator": {"login": "heaths"},
+							"createdAt": "2026-10-01T00:00:00Z",
+							"updatedAt": "2026-10-01T00:00:00Z"
+						}
+					]
+				}
+			}
+		}
+	}
+}`
+
+const statusUpdateJSON = `{
+	"id": "PVTSU_3",
+	"status": "AT_RISK",
+	"body": "Waiting on a design review.",
+	"startDate": null,
+	"targetDate": "2026-11-30",
+	"creator": {"login": "heaths"},
+	"createdAt": "2026-10-19T00:00:00Z",
+	"updatedAt": "2026-10-19T00:00:00Z"
+}`
+
+// statusUpdateNodeJSON is statusUpdateJSON with the project it belongs to.
+const statusUpdateNodeJSON = `{
+	"id": "PVTSU_3",
+	"status": "AT_RISK",
+	"body": "Waiting on a design review.",
+	"startDate": null,
+	"targetDate": "2026-11-30",
+	"creator": {"login": "heaths"},
+	"createdAt": "2026-10-19T00:00:00Z",
+	"updatedAt": "2026-10-19T00:00:00Z",
+	"project": {"number": 1, "owner": {"login": "heaths"}}
+}`
+
+func TestStatusUpdateCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		stdin      string
+		tty        bool
+		editor     textEditor
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name:  "body from stdin",
+			args:  []string{"1", "--status", "AT_RISK", "--body", "-", "--target-date", "2026-11-30"},
+			stdin: "Waiting on a design review.",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"body":"Waiting on a design review.","projectId":"PN_1","status":"AT_RISK","targetDate":"2026-11-30"`).
+					Reply(200).
+					JSON(`{"data":{"createProjectV2StatusUpdate":{"statusUpdate":` + statusUpdateJSON + `}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				id:	PVTSU_3
+				status:	AT_RISK
+				targetDate:	2026-11-30
+				creator:	heaths
+				createdAt:	2026-10-19T00:00:00Z
+				--
+				Waiting on a design review.
+			`),
+		},
+		{
+			name:   "body from editor",
+			args:   []string{"1", "-s", "AT_RISK", "--json"},
+			tty:    true,
+			editor: &fakeEditor{text: "Waiting on a design review."},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"body":"Waiting on a design review.","projectId":"PN_1","status":"AT_RISK"\}`).
+					Reply(200).
+					JSON(`{"data":{"createProjectV2StatusUpdate":{"statusUpdate":` + statusUpdateJSON + `}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				{
+				  "id": "PVTSU_3",
+				  "status": "AT_RISK",
+				  "body": "Waiting on a design review.",
+				  "targetDate": "2026-11-30",
+				  "creator": {
+				    "login": "heaths"
+				  },
+				  "createdAt": "2026-10-19T00:00:00Z",
+				  "updatedAt": "2026-10-19T00:00:00Z"
+				}
+			`),
+		},
+		{
+			name: "edit latest",
+			args: []string{"1", "--edit", "--status", "ON_TRACK", "--json"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"id":"PVTSU_2","status":"ON_TRACK"\}`).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2StatusUpdate":{"statusUpdate":{"id":"PVTSU_2","status":"ON_TRACK","body":""}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				{
+				  "id": "PVTSU_2",
+				  "status": "ON_TRACK",
+				  "body": "",
+				  "creator": {
+				    "login": ""
+				  }
+				}
+			`),
+		},
+		{
+			name:   "edit status in terminal",
+			args:   []string{"1", "--edit", "--status", "ON_TRACK", "--json"},
+			tty:    true,
+			editor: &fakeEditor{text: "Unchanged body from the editor."},
+			mocks: func() {
+				// The editor is not opened when other fields are passed, so the body is not changed.
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`\{"id":"PVTSU_2","status":"ON_TRACK"\}`).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2StatusUpdate":{"statusUpdate":{"id":"PVTSU_2","status":"ON_TRACK","body":""}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				{
+				  "id": "PVTSU_2",
+				  "status": "ON_TRACK",
+				  "body": "",
+				  "creator": {
+				    "login": ""
+				  }
+				}
+			`),
+		},
+		{
+			name:   "edit body in terminal",
+			args:   []string{"1", "--edit", "--json"},
+			tty:    true,
+			editor: &fakeEditor{text: "Design review is done."},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`\{"body":"Design review is done.","id":"PVTSU_2"\}`).
+					Reply(200).
+					JSON(`{"data":{"updateProjectV2StatusUpdate":{"statusUpdate":{"id":"PVTSU_2","status":"AT_RISK","body":"Design review is done."}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				{
+				  "id": "PVTSU_2",
+				  "status": "AT_RISK",
+				  "body": "Design review is done.",
+				  "creator": {
+				    "login": ""
+				  }
+				}
+			`),
+		},
+		{
+			name:    "edit nothing",
+			args:    []string{"1", "--edit"},
+			mocks:   func() {},
+			wantErr: "nothing to edit",
+		},
+		{
+			name:    "invalid status",
+			args:    []string{"1", "--status", "LATE"},
+			wantErr: `invalid argument "LATE" for "-s, --status" flag: valid values are {INACTIVE|ON_TRACK|AT_RISK|OFF_TRACK|COMPLETE}`,
+		},
+		{
+			name:    "invalid date",
+			args:    []string{"1", "--target-date", "11/30/2026"},
+			wantErr: `invalid --target-date "11/30/2026"; must be YYYY-MM-DD`,
+		},
+		{
+			name:    "start after target",
+			args:    []string{"1", "--start-date", "2026-12-01", "--target-date", "2026-11-30"},
+			wantErr: "--start-date must not be after --target-date",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.mocks != nil {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"first":1,"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(statusUpdatesJSON)
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake(
+				console.WithStdin(bytes.NewBufferString(tt.stdin)),
+				console.WithStdinTTY(tt.tty),
+				console.WithStdoutTTY(tt.tty),
+			)
+			globalOpts := &GlobalOptions{
+				Console: fake,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+				editor:    tt.editor,
+			}
+
+			cmd := NewStatusUpdateCmd(globalOpts)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			cmd.SetArgs(append([]string{"create"}, tt.args...))
+
+			err = cmd.Execute()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestStatusUpdateList(t *testing.T) {
+	tests := []struct {
+		name       string
+		json       bool
+		wantStdout string
+	}{
+		{
+			name: "table",
+			wantStdout: heredoc.Doc(`
+				At risk   2026-11-30  heaths  Waiting on a design review.  PVTSU_2
+				On track              heaths  Kicked off.                  PVTSU_1
+
+			`),
+		},
+		{
+			name: "json",
+			json: true,
+			wantStdout: heredoc.Doc(`
+				[
+				  {
+				    "id": "PVTSU_2",
+				    "status": "AT_RISK",
+				    "body": "Waiting on a design review.\n\nMore details.",
+				    "startDate": "2026-10-01",
+				    "targetDate": "2026-11-30",
+				    "creator": {
+				      "login": "heaths"
+				    },
+				    "createdAt": "2026-10-15T00:00:00Z",
+				    "updatedAt": "2026-10-15T00:00:00Z"
+				  },
+				  {
+				    "id": "PVTSU_1",
+				    "status": "ON_TRACK",
+				    "body": "Kicked off.",
+				    "startDate": "2026-10-01",
+				    "creator": {
+				      "login": "heaths"
+				    },
+				    "createdAt": "2026-10-01T00:00:00Z",
+				    "updatedAt": "2026-10-01T00:00:00Z"
+				  }
+				]
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`"first":10,"number":1,"owner":"heaths"`).
+				Reply(200).
+				JSON(statusUpdatesJSON)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &statusUpdateListOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				limit:  10,
+				json:   tt.json,
+			}
+
+			err = statusUpdateList(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestStatusUpdateView(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		mocks      func()
+		wantStdout string
+		wantErr    string
+	}{
+		{
+			name: "latest",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"first":1,"number":1,"owner":"heaths"`).
+					Reply(200).
+					JSON(statusUpdatesJSON)
+			},
+			wantStdout: heredoc.Doc(`
+				id:	PVTSU_2
+				status:	AT_RISK
+				startDate:	2026-10-01
+				targetDate:	2026-11-30
+				creator:	heaths
+				createdAt:	2026-10-15T00:00:00Z
+				--
+				Waiting on a design review.
+
+				More details.
+			`),
+		},
+		{
+			name: "by id",
+			id:   "PVTSU_3",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"id":"PVTSU_3"`).
+					Reply(200).
+					JSON(`{"data":{"node":` + statusUpdateNodeJSON + `}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				id:	PVTSU_3
+				status:	AT_RISK
+				targetDate:	2026-11-30
+				creator:	heaths
+				createdAt:	2026-10-19T00:00:00Z
+				--
+				Waiting on a design review.
+			`),
+		},
+		{
+			name: "by id for another project",
+			id:   "PVTSU_3",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":` + strings.Replace(statusUpdateNodeJSON, `"number": 1`, `"number": 2`, 1) + `}}`)
+			},
+			wantErr: "status update PVTSU_3 not found for project 1",
+		},
+		{
+			name: "by id for another owner",
+			id:   "PVTSU_3",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":` + strings.Replace(statusUpdateNodeJSON, `"login": "heaths"}}`, `"login": "acme"}}`, 1) + `}}`)
+			},
+			wantErr: "status update PVTSU_3 not found for project 1",
+		},
+		{
+			name: "not found",
+			id:   "PVTSU_4",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"node":null}}`)
+			},
+			wantErr: "status update not found: PVTSU_4",
+		},
+		{
+			name: "no status updates",
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					Reply(200).
+					JSON(`{"data":{"repository":{"projectV2":{"id":"PN_1","statusUpdates":{"totalCount":0,"nodes":[]}}}}}`)
+			},
+			wantErr: "no status updates for project 1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			tt.mocks()
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &statusUpdateViewOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number: 1,
+				id:     tt.id,
+			}
+
+			err = statusUpdateView(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/utils"
+)
+
+// The maximum number of IDs passed to the nodes query.
+const maxNodeIDs = 100
+
+// withSubIssues resolves issues and appends their sub-issues and tracked issues up to opts.depth levels below.
+// Each issue is only added once even if tracked by multiple parents.
+func withSubIssues(client api.GQLClient, refs []issueRef, opts *editOptions) ([]issueRef, error) {
+	for _, batch := range utils.Chunk(refs, BatchSize) {
+		numbers := make([]int, len(batch))
+		for i, ref := range batch {
+			numbers[i] = ref.number
+		}
+
+		contentIDs, err := getContentIDs(client, &opts.GlobalOptions, numbers)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range batch {
+			batch[i].contentID = contentIDs[i]
+		}
+	}
+
+	seen := make(map[string]bool, len(refs))
+	for _, ref := range refs {
+		seen[ref.contentID] = true
+	}
+
+	repo := opts.Repo.Owner() + "/" + opts.Repo.Name()
+	level := refs
+	for depth := 1; depth <= opts.depth && len(level) > 0; depth++ {
+		parentIDs := make([]string, len(level))
+		for i, ref := range level {
+			parentIDs[i] = ref.contentID
+		}
+
+		children, err := getSubIssues(client, parentIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []issueRef
+		for _, parentID := range parentIDs {
+			for _, child := range children[parentID] {
+				if seen[child.ID] {
+					continue
+				}
+				seen[child.ID] = true
+
+				ref := issueRef{
+					contentID: child.ID,
+					parentID:  parentID,
+					depth:     depth,
+				}
+				if strings.EqualFold(child.Repository.NameWithOwner, repo) {
+					ref.number = child.Number
+				}
+				next = append(next, ref)
+			}
+		}
+
+		refs = append(refs, next...)
+		level = next
+	}
+
+	return refs, nil
+}
+
+type subIssue struct {
+	ID         string
+	Number     int
+	Repository struct {
+		NameWithOwner string
+	}
+}
+
+type subIssueConnection struct {
+	Nodes    []subIssue
+	PageInfo struct {
+		HasNextPage bool
+		EndCursor   string
+	}
+}
+
+// getSubIssues gets sub-issues and tracked issues keyed by the content ID of their parent.
+func getSubIssues(client api.GQLClient, parentIDs []string) (map[string][]subIssue, error) {
+	children := make(map[string][]subIssue, len(parentIDs))
+	for _, ids := range utils.Chunk(parentIDs, maxNodeIDs) {
+		var data struct {
+			Nodes []*struct {
+				ID            string
+				SubIssues     subIssueConnection
+				TrackedIssues subIssueConnection
+			}
+		}
+
+		err := client.Do(queryIssueSubIssues+fragmentSubIssues, map[string]interface{}{
+			"ids": ids,
+		}, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range data.Nodes {
+			if node == nil {
+				continue
+			}
+			children[node.ID] = append(node.SubIssues.Nodes, node.TrackedIssues.Nodes...)
+
+			if node.SubIssues.PageInfo.HasNextPage || node.TrackedIssues.PageInfo.HasNextPage {
+				more, err := getMoreSubIssues(client, node.ID, node.SubIssues, node.TrackedIssues)
+				if err != nil {
+					return nil, err
+				}
+				children[node.ID] = append(children[node.ID], more...)
+			}
+		}
+	}
+
+	return children, nil
+}
+
+// getMoreSubIssues pages through sub-issues and tracked issues of a parent after the first page of each.
+func getMoreSubIssues(client api.GQLClient, id string, subIssues, trackedIssues subIssueConnection) ([]subIssue, error) {
+	var children []subIssue
+	for subIssues.PageInfo.HasNextPage || trackedIssues.PageInfo.HasNextPage {
+		var data struct {
+			Node *struct {
+				SubIssues     subIssueConnection
+				TrackedIssues subIssueConnection
+			}
+		}
+
+		// Only connections with more pages are selected, so the others decode as empty and stop paging.
+		err := client.Do(queryIssueMoreSubIssues+fragmentSubIssues, map[string]interface{}{
+			"id":                   id,
+			"includeSubIssues":     subIssues.PageInfo.HasNextPage,
+			"subIssuesAfter":       subIssues.PageInfo.EndCursor,
+			"includeTrackedIssues": trackedIssues.PageInfo.HasNextPage,
+			"trackedIssuesAfter":   trackedIssues.PageInfo.EndCursor,
+		}, &data)
+		if err != nil {
+			return nil, err
+		}
+		if data.Node == nil {
+			break
+		}
+
+		subIssues, trackedIssues = data.Node.SubIssues, data.Node.TrackedIssues
+		children = append(children, subIssues.Nodes...)
+		children = append(children, trackedIssues.Nodes...)
+	}
+
+	return children, nil
+}
+
+// inheritFields copies values of opts.inherit fields from parent items to sub-issues one level at a time,
+// so sub-issues inherit values their parents inherited.
+func inheritFields(client api.GQLClient, projectID string, cached *cache.Project, refs []issueRef, added map[string]string, opts *editOptions) error {
+	projectFields := cached.Fields
+	if projectFields == nil {
+		var err error
+		projectFields, err = listProjectFields(client, opts.number, &opts.GlobalOptions)
+		if err != nil {
+			return err
+		}
+		opts.Cache.SetFields(cached, projectFields)
+	}
+
+	names := make([]string, len(opts.inherit))
+	for i, name := range opts.inherit {
+		field, err := findField(projectFields, name)
+		if err != nil {
+			return err
+		}
+		names[i] = field.Name
+	}
+
+	for depth := 1; ; depth++ {
+		var level []issueRef
+		for _, ref := range refs {
+			if ref.depth == depth {
+				level = append(level, ref)
+			}
+		}
+		if len(level) == 0 {
+			return nil
+		}
+
+		var parentItemIDs []string
+		seen := make(map[string]bool)
+		for _, ref := range level {
+			if id := added[ref.parentID]; !seen[id] {
+				seen[id] = true
+				parentItemIDs = append(parentItemIDs, id)
+			}
+		}
+
+		parents, err := getProjectItems(client, parentItemIDs)
+		if err != nil {
+			return err
+		}
+
+		// Group sub-issues by the values they inherit to update as few times as possible.
+		groups := make(map[string][]string)
+		fields := make(map[string]map[string]models.Field)
+		for _, ref := range level {
+			parent, ok := parents[added[ref.parentID]]
+			if !ok {
+				continue
+			}
+
+			values := make(map[string]models.Field, len(names))
+			var key []string
+			for _, name := range names {
+				value, ok := parent.FieldValue(name)
+				if !ok || value.String() == "" {
+					continue
+				}
+
+				field, err := resolveField(projectFields, name, value.String())
+				if err != nil || field == nil {
+					if opts.Log != nil {
+						fmt.Fprintf(opts.Log, "Cannot copy %s %q to sub-issues: %v\n", name, value.String(), err)
+					}
+					continue
+				}
+
+				values[name] = *field
+				key = append(key, name+"="+value.String())
+			}
+
+			if len(values) == 0 {
+				continue
+			}
+
+			k := strings.Join(key, "\n")
+			groups[k] = append(groups[k], added[ref.contentID])
+			fields[k] = values
+		}
+
+		// Sort groups so updates are deterministic.
+		keys := make([]string, 0, len(groups))
+		for k := range groups {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := updateItemsFields(client, projectID, groups[k], fields[k]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// getProjectItems gets project items with their field values keyed by ID.
+func getProjectItems(client api.GQLClient, itemIDs []string) (map[string]models.ProjectItem, error) {
+	items := make(map[string]models.ProjectItem, len(itemIDs))
+	for _, ids := range utils.Chunk(itemIDs, maxNodeIDs) {
+		var data struct {
+			Nodes []*models.ProjectItem
+		}
+
+		err := client.Do(queryProjectV2Items+fragmentProjectV2ItemFields+fragmentProjectV2FieldName, map[string]interface{}{
+			"ids": ids,
+		}, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range data.Nodes {
+			if item != nil {
+				items[item.ID] = *item
+			}
+		}
+	}
+
+	return items, nil
+}
+
+const queryIssueSubIssues = `
+query IssueSubIssues($ids: [ID!]!) {
+	nodes(ids: $ids) {
+		...on Issue {
+			id
+			subIssues(first: 100) {
+				...subIssues
+			}
+			trackedIssues(first: 100) {
+				...subIssues
+			}
+		}
+	}
+}
+`
+
+const queryIssueMoreSubIssues = `
+query IssueMoreSubIssues($id: ID!, $includeSubIssues: Boolean!, $subIssuesAfter: String, $includeTrackedIssues: Boolean!, $trackedIssuesAfter: String) {
+	node(id: $id) {
+		...on Issue {
+			subIssues(first: 100, after: $subIssuesAfter) @include(if: $includeSubIssues) {
+				...subIssues
+			}
+			trackedIssues(first: 100, after: $trackedIssuesAfter) @include(if: $includeTrackedIssues) {
+				...subIssues
+			}
+		}
+	}
+}
+`
+
+const fragmentSubIssues = `
+fragment subIssues on IssueConnection {
+	nodes {
+		id
+		number
+		repository {
+			nameWithOwner
+		}
+	}
+	pageInfo {
+		hasNextPage
+		endCursor
+	}
+}
+`
+
+const queryProjectV2Items = `
+query ProjectV2Items($ids: [ID!]!) {
+	nodes(ids: $ids) {
+		...on ProjectV2Item {
+			...itemFields
+		}
+	}
+}
+`
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/cache"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestEdit_withSubIssues(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryIssuesOrPullRequestsID`).
+		Reply(200).
+		JSON(`{"data":{"repository":{"i0":{"id":"I_10"}}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`IssueSubIssues`).
+		BodyString(`"ids":\["I_10"\]`).
+		Reply(200).
+		JSON(`{"data":{"nodes":[{
+			"id": "I_10",
+			"subIssues": {"nodes": [{"id": "I_11", "number": 11, "repository": {"nameWithOwner": "heaths/gh-projects"}}]},
+			"trackedIssues": {"nodes": [{"id": "I_20", "number": 5, "repository": {"nameWithOwner": "heaths/other"}}]}
+		}]}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`IssueSubIssues`).
+		BodyString(`"ids":\["I_11","I_20"\]`).
+		Reply(200).
+		JSON(`{"data":{"nodes":[
+			{
+				"id": "I_11",
+				"subIssues": {"nodes": [{"id": "I_12", "number": 12, "repository": {"nameWithOwner": "heaths/gh-projects"}}]},
+				"trackedIssues": {"nodes": [{"id": "I_10", "number": 10, "repository": {"nameWithOwner": "heaths/gh-projects"}}]}
+			},
+			{
+				"id": "I_20",
+				"subIssues": {"nodes": []},
+				"trackedIssues": {"nodes": []}
+			}
+		]}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"c0":"I_10","c1":"I_11","c2":"I_20","c3":"I_12","id":"PN_1"`).
+		Reply(200).
+		JSON(`{"data":{
+			"a0": {"item": {"id": "PNI_10"}},
+			"a1": {"item": {"id": "PNI_11"}},
+			"a2": {"item": {"id": "PNI_20"}},
+			"a3": {"item": {"id": "PNI_12"}}
+		}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`query ProjectV2Items`).
+		BodyString(`"ids":\["PNI_10"\]`).
+		Reply(200).
+		JSON(`{"data":{"nodes":[{
+			"id": "PNI_10",
+			"fieldValues": {"nodes": [{"title": "Iteration 2", "field": {"name": "Iteration", "dataType": "ITERATION"}}]}
+		}]}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"f0":"PNF_Iteration","f1":"PNF_Iteration","i0":"PNI_11","i1":"PNI_20","projectId":"PN_1","v0":\{"iterationId":"PNF_Iteration_2"\}`).
+		Reply(200).
+		JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_11"}},"u1":{"projectV2Item":{"id":"PNI_20"}}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`query ProjectV2Items`).
+		BodyString(`"ids":\["PNI_11"\]`).
+		Reply(200).
+		JSON(`{"data":{"nodes":[{
+			"id": "PNI_11",
+			"fieldValues": {"nodes": [{"title": "Iteration 2", "field": {"name": "Iteration", "dataType": "ITERATION"}}]}
+		}]}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"f0":"PNF_Iteration","i0":"PNI_12","projectId":"PN_1","v0":\{"iterationId":"PNF_Iteration_2"\}`).
+		Reply(200).
+		JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_12"}}}}`)
+
+	var fields []models.ProjectField
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{
+			"id": "PNF_Iteration",
+			"name": "Iteration",
+			"dataType": "ITERATION",
+			"configuration": {
+				"iterations": [
+					{"id": "PNF_Iteration_1", "name": "Iteration 1"},
+					{"id": "PNF_Iteration_2", "name": "Iteration 2"}
+				]
+			}
+		}
+	]`), &fields))
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	c := cache.New(t.TempDir())
+	cached := c.Load("github.com", "heaths", 1)
+	c.SetProject(cached, "PN_1", "https://github.com/users/heaths/projects/1")
+	cached.AddRepository("heaths/gh-projects")
+	c.SetFields(cached, fields)
+	c.SetItems(cached, map[string]string{cache.ItemKey("heaths/gh-projects", 1): "PNI_1"})
+	require.NoError(t, c.Save("github.com", "heaths", 1, cached))
+
+	opts := &editOptions{
+		projectOptions: projectOptions{
+			GlobalOptions: GlobalOptions{
+				Console: console.Fake(),
+				Cache:   c,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+			},
+			number: 1,
+		},
+		addIssues:     []int{10},
+		withSubIssues: true,
+		depth:         2,
+		inherit:       []string{"iteration"},
+	}
+
+	err = edit(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	// Only issues from the current repository are cached.
+	cached = c.Load("github.com", "heaths", 1)
+	for number, want := range map[int]string{10: "PNI_10", 11: "PNI_11", 12: "PNI_12"} {
+		got, ok := cached.Item("heaths/gh-projects", number)
+		assert.True(t, ok, "#%d", number)
+		assert.Equal(t, want, got, "#%d", number)
+	}
+	_, ok := cached.Item("heaths/gh-projects", 5)
+	assert.False(t, ok)
+}
+
+func TestGetSubIssues_pages(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`IssueSubIssues`).
+		Reply(200).
+		JSON(`{"data":{"nodes":[{
+			"id": "I_10",
+			"subIssues": {"nodes": [{"id": "I_11", "number": 11}], "pageInfo": {"hasNextPage": true, "endCursor": "1"}},
+			"trackedIssues": {"nodes": [{"id": "I_20", "number": 20}], "pageInfo": {"hasNextPage": false}}
+		}]}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`IssueMoreSubIssues.*"variables":\{"id":"I_10","includeSubIssues":true,"includeTrackedIssues":false,"subIssuesAfter":"1","trackedIssuesAfter":""\}`).
+		Reply(200).
+		JSON(`{"data":{"node":{
+			"subIssues": {"nodes": [{"id": "I_12", "number": 12}], "pageInfo": {"hasNextPage": true, "endCursor": "2"}}
+		}}}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`IssueMoreSubIssues.*"subIssuesAfter":"2"`).
+		Reply(200).
+		JSON(`{"data":{"node":{
+			"subIssues": {"nodes": [{"id": "I_13", "number": 13}], "pageInfo": {"hasNextPage": false}}
+		}}}`)
+
+	client, err := newClient(&GlobalOptions{
+		authToken: "***",
+		host:      "github.com",
+	})
+	require.NoError(t, err)
+
+	children, err := getSubIssues(client, []string{"I_10"})
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	var ids []string
+	for _, child := range children["I_10"] {
+		ids = append(ids, child.ID)
+	}
+	assert.Equal(t, []string{"I_11", "I_20", "I_12", "I_13"}, ids)
+}
@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/spf13/cobra"
+)
+
+func NewSyncStatusCmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := syncStatusOptions{}
+	cmd := &cobra.Command{
+		Use:   "sync-status <number>",
+		Short: "Set item status from issue and pull request state",
+		Long: heredoc.Doc(`
+			Set the status of items in a project from whether their issue or pull
+			request is open, closed, or merged.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass --map for each state with the status to set e.g., "closed=Done".
+			Draft issues and items in states that are not mapped are not changed.
+
+			Pass --dry-run to show which items would be changed without changing them.
+		`),
+		Example: heredoc.Doc(`
+			# set the status of closed and merged items to Done, and open items to Todo
+			$ gh projects sync-status 1 --map closed=Done --map merged=Done --map open=Todo
+
+			# set the status of open items without a status to Todo
+			$ gh projects sync-status 1 --map open=Todo --only-if-empty
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			for state := range opts.states {
+				switch strings.ToLower(state) {
+				case "open", "closed", "merged":
+				default:
+					return fmt.Errorf("invalid state %q; must be open, closed, or merged", state)
+				}
+			}
+
+			return syncStatus(&opts)
+		},
+	}
+
+	StringToStringVarP(cmd, &opts.states, "map", "m", nil, "Set the status of items in a state e.g., closed=Done")
+	cmd.Flags().StringVar(&opts.field, "field", "Status", "Single select `field` to set")
+	cmd.Flags().BoolVar(&opts.onlyIfEmpty, "only-if-empty", false, "Only set the status of items without a status")
+	cmd.Flags().BoolVar(&opts.dryRun, "dry-run", false, "Show changes without making them")
+	_ = cmd.MarkFlagRequired("map")
+	_ = cmd.RegisterFlagCompletionFunc("field", completeFieldNames(globalOpts, "SINGLE_SELECT"))
+
+	return cmd
+}
+
+type syncStatusOptions struct {
+	GlobalOptions
+
+	number      int
+	states      map[string]string
+	field       string
+	onlyIfEmpty bool
+	dryRun      bool
+}
+
+func syncStatus(opts *syncStatusOptions) (err error) {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	projectFields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	field, err := findField(projectFields, opts.field)
+	if err != nil {
+		return
+	}
+	if field.DataType != "SINGLE_SELECT" {
+		return fmt.Errorf("field %q is not a single select field", field.Name)
+	}
+
+	// Map states e.g., "CLOSED" to the option name as defined.
+	statuses := make(map[string]string, len(opts.states))
+	values := make(map[string]models.Field, len(opts.states))
+	for state, status := range opts.states {
+		var value *models.Field
+		value, err = models.NewField(*field, status)
+		if err != nil {
+			return
+		}
+
+		for _, option := range field.Options {
+			if option.ID == value.Value.SingleSelectOptionID {
+				status = option.Name
+			}
+		}
+
+		statuses[strings.ToUpper(state)] = status
+		values[status] = *value
+	}
+
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return
+	}
+
+	var changes []template.FieldChange
+	itemIDs := make(map[string][]string)
+	for _, item := range project.Items {
+		status, ok := statuses[item.Content.State]
+		if item.Type == "DRAFT_ISSUE" || item.IsArchived || !ok {
+			continue
+		}
+
+		current, _ := item.FieldValue(field.Name)
+		if current.Name == status || opts.onlyIfEmpty && current.Name != "" {
+			continue
+		}
+
+		changes = append(changes, template.FieldChange{
+			Item: item,
+			From: current.Name,
+			To:   status,
+		})
+		itemIDs[status] = append(itemIDs[status], item.ID)
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return
+	}
+
+	if !opts.dryRun && len(changes) > 0 {
+		// Sort statuses so updates are deterministic.
+		names := make([]string, 0, len(itemIDs))
+		for status := range itemIDs {
+			names = append(names, status)
+		}
+		sort.Strings(names)
+
+		opts.Console.StartProgress(fmt.Sprintf("Updating %s", field.Name))
+		for _, status := range names {
+			err = updateItemsFields(client, project.ID, itemIDs[status], map[string]models.Field{field.Name: values[status]})
+			if err != nil {
+				break
+			}
+		}
+		opts.Console.StopProgress()
+
+		if err != nil {
+			return
+		}
+	}
+
+	return t.FieldChanges(changes, opts.dryRun)
+}
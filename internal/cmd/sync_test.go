@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNewSyncStatusCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "map required",
+			args:    []string{"1"},
+			wantErr: `required flag(s) "map" not set`,
+		},
+		{
+			name:    "invalid state",
+			args:    []string{"1", "--map", "draft=Todo"},
+			wantErr: `invalid state "draft"; must be open, closed, or merged`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalOpts := &GlobalOptions{
+				Console: console.Fake(),
+			}
+
+			cmd := NewSyncStatusCmd(globalOpts)
+			cmd.SilenceUsage = true
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSyncStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		states      map[string]string
+		onlyIfEmpty bool
+		dryRun      bool
+		mocks       func()
+		wantStdout  string
+		wantErr     string
+	}{
+		{
+			name:   "dry run",
+			states: map[string]string{"closed": "done", "merged": "Done", "open": "Todo"},
+			dryRun: true,
+			wantStdout: heredoc.Doc(`
+				#2  Add a feature  Todo → Done
+				#3  Write docs     Done → Todo
+				#4  Fix a typo     (none) → Todo
+				Would update 3 items
+			`),
+		},
+		{
+			name:   "update",
+			states: map[string]string{"closed": "done", "merged": "Done", "open": "Todo"},
+			mocks: func() {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","i0":"PNI_2","projectId":"PN_1","v0":\{"singleSelectOptionId":"2"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_2"}}}}`)
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`"f0":"PNF_Status","f1":"PNF_Status","i0":"PNI_3","i1":"PNI_4","projectId":"PN_1","v0":\{"singleSelectOptionId":"1"\},"v1":\{"singleSelectOptionId":"1"\}`).
+					Reply(200).
+					JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_3"}},"u1":{"projectV2Item":{"id":"PNI_4"}}}}`)
+			},
+			wantStdout: heredoc.Doc(`
+				#2  Add a feature  Todo → Done
+				#3  Write docs     Done → Todo
+				#4  Fix a typo     (none) → Todo
+				Updated 3 items
+			`),
+		},
+		{
+			name:        "only if empty",
+			states:      map[string]string{"open": "Todo"},
+			onlyIfEmpty: true,
+			dryRun:      true,
+			wantStdout: heredoc.Doc(`
+				#4  Fix a typo  (none) → Todo
+				Would update 1 item
+			`),
+		},
+		{
+			name:    "undefined status",
+			states:  map[string]string{"closed": "Shipped"},
+			wantErr: `option not defined for field "Status": Shipped`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2Fields`).
+				Reply(200).
+				JSON(roadmapFieldsJSON)
+			if tt.wantErr == "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2ItemFields`).
+					Reply(200).
+					JSON(syncItemsJSON)
+			}
+			if tt.mocks != nil {
+				tt.mocks()
+			}
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &syncStatusOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number:      1,
+				states:      tt.states,
+				field:       "status",
+				onlyIfEmpty: tt.onlyIfEmpty,
+				dryRun:      tt.dryRun,
+			}
+
+			err = syncStatus(opts)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+const syncItemsJSON = `{
+	"data": {
+		"viewer": {
+			"login": "heaths"
+		},
+		"repository": {
+			"projectV2": {
+				"id": "PN_1",
+				"title": "Project",
+				"url": "https://github.com/users/heaths/projects/1",
+				"items": {
+					"totalCount": 6,
+					"nodes": [
+						{
+							"id": "PNI_1",
+							"type": "ISSUE",
+							"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN"},
+							"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						},
+						{
+							"id": "PNI_2",
+							"type": "PULL_REQUEST",
+							"content": {"id": "PR_2", "number": 2, "title": "Add a feature", "state": "MERGED"},
+							"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						},
+						{
+							"id": "PNI_3",
+							"type": "ISSUE",
+							"content": {"id": "I_3", "number": 3, "title": "Write docs", "state": "OPEN"},
+							"fieldValues": {"nodes": [{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						},
+						{
+							"id": "PNI_4",
+							"type": "ISSUE",
+							"content": {"id": "I_4", "number": 4, "title": "Fix a typo", "state": "OPEN"},
+							"fieldValues": {"nodes": []}
+						},
+						{
+							"id": "PNI_5",
+							"type": "DRAFT_ISSUE",
+							"content": {"id": "DI_5", "title": "Someday"},
+							"fieldValues": {"nodes": []}
+						},
+						{
+							"id": "PNI_6",
+							"type": "ISSUE",
+							"isArchived": true,
+							"content": {"id": "I_6", "number": 6, "title": "Old work", "state": "CLOSED"},
+							"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+						}
+					],
+					"pageInfo": {
+						"hasNextPage": false
+					}
+				}
+			}
+		}
+	}
+}`
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/tui"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func NewTUICmd(globalOpts *GlobalOptions) *cobra.Command {
+	opts := tuiOptions{}
+	cmd := &cobra.Command{
+		Use:   "tui <number>",
+		Short: "Browse and edit a project in the terminal",
+		Long: heredoc.Doc(`
+			Browse and edit a project in a full-screen terminal UI.
+
+			Items are shown on a board with columns for each option of a single
+			select or iteration field, or in a table. Select an item to open it,
+			edit its fields, or move it to another column. Press "?" for help.
+
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts.GlobalOptions = *globalOpts
+
+			if !opts.Console.IsStdinTTY() || !opts.Console.IsStdoutTTY() {
+				return fmt.Errorf("tui requires a terminal")
+			}
+
+			return runTUI(&opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.groupBy, "group-by", "Status", "Single select or iteration `field` to group items by on the board")
+	_ = cmd.RegisterFlagCompletionFunc("group-by", completeFieldNames(globalOpts, "SINGLE_SELECT", "ITERATION"))
+
+	return cmd
+}
+
+type tuiOptions struct {
+	GlobalOptions
+
+	number  int
+	groupBy string
+}
+
+func runTUI(opts *tuiOptions) error {
+	client, err := newClient(&opts.GlobalOptions)
+	if err != nil {
+		return err
+	}
+
+	project, projectID, err := loadTUIProject(client, opts)
+	if err != nil {
+		return err
+	}
+
+	width, height := terminalSize(&opts.GlobalOptions)
+	ui, err := tui.New(opts.Console, project, &tuiBackend{client: client, projectID: projectID}, tui.Options{
+		GroupBy: opts.groupBy,
+		Width:   width,
+		Height:  height,
+	})
+	if err != nil {
+		return err
+	}
+
+	if f, ok := opts.Console.Stdin().(*os.File); ok {
+		state, err := term.MakeRaw(int(f.Fd()))
+		if err != nil {
+			return err
+		}
+		defer term.Restore(int(f.Fd()), state) // nolint:errcheck
+	}
+
+	return ui.Run()
+}
+
+// loadTUIProject gets the project readme, fields, and all items.
+func loadTUIProject(client api.GQLClient, opts *tuiOptions) (*tui.Project, string, error) {
+	fields, err := listProjectFields(client, opts.number, &opts.GlobalOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var data struct {
+		Node models.Project
+	}
+
+	err = client.Do(queryProjectV2Node, map[string]interface{}{"id": items.ID}, &data)
+	if err != nil {
+		return nil, "", err
+	}
+
+	project := &tui.Project{
+		Title:  items.Title,
+		Readme: data.Node.Body,
+		Fields: fields,
+		Items:  unarchivedItems(items.Items),
+		Viewer: items.Viewer,
+	}
+
+	return project, items.ID, nil
+}
+
+// tuiBackend changes project items for the TUI.
+type tuiBackend struct {
+	client    api.GQLClient
+	projectID string
+}
+
+func (b *tuiBackend) SetField(item models.ProjectItem, field models.ProjectField, value string) error {
+	// Dates are edited without a time e.g., "2022-08-01".
+	if field.DataType == "DATE" {
+		if _, err := time.Parse("2006-01-02", value); err == nil {
+			value += "T00:00:00Z"
+		}
+	}
+
+	f, err := models.NewField(field, value)
+	if err != nil {
+		return err
+	}
+
+	return updateItemsFields(b.client, b.projectID, []string{item.ID}, map[string]models.Field{field.Name: *f})
+}
+
+func (b *tuiBackend) Body(item models.ProjectItem) (string, error) {
+	if item.Content.ID == "" {
+		return "", nil
+	}
+
+	var data struct {
+		Node struct {
+			Body string
+		}
+	}
+
+	err := b.client.Do(queryContentBody, map[string]interface{}{"id": item.Content.ID}, &data)
+	if err != nil {
+		return "", err
+	}
+
+	return data.Node.Body, nil
+}
+
+const queryContentBody = `
+query ContentBody($id: ID!) {
+	node(id: $id) {
+		... on DraftIssue {
+			body
+		}
+		... on Issue {
+			body
+		}
+		... on PullRequest {
+			body
+		}
+	}
+}
+`
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNewTUICmd(t *testing.T) {
+	globalOpts := &GlobalOptions{
+		Console: console.Fake(),
+	}
+
+	cmd := NewTUICmd(globalOpts)
+	cmd.SilenceUsage = true
+
+	cmd.SetArgs([]string{"1"})
+	err := cmd.Execute()
+	assert.EqualError(t, err, "tui requires a terminal")
+}
+
+func TestLoadTUIProject(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2Fields`).
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"projectV2": {
+						"fields": {
+							"nodes": [
+								{"id": "PNF_Status", "name": "Status", "dataType": "SINGLE_SELECT", "options": [{"id": "1", "name": "Todo"}]}
+							],
+							"pageInfo": {"hasNextPage": false}
+						}
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2ItemFields`).
+		Reply(200).
+		JSON(strings.Replace(itemFieldsJSON, `"id": "PNI_3",`, `"id": "PNI_3", "isArchived": true,`, 1))
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`ProjectV2Node.*"id":"PN_1"`).
+		Reply(200).
+		JSON(`{"data":{"node":{"title":"Project","body":"Ship it!"}}}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	require.NoError(t, err)
+
+	opts := &tuiOptions{
+		GlobalOptions: GlobalOptions{
+			Console: console.Fake(),
+			Repo:    repo,
+
+			authToken: "***",
+			host:      "github.com",
+		},
+		number: 1,
+	}
+
+	client, err := newClient(&opts.GlobalOptions)
+	require.NoError(t, err)
+
+	project, projectID, err := loadTUIProject(client, opts)
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	assert.Equal(t, "PN_1", projectID)
+	assert.Equal(t, "Ship it!", project.Readme)
+	assert.Equal(t, "heaths", project.Viewer)
+	assert.Len(t, project.Fields, 1)
+	// Archived items are not shown.
+	assert.Len(t, project.Items, 2)
+}
+
+func TestTUIBackend_SetField(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"f0":"PNF_Start","i0":"PNI_1","projectId":"PN_1","v0":{"date":"2022-08-01T00:00:00Z"}`).
+		Reply(200).
+		JSON(`{"data":{"u0":{"projectV2Item":{"id":"PNI_1"}}}}`)
+
+	client, err := newClient(&GlobalOptions{authToken: "***", host: "github.com"})
+	require.NoError(t, err)
+
+	backend := &tuiBackend{client: client, projectID: "PN_1"}
+	err = backend.SetField(
+		models.ProjectItem{ID: "PNI_1"},
+		models.ProjectField{ID: "PNF_Start", Name: "Start", DataType: "DATE"},
+		"2022-08-01",
+	)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	err = backend.SetField(
+		models.ProjectItem{ID: "PNI_1"},
+		models.ProjectField{ID: "PNF_Start", Name: "Start", DataType: "DATE"},
+		"08/01/2022",
+	)
+	assert.EqualError(t, err, `invalid date for field "Start": 08/01/2022`)
+}
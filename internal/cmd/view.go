@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/MakeNowJust/heredoc"
-	"github.com/cli/go-gh"
 	"github.com/cli/go-gh/pkg/api"
 	"github.com/heaths/gh-projects/internal/models"
 	"github.com/heaths/gh-projects/internal/template"
@@ -17,19 +18,132 @@ func NewViewCmd(globalOpts *GlobalOptions) *cobra.Command {
 		Long: heredoc.Doc(`
 			View information about a project and its fields.
 
-			The number argument can begin with a "#" symbol.
+			The number argument can begin with a "#" symbol, or be a project URL, alias, or title.
+
+			Pass --web to open the project, a specific view with --view, or the issue or
+			pull request of an item with --item, in the browser. Items are referenced by
+			their issue or pull request number, or by their project item ID.
+
+			Pass --items to include draft issues, issues, and pull requests, and
+			--archived to include only archived items instead.
+
+			Pass --board to show items in columns for each option of a single select
+			or iteration field, like a board view in the browser.
+
+			Pass --roadmap with --start-field and --end-field, or --iteration-field, to
+			show items on a timeline grouped by a single select or iteration field. Pass
+			--mermaid to export the roadmap as a Mermaid gantt chart.
+
+			Pass --watch to print changes to items as they happen, such as items added
+			or removed and field values changed. The project is checked every --interval,
+			which doubles up to 8 times while nothing changes. Pass --json to print each
+			change as a line of JSON.
 		`),
-		Args: ProjectNumberArg(&opts.number),
+		Example: heredoc.Doc(`
+			# open issue 4 in the browser
+			$ gh projects view 1 --web --item 4
+
+			# show open items in columns for each status
+			$ gh projects view 1 --board
+
+			# show all items in columns for each iteration
+			$ gh projects view 1 --board --group-by Iteration --state all
+
+			# show a roadmap of items by start and target dates
+			$ gh projects view 1 --roadmap --start-field Start --end-field Target
+
+			# export a roadmap of items by iteration for documentation
+			$ gh projects view 1 --roadmap --iteration-field Iteration --mermaid > roadmap.md
+
+			# print changes to items every minute as JSON
+			$ gh projects view 1 --watch --interval 1m --json
+		`),
+		Args:              ProjectNumberArg(globalOpts, &opts.number),
+		ValidArgsFunction: completeProjectNumbers(globalOpts),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			opts.GlobalOptions = *globalOpts
 
+			if opts.archived && !opts.items {
+				return fmt.Errorf("--archived requires --items")
+			}
+
+			if opts.viewNumber > 0 && !opts.web {
+				return fmt.Errorf("--view requires --web")
+			}
+
+			if opts.item != "" {
+				if !opts.web {
+					return fmt.Errorf("--item requires --web")
+				}
+				if opts.viewNumber > 0 {
+					return fmt.Errorf("specify only one of --item or --view")
+				}
+			}
+
+			if cmd.Flags().Changed("group-by") && !opts.board && !opts.roadmap {
+				return fmt.Errorf("--group-by requires --board or --roadmap")
+			}
+
+			if opts.board && opts.web {
+				return fmt.Errorf("--board cannot be used with --web")
+			}
+
+			if opts.roadmap {
+				if opts.board || opts.web {
+					return fmt.Errorf("--roadmap cannot be used with --board or --web")
+				}
+
+				if opts.iterationField == "" && (opts.startField == "" || opts.endField == "") {
+					return fmt.Errorf("--roadmap requires --start-field and --end-field, or --iteration-field")
+				}
+
+				if opts.iterationField != "" && (opts.startField != "" || opts.endField != "") {
+					return fmt.Errorf("--iteration-field cannot be used with --start-field or --end-field")
+				}
+			} else if opts.startField != "" || opts.endField != "" || opts.iterationField != "" || opts.mermaid {
+				return fmt.Errorf("--start-field, --end-field, --iteration-field, and --mermaid require --roadmap")
+			}
+
+			if err := opts.watch.validate(cmd); err != nil {
+				return err
+			}
+
+			if opts.watch.watch && (opts.board || opts.roadmap || opts.web) {
+				return fmt.Errorf("--watch cannot be used with --board, --roadmap, or --web")
+			}
+
+			if opts.board {
+				return viewBoard(&opts)
+			}
+
+			if opts.roadmap {
+				return viewRoadmap(&opts)
+			}
+
 			return view(&opts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&opts.items, "items", false, "Include drafts, issues, and pull requests")
-	IntRangeVarP(cmd, &opts.limit, "limit", "L", 20, 1, 100, "Number of items to include")
+	cmd.Flags().BoolVar(&opts.archived, "archived", false, "Include only archived items")
+	cmd.Flags().BoolVar(&opts.board, "board", false, "Show items in columns like a board")
+	cmd.Flags().StringVar(&opts.groupBy, "group-by", "Status", "Single select or iteration `field` to group items by on a board or roadmap")
+	cmd.Flags().BoolVar(&opts.roadmap, "roadmap", false, "Show items on a timeline")
+	cmd.Flags().StringVar(&opts.startField, "start-field", "", "Date `field` when items start on a roadmap")
+	cmd.Flags().StringVar(&opts.endField, "end-field", "", "Date `field` when items end on a roadmap")
+	cmd.Flags().StringVar(&opts.iterationField, "iteration-field", "", "Iteration `field` when items start and end on a roadmap")
+	cmd.Flags().BoolVar(&opts.mermaid, "mermaid", false, "Export the roadmap as a Mermaid gantt chart")
+	IntRangeVarP(cmd, &opts.limit, "limit", "L", 20, 1, 100, "Number of items to include, or per column on a board")
 	StringEnumVarP(cmd, &opts.state, "state", "s", "open", []string{"open", "closed", "merged", "all"}, "State of items to include")
+	cmd.Flags().BoolVarP(&opts.web, "web", "w", false, "Open the project in the browser")
+	cmd.Flags().IntVar(&opts.viewNumber, "view", 0, "Open a specific view `number` of the project in the browser")
+	cmd.Flags().StringVar(&opts.item, "item", "", "Open the issue or pull request of the `item` in the browser")
+	addWatchFlags(cmd, &opts.watch)
+
+	_ = cmd.RegisterFlagCompletionFunc("group-by", completeFieldNames(globalOpts, "SINGLE_SELECT", "ITERATION"))
+	_ = cmd.RegisterFlagCompletionFunc("start-field", completeFieldNames(globalOpts, "DATE"))
+	_ = cmd.RegisterFlagCompletionFunc("end-field", completeFieldNames(globalOpts, "DATE"))
+	_ = cmd.RegisterFlagCompletionFunc("iteration-field", completeFieldNames(globalOpts, "ITERATION"))
 
 	return cmd
 }
@@ -37,26 +151,43 @@ func NewViewCmd(globalOpts *GlobalOptions) *cobra.Command {
 type viewOptions struct {
 	GlobalOptions
 
-	number int
-	items  bool
-	limit  int
-	state  string
+	number   int
+	items    bool
+	archived bool
+	limit    int
+	state    string
+
+	web        bool
+	viewNumber int
+	item       string
+
+	board   bool
+	groupBy string
+
+	roadmap        bool
+	startField     string
+	endField       string
+	iterationField string
+	mermaid        bool
+
+	watch watchOptions
 }
 
 func view(opts *viewOptions) (err error) {
-	clientOpts := &api.ClientOptions{
-		Log: opts.Log,
-	}
-	client, err := gh.GQLClient(clientOpts)
+	client, err := newClient(&opts.GlobalOptions)
 	if err != nil {
 		return
 	}
 
+	if opts.item != "" {
+		return viewItemInBrowser(client, opts)
+	}
+
 	vars := map[string]interface{}{
 		"owner":        opts.Repo.Owner(),
 		"number":       opts.number,
 		"first":        opts.limit,
-		"includeItems": opts.items,
+		"includeItems": opts.items && !opts.web,
 	}
 
 	var data models.RepositoryProject
@@ -67,26 +198,46 @@ func view(opts *viewOptions) (err error) {
 
 	project := data.Repository.ProjectV2
 
+	if opts.web {
+		url := project.URL
+		if opts.viewNumber > 0 {
+			url = fmt.Sprintf("%s/views/%d", url, opts.viewNumber)
+		}
+
+		return openInBrowser(&opts.GlobalOptions, url)
+	}
+
 	if opts.items {
 		items := make([]models.ProjectItem, 0, opts.limit)
+		page := project.Items
+		delete(vars, "includeItems")
 		for {
-			for _, item := range data.Repository.ProjectV2.Items.Nodes {
-				if equalItemState(item.Content.State, opts.state) {
+			for _, item := range page.Nodes {
+				if item.IsArchived == opts.archived && equalItemState(item.Content.State, opts.state) {
 					items = append(items, item)
 				}
 			}
 
-			if len(items) < opts.limit && project.Items.PageInfo.HasNextPage {
-				vars["after"] = project.Items.PageInfo.EndCursor
-				err = client.Do(queryRepositoryProjectV2MoreItems+fragmentProjectV2Items, vars, &data)
-				if err != nil {
-					return
-				}
-			} else {
+			if len(items) >= opts.limit || !page.PageInfo.HasNextPage {
 				break
 			}
+			vars["after"] = page.PageInfo.EndCursor
+
+			// Decode each page into new values so items already kept do not share slices with the next page.
+			var more models.RepositoryProject
+			err = client.Do(queryRepositoryOwnerProjectV2MoreItems+fragmentProjectV2Items, vars, &more)
+			if err != nil {
+				return
+			}
+			if more.Repository.ProjectV2 == nil || more.Repository.ProjectV2.Items == nil {
+				break
+			}
+			page = more.Repository.ProjectV2.Items
 		}
 
+		if len(items) > opts.limit {
+			items = items[:opts.limit]
+		}
 		project.Items.Nodes = items
 	}
 
@@ -95,7 +246,37 @@ func view(opts *viewOptions) (err error) {
 		return
 	}
 
-	return t.Project(*project)
+	err = t.Project(*project)
+	if err != nil || !opts.watch.watch {
+		return
+	}
+
+	return watchItems(&opts.GlobalOptions, &opts.watch, client, opts.number, func() ([]models.ProjectItem, error) {
+		project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+		if err != nil {
+			return nil, err
+		}
+		return unarchivedItems(project.Items), nil
+	})
+}
+
+// viewItemInBrowser opens the issue or pull request of an item in the browser.
+func viewItemInBrowser(client api.GQLClient, opts *viewOptions) error {
+	project, err := listProjectItems(client, opts.number, 0, &opts.GlobalOptions)
+	if err != nil {
+		return err
+	}
+
+	item, err := findItem(project.Items, opts.item, opts.Repo.Owner()+"/"+opts.Repo.Name())
+	if err != nil {
+		return err
+	}
+
+	if item.Content.URL == "" {
+		return fmt.Errorf("draft issue %q cannot be opened in the browser", item.Content.Title)
+	}
+
+	return openInBrowser(&opts.GlobalOptions, item.Content.URL)
 }
 
 const queryRepositoryProjectV2 = `
@@ -121,11 +302,13 @@ query RepositoryProjectV2($owner: String!, $number: Int!, $first: Int!, $after:
 }
 `
 
-const queryRepositoryProjectV2MoreItems = `
-query RepositoryProjectV2($owner: String!, $name: String!, $number: Int!, $first: Int!, $after: String) {
-	repository(name: $name, owner: $owner) {
-		projectV2(number: $number) {
-			...items
+const queryRepositoryOwnerProjectV2MoreItems = `
+query RepositoryOwnerProjectV2MoreItems($owner: String!, $number: Int!, $first: Int!, $after: String) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				...items
+			}
 		}
 	}
 }
@@ -138,6 +321,7 @@ fragment items on ProjectV2 {
 		nodes {
 			id
 			type
+			isArchived
 			content {
 				... on DraftIssue {
 					title
@@ -0,0 +1,409 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestNewViewCmd(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "no args",
+			wantErr: "missing required project number",
+		},
+		{
+			name:    "archived requires items",
+			args:    []string{"1", "--archived"},
+			wantErr: "--archived requires --items",
+		},
+		{
+			name:    "view requires web",
+			args:    []string{"1", "--view", "2"},
+			wantErr: "--view requires --web",
+		},
+		{
+			name:    "item requires web",
+			args:    []string{"1", "--item", "4"},
+			wantErr: "--item requires --web",
+		},
+		{
+			name:    "item with view",
+			args:    []string{"1", "--web", "--item", "4", "--view", "2"},
+			wantErr: "specify only one of --item or --view",
+		},
+		{
+			name:    "group-by requires board",
+			args:    []string{"1", "--group-by", "Iteration"},
+			wantErr: "--group-by requires --board or --roadmap",
+		},
+		{
+			name:    "roadmap requires fields",
+			args:    []string{"1", "--roadmap", "--start-field", "Start"},
+			wantErr: "--roadmap requires --start-field and --end-field, or --iteration-field",
+		},
+		{
+			name:    "roadmap with dates and iteration",
+			args:    []string{"1", "--roadmap", "--start-field", "Start", "--end-field", "Target", "--iteration-field", "Iteration"},
+			wantErr: "--iteration-field cannot be used with --start-field or --end-field",
+		},
+		{
+			name:    "roadmap with board",
+			args:    []string{"1", "--roadmap", "--board", "--iteration-field", "Iteration"},
+			wantErr: "--roadmap cannot be used with --board or --web",
+		},
+		{
+			name:    "mermaid requires roadmap",
+			args:    []string{"1", "--mermaid"},
+			wantErr: "--start-field, --end-field, --iteration-field, and --mermaid require --roadmap",
+		},
+		{
+			name:    "board with web",
+			args:    []string{"1", "--board", "--web"},
+			wantErr: "--board cannot be used with --web",
+		},
+		{
+			name:    "watch with board",
+			args:    []string{"1", "--watch", "--board"},
+			wantErr: "--watch cannot be used with --board, --roadmap, or --web",
+		},
+		{
+			name:    "json requires watch",
+			args:    []string{"1", "--json"},
+			wantErr: "--interval and --json require --watch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			globalOpts := &GlobalOptions{
+				Console: console.Fake(),
+			}
+
+			cmd := NewViewCmd(globalOpts)
+			cmd.SilenceUsage = true
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestView_web(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *viewOptions
+		wantBrowsed string
+	}{
+		{
+			name: "project",
+			opts: &viewOptions{
+				number: 1,
+			},
+			wantBrowsed: "https://github.com/users/heaths/projects/1",
+		},
+		{
+			name: "view",
+			opts: &viewOptions{
+				number:     1,
+				viewNumber: 2,
+			},
+			wantBrowsed: "https://github.com/users/heaths/projects/1/views/2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				Reply(200).
+				JSON(`{
+					"data": {
+						"repository": {
+							"projectV2": {
+								"id": "PN_1",
+								"number": 1,
+								"title": "Project",
+								"url": "https://github.com/users/heaths/projects/1"
+							}
+						}
+					}
+				}`)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			browser := &fakeBrowser{}
+			tt.opts.GlobalOptions = GlobalOptions{
+				Console: fake,
+				Repo:    repo,
+
+				authToken: "***",
+				host:      "github.com",
+				browser:   browser,
+			}
+			tt.opts.web = true
+
+			err = view(tt.opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			assert.Equal(t, tt.wantBrowsed, browser.url)
+
+			stdout, _, _ := fake.Buffers()
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestView_webItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		item        string
+		wantBrowsed string
+		wantErr     string
+	}{
+		{
+			name:        "issue",
+			item:        "#4",
+			wantBrowsed: "https://github.com/heaths/gh-projects/issues/4",
+		},
+		{
+			name:        "pull request by ID",
+			item:        "PNI_5",
+			wantBrowsed: "https://github.com/heaths/gh-projects/pull/5",
+		},
+		{
+			name:    "draft issue",
+			item:    "PNI_6",
+			wantErr: `draft issue "Someday" cannot be opened in the browser`,
+		},
+		{
+			name:    "not found",
+			item:    "7",
+			wantErr: "project does not reference #7",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString("RepositoryOwnerProjectV2ItemFields").
+				Reply(200).
+				JSON(`{"data":{"repository":{"projectV2":{"id":"PN_1","items":{"nodes":[
+					{"id":"PNI_4","type":"ISSUE","content":{"number":4,"title":"Fix the parser","url":"https://github.com/heaths/gh-projects/issues/4","repository":{"nameWithOwner":"heaths/gh-projects"}}},
+					{"id":"PNI_5","type":"PULL_REQUEST","content":{"number":5,"title":"Add a feature","url":"https://github.com/heaths/gh-projects/pull/5","repository":{"nameWithOwner":"heaths/gh-projects"}}},
+					{"id":"PNI_6","type":"DRAFT_ISSUE","content":{"title":"Someday"}}
+				]}}}}}`)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			browser := &fakeBrowser{}
+			opts := &viewOptions{
+				GlobalOptions: GlobalOptions{
+					Console: console.Fake(),
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					browser:   browser,
+				},
+				number: 1,
+				web:    true,
+				item:   tt.item,
+			}
+
+			err = view(opts)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantBrowsed, browser.url)
+		})
+	}
+}
+
+func TestView_archived(t *testing.T) {
+	tests := []struct {
+		name     string
+		archived bool
+		want     string
+		wantNot  string
+	}{
+		{
+			name:    "unarchived",
+			want:    "Fix the parser",
+			wantNot: "Old work",
+		},
+		{
+			name:     "archived",
+			archived: true,
+			want:     "Old work",
+			wantNot:  "Fix the parser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`"includeItems":true`).
+				Reply(200).
+				JSON(`{
+					"data": {
+						"repository": {
+							"projectV2": {
+								"id": "PN_1",
+								"number": 1,
+								"title": "Project",
+								"url": "https://github.com/users/heaths/projects/1",
+								"creator": {"login": "heaths"},
+								"createdAt": "2022-07-01T00:00:00Z",
+								"items": {
+									"totalCount": 2,
+									"nodes": [
+										{"id": "PNI_1", "type": "ISSUE", "content": {"number": 1, "title": "Fix the parser", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}},
+										{"id": "PNI_2", "type": "ISSUE", "isArchived": true, "content": {"number": 2, "title": "Old work", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}}
+									],
+									"pageInfo": {
+										"hasNextPage": false
+									}
+								}
+							}
+						}
+					}
+				}`)
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			fake := console.Fake()
+			opts := &viewOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+				},
+				number:   1,
+				items:    true,
+				archived: tt.archived,
+				limit:    20,
+				state:    "open",
+			}
+
+			err = view(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+			stdout, _, _ := fake.Buffers()
+			assert.Contains(t, stdout.String(), tt.want)
+			assert.NotContains(t, stdout.String(), tt.wantNot)
+		})
+	}
+}
+
+func TestView_archivedPages(t *testing.T) {
+	t.Cleanup(gock.Off)
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"includeItems":true`).
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"projectV2": {
+						"id": "PN_1",
+						"number": 1,
+						"title": "Project",
+						"url": "https://github.com/users/heaths/projects/1",
+						"creator": {"login": "heaths"},
+						"createdAt": "2022-07-01T00:00:00Z",
+						"items": {
+							"totalCount": 4,
+							"nodes": [
+								{"id": "PNI_1", "type": "ISSUE", "isArchived": true, "content": {"number": 1, "title": "Old work", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}},
+								{"id": "PNI_2", "type": "ISSUE", "content": {"number": 2, "title": "Fix the parser", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}}
+							],
+							"pageInfo": {
+								"hasNextPage": true,
+								"endCursor": "2"
+							}
+						}
+					}
+				}
+			}
+		}`)
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`RepositoryOwnerProjectV2MoreItems.*"variables":\{"after":"2","first":2,"number":1,"owner":"heaths"\}`).
+		Reply(200).
+		JSON(`{
+			"data": {
+				"repository": {
+					"projectV2": {
+						"items": {
+							"totalCount": 4,
+							"nodes": [
+								{"id": "PNI_3", "type": "ISSUE", "content": {"number": 3, "title": "Add a feature", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}},
+								{"id": "PNI_4", "type": "ISSUE", "isArchived": true, "content": {"number": 4, "title": "Older work", "state": "OPEN", "createdAt": "2022-07-01T00:00:00Z"}}
+							],
+							"pageInfo": {
+								"hasNextPage": false
+							}
+						}
+					}
+				}
+			}
+		}`)
+
+	repo, err := repository.Parse("heaths/gh-projects")
+	assert.NoError(t, err)
+
+	fake := console.Fake()
+	opts := &viewOptions{
+		GlobalOptions: GlobalOptions{
+			Console: fake,
+			Repo:    repo,
+
+			authToken: "***",
+			host:      "github.com",
+		},
+		number:   1,
+		items:    true,
+		archived: true,
+		limit:    2,
+		state:    "open",
+	}
+
+	err = view(opts)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+
+	stdout, _, _ := fake.Buffers()
+	assert.Contains(t, stdout.String(), "Old work")
+	assert.Contains(t, stdout.String(), "Older work")
+	assert.NotContains(t, stdout.String(), "Fix the parser")
+	assert.NotContains(t, stdout.String(), "Add a feature")
+}
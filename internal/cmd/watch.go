@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/cli/go-gh/pkg/api"
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+	"github.com/heaths/gh-projects/internal/watch"
+	"github.com/spf13/cobra"
+)
+
+// The maximum interval between polls is this many times the interval when nothing changes.
+const maxIntervalFactor = 8
+
+type watchOptions struct {
+	watch    bool
+	interval time.Duration
+	json     bool
+}
+
+func addWatchFlags(cmd *cobra.Command, opts *watchOptions) {
+	cmd.Flags().BoolVar(&opts.watch, "watch", false, "Print changes to items as they happen")
+	cmd.Flags().DurationVar(&opts.interval, "interval", 30*time.Second, "How often to check for changes with --watch")
+	cmd.Flags().BoolVar(&opts.json, "json", false, "Print changes as newline-delimited JSON with --watch")
+}
+
+func (opts *watchOptions) validate(cmd *cobra.Command) error {
+	if !opts.watch {
+		if cmd.Flags().Changed("interval") || opts.json {
+			return fmt.Errorf("--interval and --json require --watch")
+		}
+		return nil
+	}
+
+	if opts.interval < time.Second {
+		return fmt.Errorf("--interval must be at least 1s")
+	}
+
+	return nil
+}
+
+// watchItems polls the project and prints changes to items until interrupted.
+// Items are only fetched when the fingerprint of when items were last updated changes.
+func watchItems(opts *GlobalOptions, watchOpts *watchOptions, client api.GQLClient, number int, fetch func() ([]models.ProjectItem, error)) error {
+	fingerprint, err := itemsFingerprint(client, number, opts)
+	if err != nil {
+		return err
+	}
+
+	items, err := fetch()
+	if err != nil {
+		return err
+	}
+
+	t, err := template.New(opts.Console)
+	if err != nil {
+		return err
+	}
+
+	if opts.Console.IsStderrTTY() {
+		fmt.Fprintf(opts.Console.Stderr(), "Watching %s; press Ctrl+C to stop\n", text.Pluralize(len(items), "item"))
+	}
+
+	backoff := watch.Backoff{
+		Min: watchOpts.interval,
+		Max: watchOpts.interval * maxIntervalFactor,
+	}
+
+	changed := true
+	for sleep(opts, backoff.Next(changed)) {
+		changed = false
+
+		next, err := itemsFingerprint(client, number, opts)
+		if err != nil {
+			return err
+		}
+		if next == fingerprint {
+			continue
+		}
+		fingerprint = next
+
+		nextItems, err := fetch()
+		if err != nil {
+			return err
+		}
+
+		changes := watch.Diff(items, nextItems, timeNow(opts))
+		items = nextItems
+		if len(changes) == 0 {
+			continue
+		}
+		changed = true
+
+		if watchOpts.json {
+			err = t.ChangesJSON(changes)
+		} else {
+			err = t.Changes(changes)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// itemsFingerprint gets a value that changes when items are added, removed, or updated.
+// The GraphQL API does not support conditional requests, so this inexpensive query
+// avoids fetching all field values when nothing has changed.
+func itemsFingerprint(client api.GQLClient, number int, opts *GlobalOptions) (string, error) {
+	vars := map[string]interface{}{
+		"owner":  opts.Repo.Owner(),
+		"number": number,
+	}
+
+	var data struct {
+		Repository struct {
+			ProjectV2 struct {
+				Items struct {
+					Nodes []struct {
+						ID        string
+						UpdatedAt string
+						Content   struct {
+							UpdatedAt string
+						}
+					}
+					PageInfo struct {
+						HasNextPage bool
+						EndCursor   string
+					}
+				}
+			}
+		}
+	}
+
+	h := sha256.New()
+	for {
+		err := client.Do(queryRepositoryOwnerProjectV2ItemsUpdated, vars, &data)
+		if err != nil {
+			return "", err
+		}
+
+		items := data.Repository.ProjectV2.Items
+		for _, item := range items.Nodes {
+			fmt.Fprintf(h, "%s\t%s\t%s\n", item.ID, item.UpdatedAt, item.Content.UpdatedAt)
+		}
+
+		if !items.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = items.PageInfo.EndCursor
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+const queryRepositoryOwnerProjectV2ItemsUpdated = `
+query RepositoryOwnerProjectV2ItemsUpdated($owner: String!, $number: Int!, $after: String) {
+	repository: repositoryOwner(login: $owner) {
+		...on ProjectV2Owner {
+			projectV2(number: $number) {
+				items(first: 100, after: $after) {
+					nodes {
+						id
+						updatedAt
+						content {
+							...on DraftIssue {
+								updatedAt
+							}
+							...on Issue {
+								updatedAt
+							}
+							...on PullRequest {
+								updatedAt
+							}
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}
+	}
+}
+`
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func itemsUpdatedJSON(updatedAt string) string {
+	return `{"data":{"repository":{"projectV2":{"items":{"nodes":[{"id":"PNI_1","updatedAt":"` + updatedAt + `"}],"pageInfo":{"hasNextPage":false}}}}}}`
+}
+
+func TestItemList_watch(t *testing.T) {
+	tests := []struct {
+		name       string
+		view       string
+		json       bool
+		wantStdout string
+	}{
+		{
+			name: "text",
+			wantStdout: heredoc.Doc(`
+				Issue        #1  Fix the parser  open
+				PullRequest  #2  Add a feature   merged
+				Issue        #3  Write docs      open
+
+				12:00:00 #1 Fix the parser: Status changed from In Progress to Done
+			`),
+		},
+		{
+			name: "json",
+			json: true,
+			wantStdout: heredoc.Doc(`
+				Issue        #1  Fix the parser  open
+				PullRequest  #2  Add a feature   merged
+				Issue        #3  Write docs      open
+
+				{"time":"2026-10-19T12:00:00Z","kind":"changed","itemId":"PNI_1","number":1,"title":"Fix the parser","field":"Status","from":"In Progress","to":"Done"}
+			`),
+		},
+		{
+			name: "view",
+			view: "board",
+			wantStdout: heredoc.Doc(`
+				Issue  #3  Write docs      open  Todo         5
+				Issue  #1  Fix the parser  open  In Progress  3
+
+				12:00:00 #1 Fix the parser: removed
+			`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Cleanup(gock.Off)
+
+			if tt.view != "" {
+				gock.New("https://api.github.com").
+					Post("/graphql").
+					BodyString(`RepositoryOwnerProjectV2Views`).
+					Reply(200).
+					JSON(viewsJSON)
+			}
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemFields`).
+				Times(2).
+				Reply(200).
+				JSON(itemFieldsJSON)
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemsUpdated`).
+				Times(2).
+				Reply(200).
+				JSON(itemsUpdatedJSON("2026-10-19T11:00:00Z"))
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemsUpdated`).
+				Reply(200).
+				JSON(itemsUpdatedJSON("2026-10-19T12:00:00Z"))
+			gock.New("https://api.github.com").
+				Post("/graphql").
+				BodyString(`RepositoryOwnerProjectV2ItemFields`).
+				Reply(200).
+				JSON(strings.Replace(itemFieldsJSON, `"In Progress"`, `"Done"`, 1))
+
+			repo, err := repository.Parse("heaths/gh-projects")
+			assert.NoError(t, err)
+
+			var waits []time.Duration
+			fake := console.Fake()
+			opts := &itemListOptions{
+				GlobalOptions: GlobalOptions{
+					Console: fake,
+					Repo:    repo,
+
+					authToken: "***",
+					host:      "github.com",
+					now:       time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+					sleep: func(d time.Duration) bool {
+						waits = append(waits, d)
+						return len(waits) < 3
+					},
+				},
+				number: 1,
+				limit:  30,
+				view:   tt.view,
+				watch: watchOptions{
+					watch:    true,
+					interval: 30 * time.Second,
+					json:     tt.json,
+				},
+			}
+
+			err = itemList(opts)
+			assert.NoError(t, err)
+			assert.True(t, gock.IsDone(), pendingMocks(gock.Pending()))
+			assert.Equal(t, []time.Duration{30 * time.Second, time.Minute, 30 * time.Second}, waits)
+
+			stdout, _, _ := fake.Buffers()
+			assert.Equal(t, tt.wantStdout, stdout.String())
+		})
+	}
+}
+
+func TestNewItemListCmd_watch(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "interval requires watch",
+			args:    []string{"1", "--interval", "1m"},
+			wantErr: "--interval and --json require --watch",
+		},
+		{
+			name:    "json requires watch",
+			args:    []string{"1", "--json"},
+			wantErr: "--interval and --json require --watch",
+		},
+		{
+			name:    "interval too short",
+			args:    []string{"1", "--watch", "--interval", "10ms"},
+			wantErr: "--interval must be at least 1s",
+		},
+		{
+			name:    "watch with web",
+			args:    []string{"1", "--watch", "--web"},
+			wantErr: "--watch cannot be used with --web",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newItemListCmd(&GlobalOptions{
+				Console: console.Fake(),
+			})
+			cmd.SilenceUsage = true
+
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,409 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is persistent configuration read from user and repository config files, and environment variables.
+type Config struct {
+	// Owner is the default project owner as [HOST/]OWNER when --repo is not passed.
+	Owner string `yaml:"owner,omitempty"`
+
+	// Project is the default project number, URL, or title when none is passed.
+	Project string `yaml:"project,omitempty"`
+
+	// Fields are default field values set on issues and pull requests when they are added.
+	Fields map[string]string `yaml:"fields,omitempty"`
+
+	// Workers is the number of concurrent workers making requests.
+	Workers int `yaml:"workers,omitempty"`
+
+	// Format is the default output format for commands that support JSON.
+	Format string `yaml:"format,omitempty"`
+
+	// Aliases are names for projects as [HOST/]OWNER/NUMBER or a project URL.
+	Aliases map[string]string `yaml:"aliases,omitempty"`
+}
+
+// Setting is a key and value of a config setting.
+type Setting struct {
+	Key   string
+	Value string
+}
+
+// Keys are the names of settings. Default field values are set with "fields.NAME".
+var Keys = []string{"owner", "project", "fields", "workers", "format"}
+
+// Formats are the valid output formats.
+var Formats = []string{"table", "json"}
+
+// Environment variables that override config files.
+var envKeys = map[string]string{
+	"owner":   "GH_PROJECTS_OWNER",
+	"project": "GH_PROJECTS_PROJECT",
+	"fields":  "GH_PROJECTS_FIELDS",
+	"workers": "GH_PROJECTS_WORKERS",
+	"format":  "GH_PROJECTS_FORMAT",
+}
+
+// UserPath gets the path to the user config file under $XDG_CONFIG_HOME or ~/.config.
+func UserPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "gh-projects", "config.yml")
+}
+
+// RepoPath gets the path to the config file in the git repository containing dir, or an empty string if not in a repository.
+func RepoPath(dir string) string {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return filepath.Join(dir, ".github", "gh-projects.yml")
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Load reads the user config, the repository config if repoPath is not empty, and environment variables
+// using getenv, with later sources taking precedence.
+func Load(userPath, repoPath string, getenv func(string) string) (*Config, error) {
+	c, err := readValid(userPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if repoPath != "" {
+		repo, err := readValid(repoPath)
+		if err != nil {
+			return nil, err
+		}
+		c.merge(repo)
+	}
+
+	env := &Config{}
+	for _, key := range Keys {
+		if value := getenv(envKeys[key]); value != "" {
+			if err := env.Set(key, value); err != nil {
+				return nil, fmt.Errorf("%s: %w", envKeys[key], err)
+			}
+		}
+	}
+	c.merge(env)
+
+	return c, nil
+}
+
+// Read reads a config file, or returns an empty config if the file does not exist.
+// Settings are not validated so that invalid settings can be changed.
+func Read(path string) (*Config, error) {
+	c := &Config{}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// readValid reads a config file and validates its settings.
+func readValid(path string) (*Config, error) {
+	c, err := Read(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Format != "" && !validFormat(c.Format) {
+		return nil, fmt.Errorf("failed to read %s: %w", path, formatError(c.Format))
+	}
+
+	return c, nil
+}
+
+// Write writes the config file, creating directories as needed.
+func (c *Config) Write(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+// Get gets the value of a setting by key.
+func (c *Config) Get(key string) (string, error) {
+	name, field, err := parseKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	switch name {
+	case "owner":
+		return c.Owner, nil
+	case "project":
+		return c.Project, nil
+	case "fields":
+		if field != "" {
+			_, value := c.field(field)
+			return value, nil
+		}
+
+		var pairs []string
+		for _, s := range c.fieldSettings() {
+			pairs = append(pairs, strings.TrimPrefix(s.Key, "fields.")+"="+s.Value)
+		}
+		return strings.Join(pairs, ","), nil
+	case "workers":
+		if c.Workers == 0 {
+			return "", nil
+		}
+		return strconv.Itoa(c.Workers), nil
+	default:
+		return c.Format, nil
+	}
+}
+
+// Set sets the value of a setting by key, or removes it if value is empty.
+// The "fields" key takes comma-separated NAME=VALUE pairs, while "fields.NAME" sets a single field.
+func (c *Config) Set(key, value string) error {
+	name, field, err := parseKey(key)
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "owner":
+		if strings.Count(value, "/") > 1 || strings.HasPrefix(value, "/") || strings.HasSuffix(value, "/") {
+			return fmt.Errorf("invalid owner %q; must be [HOST/]OWNER", value)
+		}
+		c.Owner = value
+	case "project":
+		c.Project = value
+	case "fields":
+		if field != "" {
+			c.setField(field, value)
+			return nil
+		}
+
+		c.Fields = nil
+		if value == "" {
+			return nil
+		}
+		for _, pair := range strings.Split(value, ",") {
+			name, value, found := strings.Cut(pair, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				return fmt.Errorf("%s must be formatted as name=value", pair)
+			}
+			c.setField(strings.TrimSpace(name), value)
+		}
+	case "workers":
+		if value == "" {
+			c.Workers = 0
+			return nil
+		}
+		workers, err := strconv.Atoi(value)
+		if err != nil || workers < 1 {
+			return fmt.Errorf("invalid workers %q; must be a positive number", value)
+		}
+		c.Workers = workers
+	default:
+		if value != "" && !validFormat(value) {
+			return formatError(value)
+		}
+		c.Format = strings.ToLower(value)
+	}
+
+	return nil
+}
+
+// List gets all settings that have a value, sorted by key.
+func (c *Config) List() []Setting {
+	var settings []Setting
+	for _, key := range Keys {
+		if key == "fields" {
+			settings = append(settings, c.fieldSettings()...)
+			continue
+		}
+
+		if value, _ := c.Get(key); value != "" {
+			settings = append(settings, Setting{Key: key, Value: value})
+		}
+	}
+
+	return settings
+}
+
+// merge sets values from other that are not empty. Fields are merged by name.
+func (c *Config) merge(other *Config) {
+	if other.Owner != "" {
+		c.Owner = other.Owner
+	}
+	if other.Project != "" {
+		c.Project = other.Project
+	}
+	for name, value := range other.Fields {
+		c.setField(name, value)
+	}
+	if other.Workers != 0 {
+		c.Workers = other.Workers
+	}
+	if other.Format != "" {
+		c.Format = other.Format
+	}
+	for name, project := range other.Aliases {
+		_ = c.SetAlias(name, project)
+	}
+}
+
+// Alias gets the project for an alias compared case-insensitively.
+func (c *Config) Alias(name string) (string, bool) {
+	for k, v := range c.Aliases {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// SetAlias sets the project for an alias, replacing any alias with the same name compared case-insensitively.
+// Aliases cannot look like project numbers or URLs.
+func (c *Config) SetAlias(name, project string) error {
+	if name == "" || strings.HasPrefix(name, "#") || strings.Trim(name, "0123456789") == "" || strings.ContainsAny(name, "/: \t") {
+		return fmt.Errorf("invalid alias %q; must not be a number or contain spaces, slashes, or colons", name)
+	}
+
+	c.DeleteAlias(name)
+	if c.Aliases == nil {
+		c.Aliases = make(map[string]string)
+	}
+	c.Aliases[name] = project
+
+	return nil
+}
+
+// DeleteAlias deletes an alias compared case-insensitively, and returns whether it existed.
+func (c *Config) DeleteAlias(name string) bool {
+	for k := range c.Aliases {
+		if strings.EqualFold(k, name) {
+			delete(c.Aliases, k)
+			if len(c.Aliases) == 0 {
+				c.Aliases = nil
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// AliasList gets all aliases sorted by name.
+func (c *Config) AliasList() []Setting {
+	settings := make([]Setting, 0, len(c.Aliases))
+	for name, project := range c.Aliases {
+		settings = append(settings, Setting{Key: name, Value: project})
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return strings.ToLower(settings[i].Key) < strings.ToLower(settings[j].Key)
+	})
+
+	return settings
+}
+
+// field gets the name and value of a field compared case-insensitively.
+func (c *Config) field(name string) (string, string) {
+	for k, v := range c.Fields {
+		if strings.EqualFold(k, name) {
+			return k, v
+		}
+	}
+
+	return "", ""
+}
+
+// setField sets a field value replacing any field with the same name compared case-insensitively,
+// or removes the field if value is empty.
+func (c *Config) setField(name, value string) {
+	if k, _ := c.field(name); k != "" {
+		delete(c.Fields, k)
+	}
+
+	if value == "" {
+		if len(c.Fields) == 0 {
+			c.Fields = nil
+		}
+		return
+	}
+
+	if c.Fields == nil {
+		c.Fields = make(map[string]string)
+	}
+	c.Fields[name] = value
+}
+
+func (c *Config) fieldSettings() []Setting {
+	settings := make([]Setting, 0, len(c.Fields))
+	for name, value := range c.Fields {
+		settings = append(settings, Setting{Key: "fields." + name, Value: value})
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return strings.ToLower(settings[i].Key) < strings.ToLower(settings[j].Key)
+	})
+
+	return settings
+}
+
+// parseKey splits a key like "fields.Status" into its name and field name.
+func parseKey(key string) (name, field string, err error) {
+	name, field, _ = strings.Cut(key, ".")
+	name = strings.ToLower(name)
+
+	if _, ok := envKeys[name]; !ok || (field != "" && name != "fields") {
+		return "", "", fmt.Errorf("unknown config key: %s", key)
+	}
+
+	return name, field, nil
+}
+
+func validFormat(format string) bool {
+	for _, f := range Formats {
+		if strings.EqualFold(f, format) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func formatError(format string) error {
+	return fmt.Errorf("invalid format %q; valid values are {%s}", format, strings.Join(Formats, "|"))
+}
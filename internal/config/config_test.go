@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "user", "config.yml")
+	repoPath := filepath.Join(dir, "repo", ".github", "gh-projects.yml")
+
+	user := &Config{
+		Owner:   "heaths",
+		Project: "1",
+		Fields:  map[string]string{"Status": "Triage", "Priority": "P2"},
+		Workers: 4,
+	}
+	require.NoError(t, user.Write(userPath))
+
+	repo := &Config{
+		Project: "12",
+		Fields:  map[string]string{"status": "Todo"},
+	}
+	require.NoError(t, repo.Write(repoPath))
+
+	env := map[string]string{
+		"GH_PROJECTS_FORMAT": "json",
+		"GH_PROJECTS_FIELDS": "Iteration=@current",
+	}
+
+	c, err := Load(userPath, repoPath, func(key string) string {
+		return env[key]
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []Setting{
+		{Key: "owner", Value: "heaths"},
+		{Key: "project", Value: "12"},
+		{Key: "fields.Iteration", Value: "@current"},
+		{Key: "fields.Priority", Value: "P2"},
+		{Key: "fields.status", Value: "Todo"},
+		{Key: "workers", Value: "4"},
+		{Key: "format", Value: "json"},
+	}, c.List())
+
+	// Missing files are empty.
+	c, err = Load(filepath.Join(dir, "missing.yml"), "", func(string) string { return "" })
+	require.NoError(t, err)
+	assert.Empty(t, c.List())
+
+	// Invalid environment variables are reported.
+	_, err = Load(userPath, "", func(key string) string {
+		if key == "GH_PROJECTS_WORKERS" {
+			return "many"
+		}
+		return ""
+	})
+	assert.EqualError(t, err, `GH_PROJECTS_WORKERS: invalid workers "many"; must be a positive number`)
+}
+
+func TestLoad_invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(path, []byte("format: xml\n"), 0o600))
+
+	_, err := Load(path, "", func(string) string { return "" })
+	assert.EqualError(t, err, "failed to read "+path+`: invalid format "xml"; valid values are {table|json}`)
+
+	// Invalid settings can still be read so they can be changed.
+	c, err := Read(path)
+	require.NoError(t, err)
+	assert.Equal(t, "xml", c.Format)
+}
+
+func TestConfig_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		value   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "owner",
+			key:   "owner",
+			value: "ghe.example.com/acme",
+			want:  "ghe.example.com/acme",
+		},
+		{
+			name:    "invalid owner",
+			key:     "owner",
+			value:   "heaths/gh-projects/1",
+			wantErr: `invalid owner "heaths/gh-projects/1"; must be [HOST/]OWNER`,
+		},
+		{
+			name:  "field",
+			key:   "fields.Status",
+			value: "Triage",
+			want:  "Triage",
+		},
+		{
+			name:  "fields",
+			key:   "fields",
+			value: "Status=Triage,Priority=P2",
+			want:  "Priority=P2,Status=Triage",
+		},
+		{
+			name:    "invalid fields",
+			key:     "fields",
+			value:   "Status",
+			wantErr: "Status must be formatted as name=value",
+		},
+		{
+			name:  "workers",
+			key:   "Workers",
+			value: "4",
+			want:  "4",
+		},
+		{
+			name:    "invalid workers",
+			key:     "workers",
+			value:   "0",
+			wantErr: `invalid workers "0"; must be a positive number`,
+		},
+		{
+			name:  "format",
+			key:   "format",
+			value: "JSON",
+			want:  "json",
+		},
+		{
+			name:    "invalid format",
+			key:     "format",
+			value:   "xml",
+			wantErr: `invalid format "xml"; valid values are {table|json}`,
+		},
+		{
+			name:    "unknown key",
+			key:     "color",
+			value:   "always",
+			wantErr: "unknown config key: color",
+		},
+		{
+			name:    "unknown subkey",
+			key:     "owner.name",
+			value:   "heaths",
+			wantErr: "unknown config key: owner.name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+			err := c.Set(tt.key, tt.value)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			got, err := c.Get(tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// An empty value removes the setting.
+			require.NoError(t, c.Set(tt.key, ""))
+			assert.Empty(t, c.List())
+		})
+	}
+}
+
+func TestRepoPath(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o700))
+
+	dir := filepath.Join(root, "internal", "config")
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+
+	assert.Equal(t, filepath.Join(root, ".github", "gh-projects.yml"), RepoPath(dir))
+}
+
+func TestConfig_aliases(t *testing.T) {
+	c := &Config{}
+	require.NoError(t, c.SetAlias("roadmap", "acme/12"))
+	require.NoError(t, c.SetAlias("Backlog", "https://ghe.example.com/orgs/acme/projects/7"))
+
+	project, ok := c.Alias("Roadmap")
+	assert.True(t, ok)
+	assert.Equal(t, "acme/12", project)
+
+	// Aliases are replaced case-insensitively.
+	require.NoError(t, c.SetAlias("RoadMap", "heaths/1"))
+	assert.Equal(t, []Setting{
+		{Key: "Backlog", Value: "https://ghe.example.com/orgs/acme/projects/7"},
+		{Key: "RoadMap", Value: "heaths/1"},
+	}, c.AliasList())
+
+	assert.True(t, c.DeleteAlias("roadmap"))
+	assert.False(t, c.DeleteAlias("roadmap"))
+
+	_, ok = c.Alias("roadmap")
+	assert.False(t, ok)
+
+	for _, name := range []string{"", "12", "#roadmap", "acme/roadmap", "https:", "the roadmap"} {
+		assert.EqualError(t, c.SetAlias(name, "acme/12"),
+			fmt.Sprintf("invalid alias %q; must not be a number or contain spaces, slashes, or colons", name))
+	}
+}
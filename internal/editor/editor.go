@@ -0,0 +1,119 @@
+package editor
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+
+	"github.com/cli/go-gh/pkg/config"
+	"github.com/cli/safeexec"
+	"github.com/google/shlex"
+)
+
+// Editor opens text in an external editor.
+type Editor struct {
+	command string
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+
+	// Test-only hooks.
+	run func(name string, args []string) error
+}
+
+// New creates an Editor using the command from Resolve if command is empty.
+func New(command string, stdin io.Reader, stdout, stderr io.Writer) *Editor {
+	if command == "" {
+		command = Resolve()
+	}
+
+	e := &Editor{
+		command: command,
+		stdin:   stdin,
+		stdout:  stdout,
+		stderr:  stderr,
+	}
+	e.run = e.exec
+
+	return e
+}
+
+// Resolve gets the editor command from GH_EDITOR, the gh editor setting, VISUAL, or EDITOR in that order.
+func Resolve() string {
+	if editor := os.Getenv("GH_EDITOR"); editor != "" {
+		return editor
+	}
+
+	if cfg, err := config.Read(); err == nil {
+		if editor, _ := cfg.Get([]string{"editor"}); editor != "" {
+			return editor
+		}
+	}
+
+	if editor := os.Getenv("VISUAL"); editor != "" {
+		return editor
+	}
+
+	if editor := os.Getenv("EDITOR"); editor != "" {
+		return editor
+	}
+
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+
+	return "nano"
+}
+
+// Edit writes text to a temporary file with the given pattern e.g., "*.md", opens it in the editor,
+// and returns the edited text after the editor exits.
+func (e *Editor) Edit(pattern, text string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(text)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	args, err := shlex.Split(e.command)
+	if err != nil {
+		return "", fmt.Errorf("invalid editor %q: %w", e.command, err)
+	}
+	if len(args) == 0 {
+		return "", fmt.Errorf("no editor defined")
+	}
+
+	if err := e.run(args[0], append(args[1:], f.Name())); err != nil {
+		return "", fmt.Errorf("failed to run editor %q: %w", e.command, err)
+	}
+
+	edited, err := os.ReadFile(f.Name())
+	if err != nil {
+		return "", err
+	}
+
+	return string(edited), nil
+}
+
+func (e *Editor) exec(name string, args []string) error {
+	path, err := safeexec.LookPath(name)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = e.stdin
+	cmd.Stdout = e.stdout
+	cmd.Stderr = e.stderr
+
+	return cmd.Run()
+}
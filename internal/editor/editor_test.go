@@ -0,0 +1,58 @@
+package editor
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditor_Edit(t *testing.T) {
+	var gotName string
+	var gotArgs []string
+
+	e := New("code --wait", nil, nil, nil)
+	e.run = func(name string, args []string) error {
+		gotName, gotArgs = name, args
+
+		path := args[len(args)-1]
+		original, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		return os.WriteFile(path, append(original, " edited"...), 0o600)
+	}
+
+	got, err := e.Edit("*.md", "original")
+	assert.NoError(t, err)
+	assert.Equal(t, "original edited", got)
+	assert.Equal(t, "code", gotName)
+	assert.Equal(t, "--wait", gotArgs[0])
+	assert.NoFileExists(t, gotArgs[1], "temporary file should be removed")
+}
+
+func TestEditor_Edit_failed(t *testing.T) {
+	e := New("vim", nil, nil, nil)
+	e.run = func(name string, args []string) error {
+		return errors.New("exit status 1")
+	}
+
+	_, err := e.Edit("*.md", "original")
+	assert.EqualError(t, err, `failed to run editor "vim": exit status 1`)
+}
+
+func TestResolve(t *testing.T) {
+	t.Setenv("GH_EDITOR", "")
+	t.Setenv("GH_CONFIG_DIR", t.TempDir())
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "vim")
+	assert.Equal(t, "vim", Resolve())
+
+	t.Setenv("VISUAL", "code --wait")
+	assert.Equal(t, "code --wait", Resolve())
+
+	t.Setenv("GH_EDITOR", "nano")
+	assert.Equal(t, "nano", Resolve())
+}
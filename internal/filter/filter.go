@@ -0,0 +1,517 @@
+// Package filter matches and sorts project items like project views do in the browser.
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+const dateLayout = "2006-01-02"
+
+// Filter is a parsed project view filter e.g., `status:Todo,"In Progress" -label:bug is:open`.
+type Filter struct {
+	terms []term
+}
+
+// Env is the environment in which items are matched.
+type Env struct {
+	// Viewer is the login to match "@me".
+	Viewer string
+
+	// Now is used to match "@today" and "@current" iterations.
+	Now time.Time
+}
+
+type term struct {
+	negate bool
+	key    string
+	values []string
+}
+
+// Parse parses a filter. An empty filter matches all items.
+func Parse(query string) (*Filter, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Filter{}
+	for _, token := range tokens {
+		t := term{}
+		if strings.HasPrefix(token, "-") && len(token) > 1 {
+			t.negate = true
+			token = token[1:]
+		}
+
+		if i := strings.Index(token, ":"); i > 0 {
+			t.key = strings.ToLower(unquote(token[:i]))
+			t.values, err = splitValues(token[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			if len(t.values) == 0 {
+				return nil, fmt.Errorf("missing value for %q in filter", t.key)
+			}
+		} else {
+			t.values = []string{unquote(token)}
+		}
+
+		f.terms = append(f.terms, t)
+	}
+
+	return f, nil
+}
+
+// And adds a term that matches any of the values for a key, which may be a field name containing spaces.
+func (f *Filter) And(key string, values ...string) *Filter {
+	f.terms = append(f.terms, term{key: strings.ToLower(key), values: values})
+	return f
+}
+
+// String gets the filter as it would be parsed.
+func (f *Filter) String() string {
+	terms := make([]string, len(f.terms))
+	for i, t := range f.terms {
+		var sb strings.Builder
+		if t.negate {
+			sb.WriteString("-")
+		}
+		if t.key != "" {
+			sb.WriteString(t.key)
+			sb.WriteString(":")
+		}
+		for j, v := range t.values {
+			if j > 0 {
+				sb.WriteString(",")
+			}
+			if strings.ContainsAny(v, " ,") {
+				v = strconv.Quote(v)
+			}
+			sb.WriteString(v)
+		}
+		terms[i] = sb.String()
+	}
+
+	return strings.Join(terms, " ")
+}
+
+// Match gets whether the item matches all terms of the filter.
+func (f *Filter) Match(item models.ProjectItem, env Env) bool {
+	if f == nil {
+		return true
+	}
+
+	if env.Now.IsZero() {
+		env.Now = time.Now()
+	}
+
+	for _, t := range f.terms {
+		if t.match(item, env) == t.negate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// match gets whether any of the term values match the item.
+func (t term) match(item models.ProjectItem, env Env) bool {
+	for _, value := range t.values {
+		if t.matchValue(item, value, env) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t term) matchValue(item models.ProjectItem, value string, env Env) bool {
+	switch t.key {
+	case "":
+		return containsFold(item.Content.Title, value)
+
+	case "is":
+		return matchIs(item, value)
+
+	case "has", "no":
+		hasValue := len(itemValues(item, value)) > 0
+		return hasValue == (t.key == "has")
+
+	case "created":
+		return matchTime(item.Content.CreatedAt, value, env)
+
+	case "updated":
+		return matchTime(item.Content.UpdatedAt, value, env)
+
+	case "closed":
+		return matchTime(item.Content.ClosedAt, value, env)
+	}
+
+	if fieldValue, ok := item.FieldValue(t.key); ok {
+		switch fieldValue.Field.DataType {
+		case "ITERATION":
+			return matchIteration(fieldValue, value, env)
+
+		case "DATE":
+			if date, err := time.Parse(dateLayout, fieldValue.Date); err == nil {
+				return matchTime(&date, value, env)
+			}
+			return false
+
+		case "NUMBER":
+			if fieldValue.Number != nil {
+				return matchNumber(*fieldValue.Number, value)
+			}
+			return false
+		}
+	}
+
+	if value == "@me" {
+		value = env.Viewer
+	}
+
+	for _, v := range itemValues(item, t.key) {
+		if matchWildcard(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// itemValues gets values of a field by name, or from the issue or pull request for common qualifiers.
+func itemValues(item models.ProjectItem, key string) []string {
+	if fieldValue, ok := item.FieldValue(key); ok {
+		return fieldValue.Values()
+	}
+
+	// Qualifiers may be singular or plural e.g., "label:bug" or "labels:bug".
+	switch strings.TrimSuffix(strings.ToLower(key), "s") {
+	case "assignee":
+		return item.Content.Assignees.Logins()
+
+	case "label":
+		return item.Content.Labels.Names()
+
+	case "repo", "repository":
+		if item.Content.Repository != nil {
+			return []string{item.Content.Repository.NameWithOwner}
+		}
+
+	case "title":
+		return []string{item.Content.Title}
+	}
+
+	return nil
+}
+
+func matchIs(item models.ProjectItem, value string) bool {
+	switch strings.ToLower(value) {
+	case "open", "closed", "merged":
+		return strings.EqualFold(item.Content.State, value)
+	case "issue":
+		return item.Type == "ISSUE"
+	case "pr":
+		return item.Type == "PULL_REQUEST"
+	case "draft":
+		return item.Type == "DRAFT_ISSUE"
+	case "archived":
+		return item.IsArchived
+	default:
+		return false
+	}
+}
+
+func matchIteration(fieldValue models.ProjectItemFieldValue, value string, env Env) bool {
+	start, end, ok := fieldValue.Iteration()
+	if !ok {
+		return false
+	}
+
+	duration := end.Sub(start)
+	switch strings.ToLower(value) {
+	case "@current":
+		return !env.Now.Before(start) && env.Now.Before(end)
+	case "@next":
+		return start.After(env.Now) && start.Before(env.Now.Add(duration))
+	case "@previous":
+		return !end.After(env.Now) && end.After(env.Now.Add(-duration))
+	default:
+		return matchWildcard(fieldValue.Title, value)
+	}
+}
+
+func matchTime(t *time.Time, value string, env Env) bool {
+	if t == nil {
+		return false
+	}
+
+	// Compare only dates, like the browser.
+	d := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix()
+	return compare(float64(d), value, func(s string) (float64, bool) {
+		date, err := parseDate(s, env.Now)
+		if err != nil {
+			return 0, false
+		}
+		return float64(date.Unix()), true
+	})
+}
+
+func matchNumber(n float64, value string) bool {
+	return compare(n, value, func(s string) (float64, bool) {
+		v, err := strconv.ParseFloat(s, 64)
+		return v, err == nil
+	})
+}
+
+// compare matches a value against comparisons like ">5", "<=@today", or ranges like "1..5".
+func compare(v float64, value string, parse func(string) (float64, bool)) bool {
+	if i := strings.Index(value, ".."); i >= 0 {
+		from, to := value[:i], value[i+2:]
+		if from != "" && from != "*" {
+			if f, ok := parse(from); !ok || v < f {
+				return false
+			}
+		}
+		if to != "" && to != "*" {
+			if t, ok := parse(to); !ok || v > t {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, op) {
+			operand, ok := parse(value[len(op):])
+			if !ok {
+				return false
+			}
+
+			switch op {
+			case ">=":
+				return v >= operand
+			case "<=":
+				return v <= operand
+			case ">":
+				return v > operand
+			default:
+				return v < operand
+			}
+		}
+	}
+
+	operand, ok := parse(value)
+	return ok && v == operand
+}
+
+// parseDate parses a date like "2022-08-01", "@today", or "@today-14d" with units of days, weeks, months, or years.
+func parseDate(s string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !strings.HasPrefix(strings.ToLower(s), "@today") {
+		return time.Parse(dateLayout, s)
+	}
+
+	offset := s[len("@today"):]
+	if offset == "" {
+		return today, nil
+	}
+
+	unit := offset[len(offset)-1]
+	n, err := strconv.Atoi(offset[:len(offset)-1])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %s", s)
+	}
+
+	switch unit {
+	case 'd':
+		return today.AddDate(0, 0, n), nil
+	case 'w':
+		return today.AddDate(0, 0, 7*n), nil
+	case 'm':
+		return today.AddDate(0, n, 0), nil
+	case 'y':
+		return today.AddDate(n, 0, 0), nil
+	default:
+		return time.Time{}, fmt.Errorf("invalid date: %s", s)
+	}
+}
+
+// matchWildcard matches s case-insensitively against a pattern with optional leading or trailing "*".
+func matchWildcard(s, pattern string) bool {
+	s, pattern = strings.ToLower(s), strings.ToLower(pattern)
+	prefix := strings.HasSuffix(pattern, "*")
+	suffix := strings.HasPrefix(pattern, "*")
+	pattern = strings.Trim(pattern, "*")
+
+	switch {
+	case prefix && suffix:
+		return strings.Contains(s, pattern)
+	case prefix:
+		return strings.HasPrefix(s, pattern)
+	case suffix:
+		return strings.HasSuffix(s, pattern)
+	default:
+		return s == pattern
+	}
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// tokenize splits a query on whitespace outside of quotes.
+func tokenize(query string) ([]string, error) {
+	var tokens []string
+	var sb strings.Builder
+	quoted := false
+
+	for _, r := range query {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			sb.WriteRune(r)
+		case unicode.IsSpace(r) && !quoted:
+			if sb.Len() > 0 {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if quoted {
+		return nil, fmt.Errorf("unterminated quote in filter: %s", query)
+	}
+	if sb.Len() > 0 {
+		tokens = append(tokens, sb.String())
+	}
+
+	return tokens, nil
+}
+
+// splitValues splits comma-separated values outside of quotes.
+func splitValues(s string) ([]string, error) {
+	var values []string
+	var sb strings.Builder
+	quoted := false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			if sb.Len() > 0 {
+				values = append(values, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(r)
+		}
+	}
+
+	if sb.Len() > 0 {
+		values = append(values, sb.String())
+	}
+
+	return values, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// Sort stably sorts items by each field in order. Items without a value sort last in either direction.
+func Sort(items []models.ProjectItem, sortBy []models.ProjectViewSortBy) {
+	if len(sortBy) == 0 {
+		return
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		for _, s := range sortBy {
+			c := compareItems(items[i], items[j], s.Field)
+			if c == 0 {
+				continue
+			}
+
+			if s.Direction == "DESC" && c != emptyLast && c != -emptyLast {
+				c = -c
+			}
+			return c < 0
+		}
+
+		return false
+	})
+}
+
+// emptyLast is returned from compareItems when only one item has a value, and is never reversed.
+const emptyLast = 2
+
+func compareItems(a, b models.ProjectItem, field models.ProjectField) int {
+	av, aok := sortKey(a, field)
+	bv, bok := sortKey(b, field)
+
+	switch {
+	case !aok && !bok:
+		return 0
+	case !aok:
+		return emptyLast
+	case !bok:
+		return -emptyLast
+	}
+
+	if an, err := strconv.ParseFloat(av, 64); err == nil {
+		if bn, err := strconv.ParseFloat(bv, 64); err == nil {
+			switch {
+			case an < bn:
+				return -1
+			case an > bn:
+				return 1
+			default:
+				return 0
+			}
+		}
+	}
+
+	return strings.Compare(strings.ToLower(av), strings.ToLower(bv))
+}
+
+// sortKey gets a comparable value for the field e.g., the option position for single select fields.
+func sortKey(item models.ProjectItem, field models.ProjectField) (string, bool) {
+	value, ok := item.FieldValue(field.Name)
+	if !ok {
+		if strings.EqualFold(field.Name, "Title") {
+			return item.Content.Title, item.Content.Title != ""
+		}
+		return "", false
+	}
+
+	switch value.Field.DataType {
+	case "SINGLE_SELECT":
+		for i, option := range field.Options {
+			if strings.EqualFold(option.Name, value.Name) {
+				return strconv.Itoa(i), true
+			}
+		}
+	case "ITERATION":
+		return value.StartDate, value.StartDate != ""
+	case "NUMBER":
+		if value.Number != nil {
+			return strconv.FormatFloat(*value.Number, 'f', -1, 64), true
+		}
+		return "", false
+	}
+
+	s := value.String()
+	return s, s != ""
+}
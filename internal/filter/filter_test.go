@@ -0,0 +1,201 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {
+			"number": 1,
+			"title": "Fix the parser",
+			"state": "OPEN",
+			"updatedAt": "2022-07-01T00:00:00Z",
+			"assignees": {"nodes": [{"login": "heaths"}]},
+			"labels": {"nodes": [{"name": "bug"}]}
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+				{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}},
+				{"title": "Iteration 2", "startDate": "2022-07-25", "duration": 14, "field": {"name": "Iteration", "dataType": "ITERATION"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_2",
+		"type": "PULL_REQUEST",
+		"content": {
+			"number": 2,
+			"title": "Add a feature",
+			"state": "MERGED",
+			"updatedAt": "2022-07-30T00:00:00Z"
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+				{"number": 8, "field": {"name": "Estimate", "dataType": "NUMBER"}},
+				{"title": "Iteration 1", "startDate": "2022-07-11", "duration": 14, "field": {"name": "Iteration", "dataType": "ITERATION"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_3",
+		"type": "DRAFT_ISSUE",
+		"isArchived": true,
+		"content": {
+			"title": "Write docs"
+		},
+		"fieldValues": {
+			"nodes": [
+				{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+			]
+		}
+	}
+]`
+
+func items(t *testing.T) []models.ProjectItem {
+	var items []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(itemsJSON), &items))
+	return items
+}
+
+func TestFilter_Match(t *testing.T) {
+	env := Env{
+		Viewer: "heaths",
+		Now:    time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{filter: "", want: []string{"PNI_1", "PNI_2", "PNI_3"}},
+		{filter: "status:Todo", want: []string{"PNI_3"}},
+		{filter: `status:Todo,"in progress"`, want: []string{"PNI_1", "PNI_3"}},
+		{filter: "-status:Done", want: []string{"PNI_1", "PNI_3"}},
+		{filter: "is:open is:issue", want: []string{"PNI_1"}},
+		{filter: "is:pr", want: []string{"PNI_2"}},
+		{filter: "is:draft", want: []string{"PNI_3"}},
+		{filter: "is:archived", want: []string{"PNI_3"}},
+		{filter: "-is:archived", want: []string{"PNI_1", "PNI_2"}},
+		{filter: "assignee:@me", want: []string{"PNI_1"}},
+		{filter: "no:assignee", want: []string{"PNI_2", "PNI_3"}},
+		{filter: "has:estimate", want: []string{"PNI_1", "PNI_2"}},
+		{filter: "label:bug", want: []string{"PNI_1"}},
+		{filter: "estimate:>5", want: []string{"PNI_2"}},
+		{filter: "estimate:1..3", want: []string{"PNI_1"}},
+		{filter: "iteration:@current", want: []string{"PNI_1"}},
+		{filter: "iteration:@previous", want: []string{"PNI_2"}},
+		{filter: `iteration:"Iteration 1"`, want: []string{"PNI_2"}},
+		{filter: "updated:<@today-7d", want: []string{"PNI_1"}},
+		{filter: "updated:>=2022-07-30", want: []string{"PNI_2"}},
+		{filter: "status:in*", want: []string{"PNI_1"}},
+		{filter: "docs", want: []string{"PNI_3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filter, func(t *testing.T) {
+			f, err := Parse(tt.filter)
+			require.NoError(t, err)
+
+			var got []string
+			for _, item := range items(t) {
+				if f.Match(item, env) {
+					got = append(got, item.ID)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	f, err := Parse(`-status:Todo,"In Progress"  label:bug`)
+	require.NoError(t, err)
+	assert.Equal(t, `-status:Todo,"In Progress" label:bug`, f.String())
+
+	_, err = Parse(`status:"Todo`)
+	assert.EqualError(t, err, `unterminated quote in filter: status:"Todo`)
+
+	_, err = Parse(`status:`)
+	assert.EqualError(t, err, `missing value for "status" in filter`)
+}
+
+func TestFilter_And(t *testing.T) {
+	f, err := Parse("is:open")
+	require.NoError(t, err)
+
+	f.And("Status", "Todo", "In Progress")
+	assert.Equal(t, `is:open status:Todo,"In Progress"`, f.String())
+
+	var got []string
+	for _, item := range items(t) {
+		if f.Match(item, Env{}) {
+			got = append(got, item.ID)
+		}
+	}
+	assert.Equal(t, []string{"PNI_1"}, got)
+}
+
+func TestSort(t *testing.T) {
+	status := models.ProjectField{Name: "Status"}
+	for _, name := range []string{"Todo", "In Progress", "Done"} {
+		status.Options = append(status.Options, struct {
+			ID   string
+			Name string
+		}{Name: name})
+	}
+
+	tests := []struct {
+		name   string
+		sortBy []models.ProjectViewSortBy
+		want   []string
+	}{
+		{
+			name: "unsorted",
+			want: []string{"PNI_1", "PNI_2", "PNI_3"},
+		},
+		{
+			name:   "single select by option position",
+			sortBy: []models.ProjectViewSortBy{{Direction: "ASC", Field: status}},
+			want:   []string{"PNI_3", "PNI_1", "PNI_2"},
+		},
+		{
+			name:   "number descending",
+			sortBy: []models.ProjectViewSortBy{{Direction: "DESC", Field: models.ProjectField{Name: "Estimate"}}},
+			want:   []string{"PNI_2", "PNI_1", "PNI_3"},
+		},
+		{
+			name:   "empty values last",
+			sortBy: []models.ProjectViewSortBy{{Direction: "ASC", Field: models.ProjectField{Name: "Iteration"}}},
+			want:   []string{"PNI_2", "PNI_1", "PNI_3"},
+		},
+		{
+			name:   "title",
+			sortBy: []models.ProjectViewSortBy{{Direction: "ASC", Field: models.ProjectField{Name: "Title"}}},
+			want:   []string{"PNI_2", "PNI_1", "PNI_3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := items(t)
+			Sort(items, tt.sortBy)
+
+			got := make([]string, len(items))
+			for i, item := range items {
+				got[i] = item.ID
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Group is items grouped by a field value.
+type Group struct {
+	Name  string
+	Items []models.ProjectItem
+}
+
+// GroupBy groups items by each single select option or iteration in the order defined.
+// Items without a value are grouped first, and groups for values no longer defined e.g.,
+// completed iterations are added last.
+func GroupBy(items []models.ProjectItem, field *models.ProjectField) ([]Group, error) {
+	var names []string
+	switch field.DataType {
+	case "SINGLE_SELECT":
+		for _, option := range field.Options {
+			names = append(names, option.Name)
+		}
+	case "ITERATION":
+		for _, iteration := range field.Configuration.Iterations {
+			names = append(names, iteration.Name)
+		}
+	default:
+		return nil, fmt.Errorf("field %q cannot be used to group items", field.Name)
+	}
+
+	noValue := Group{Name: "No " + field.Name}
+	groups := make([]Group, len(names))
+	for i, name := range names {
+		groups[i].Name = name
+	}
+
+	for _, item := range items {
+		value, ok := item.FieldValue(field.Name)
+		name := value.String()
+		if !ok || name == "" {
+			noValue.Items = append(noValue.Items, item)
+			continue
+		}
+
+		i := indexOfFold(names, name)
+		if i < 0 {
+			names = append(names, name)
+			groups = append(groups, Group{Name: name})
+			i = len(groups) - 1
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+
+	if len(noValue.Items) > 0 {
+		groups = append([]Group{noValue}, groups...)
+	}
+
+	return groups, nil
+}
+
+func indexOfFold(values []string, value string) int {
+	for i, v := range values {
+		if strings.EqualFold(v, value) {
+			return i
+		}
+	}
+	return -1
+}
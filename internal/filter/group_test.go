@@ -0,0 +1,36 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupBy(t *testing.T) {
+	field := &models.ProjectField{Name: "Status", DataType: "SINGLE_SELECT"}
+	field.Options = append(field.Options, struct {
+		ID   string
+		Name string
+	}{Name: "Todo"})
+
+	var items []models.ProjectItem
+	items = append(items, models.ProjectItem{ID: "PNI_1"}, models.ProjectItem{ID: "PNI_2"})
+	items[1].FieldValues.Nodes = append(items[1].FieldValues.Nodes, models.ProjectItemFieldValue{Name: "Archived"})
+	items[1].FieldValues.Nodes[0].Field.Name = "Status"
+	items[1].FieldValues.Nodes[0].Field.DataType = "SINGLE_SELECT"
+
+	groups, err := GroupBy(items, field)
+	assert.NoError(t, err)
+
+	names := make([]string, len(groups))
+	for i, group := range groups {
+		names[i] = group.Name
+	}
+	assert.Equal(t, []string{"No Status", "Todo", "Archived"}, names)
+	assert.Len(t, groups[0].Items, 1)
+	assert.Empty(t, groups[1].Items)
+
+	_, err = GroupBy(items, &models.ProjectField{Name: "Estimate", DataType: "NUMBER"})
+	assert.EqualError(t, err, `field "Estimate" cannot be used to group items`)
+}
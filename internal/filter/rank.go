@@ -0,0 +1,69 @@
+package filter
+
+import "sort"
+
+// Move positions an item after another item, or first if AfterID is empty.
+type Move struct {
+	ItemID  string
+	AfterID string
+}
+
+// Moves gets the fewest moves to reorder item IDs from current to target order.
+// Items in the longest subsequence already in target order are not moved, and
+// moves are returned in target order so each item is moved after one in place.
+func Moves(current, target []string) []Move {
+	positions := make(map[string]int, len(current))
+	for i, id := range current {
+		positions[id] = i
+	}
+
+	keep := longestIncreasing(target, positions)
+
+	var moves []Move
+	for i, id := range target {
+		if keep[id] {
+			continue
+		}
+
+		move := Move{ItemID: id}
+		if i > 0 {
+			move.AfterID = target[i-1]
+		}
+		moves = append(moves, move)
+	}
+
+	return moves
+}
+
+// longestIncreasing gets the IDs in the longest subsequence of target with increasing current positions.
+func longestIncreasing(target []string, positions map[string]int) map[string]bool {
+	// tails[k] is the index into target of the smallest tail of an increasing subsequence of length k+1.
+	var tails []int
+	prev := make([]int, len(target))
+	for i, id := range target {
+		pos := positions[id]
+		k := sort.Search(len(tails), func(k int) bool {
+			return positions[target[tails[k]]] >= pos
+		})
+
+		prev[i] = -1
+		if k > 0 {
+			prev[i] = tails[k-1]
+		}
+
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	keep := make(map[string]bool, len(tails))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			keep[target[i]] = true
+		}
+	}
+
+	return keep
+}
@@ -0,0 +1,120 @@
+package filter
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoves(t *testing.T) {
+	tests := []struct {
+		name    string
+		current []string
+		target  []string
+		want    []Move
+	}{
+		{
+			name:    "unchanged",
+			current: []string{"a", "b", "c"},
+			target:  []string{"a", "b", "c"},
+		},
+		{
+			name:    "move to top",
+			current: []string{"a", "b", "c"},
+			target:  []string{"c", "a", "b"},
+			want:    []Move{{ItemID: "c"}},
+		},
+		{
+			name:    "move after",
+			current: []string{"a", "b", "c", "d"},
+			target:  []string{"a", "c", "d", "b"},
+			want:    []Move{{ItemID: "b", AfterID: "d"}},
+		},
+		{
+			name:    "reversed",
+			current: []string{"a", "b", "c"},
+			target:  []string{"c", "b", "a"},
+			want:    []Move{{ItemID: "c"}, {ItemID: "b", AfterID: "c"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moves := Moves(tt.current, tt.target)
+			assert.Equal(t, tt.want, moves)
+			assert.Equal(t, tt.target, applyMoves(tt.current, moves))
+		})
+	}
+}
+
+func TestMoves_random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 1; n < 100; n++ {
+		current := make([]string, n)
+		for i := range current {
+			current[i] = strconv.Itoa(i)
+		}
+
+		target := make([]string, n)
+		for i, j := range r.Perm(n) {
+			target[i] = current[j]
+		}
+
+		moves := Moves(current, target)
+		assert.Equal(t, target, applyMoves(current, moves), "n=%d", n)
+		assert.Equal(t, n-lisLength(current, target), len(moves), "n=%d", n)
+	}
+}
+
+// applyMoves simulates moving items like updateProjectV2ItemPosition.
+func applyMoves(items []string, moves []Move) []string {
+	items = append([]string(nil), items...)
+	for _, move := range moves {
+		for i, id := range items {
+			if id == move.ItemID {
+				items = append(items[:i], items[i+1:]...)
+				break
+			}
+		}
+
+		at := 0
+		for i, id := range items {
+			if id == move.AfterID {
+				at = i + 1
+				break
+			}
+		}
+
+		items = append(items[:at], append([]string{move.ItemID}, items[at:]...)...)
+	}
+
+	if len(items) == 0 {
+		return nil
+	}
+	return items
+}
+
+// lisLength gets the length of the longest subsequence of target in current order using dynamic programming.
+func lisLength(current, target []string) int {
+	positions := make(map[string]int, len(current))
+	for i, id := range current {
+		positions[id] = i
+	}
+
+	lengths := make([]int, len(target))
+	longest := 0
+	for i := range target {
+		lengths[i] = 1
+		for j := 0; j < i; j++ {
+			if positions[target[j]] < positions[target[i]] && lengths[j]+1 > lengths[i] {
+				lengths[i] = lengths[j] + 1
+			}
+		}
+		if lengths[i] > longest {
+			longest = lengths[i]
+		}
+	}
+	return longest
+}
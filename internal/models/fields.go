@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -68,13 +69,69 @@ type ProjectField struct {
 	Name          string
 	DataType      string
 	Configuration struct {
-		Iterations []struct {
-			ID   string
-			Name string
-		}
+		Iterations          []ProjectFieldIteration
+		CompletedIterations []ProjectFieldIteration
 	}
 	Options []struct {
 		ID   string
 		Name string
 	}
 }
+
+type ProjectFieldIteration struct {
+	ID        string
+	Name      string
+	StartDate string
+	Duration  int
+}
+
+// Dates gets the start date and the exclusive end date of the iteration.
+func (i ProjectFieldIteration) Dates() (start, end time.Time, ok bool) {
+	start, err := time.Parse("2006-01-02", i.StartDate)
+	if err != nil {
+		return
+	}
+
+	return start, start.AddDate(0, 0, i.Duration), true
+}
+
+// FindIteration finds an iteration by case-insensitive name, or "@current", "@next", or "@previous" relative to now.
+func (f ProjectField) FindIteration(name string, now time.Time) (*ProjectFieldIteration, error) {
+	iterations := make([]ProjectFieldIteration, 0, len(f.Configuration.CompletedIterations)+len(f.Configuration.Iterations))
+	iterations = append(iterations, f.Configuration.CompletedIterations...)
+	iterations = append(iterations, f.Configuration.Iterations...)
+	sort.SliceStable(iterations, func(i, j int) bool {
+		return iterations[i].StartDate < iterations[j].StartDate
+	})
+
+	current := -1
+	for i, iter := range iterations {
+		if strings.EqualFold(name, iter.Name) {
+			return &iterations[i], nil
+		}
+
+		if start, end, ok := iter.Dates(); ok && !now.Before(start) && now.Before(end) {
+			current = i
+		}
+	}
+
+	index := -1
+	switch strings.ToLower(name) {
+	case "@current":
+		index = current
+	case "@next":
+		if current >= 0 {
+			index = current + 1
+		}
+	case "@previous":
+		if current >= 0 {
+			index = current - 1
+		}
+	}
+
+	if index < 0 || index >= len(iterations) {
+		return nil, fmt.Errorf("iteration not defined for field %q: %v", f.Name, name)
+	}
+
+	return &iterations[index], nil
+}
@@ -1,11 +1,8 @@
 package models
 
-type RepositoryIssueOrPullRequest struct {
-	Repository issueOrPullRequestNode
-}
-
-type issueOrPullRequestNode struct {
-	IssueOrPullRequest IssueOrPullRequest
+// RepositoryIssuesOrPullRequests contains aliased issues or pull requests from a single query.
+type RepositoryIssuesOrPullRequests struct {
+	Repository map[string]*IssueOrPullRequest
 }
 
 type IssueOrPullRequest struct {
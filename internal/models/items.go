@@ -0,0 +1,134 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type projectItemFieldValueNode struct {
+	Nodes []ProjectItemFieldValue
+}
+
+// ProjectItemFieldValue is the value of any field type. Only the properties for the field's data type are set.
+type ProjectItemFieldValue struct {
+	Field struct {
+		Name     string
+		DataType string
+	}
+
+	Text      string
+	Number    *float64
+	Date      string
+	Name      string
+	Title     string
+	StartDate string
+	Duration  int
+	Labels    nameNode
+	Users     loginNode
+	Milestone *struct {
+		Title string
+	}
+	Repository *struct {
+		NameWithOwner string
+	}
+}
+
+// String gets the value to display.
+func (v ProjectItemFieldValue) String() string {
+	switch v.Field.DataType {
+	case "NUMBER":
+		if v.Number != nil {
+			return strconv.FormatFloat(*v.Number, 'f', -1, 64)
+		}
+	case "DATE":
+		return v.Date
+	case "SINGLE_SELECT":
+		return v.Name
+	case "ITERATION":
+		return v.Title
+	case "LABELS":
+		return strings.Join(v.Labels.Names(), ", ")
+	case "ASSIGNEES":
+		return strings.Join(v.Users.Logins(), ", ")
+	case "MILESTONE":
+		if v.Milestone != nil {
+			return v.Milestone.Title
+		}
+	case "REPOSITORY":
+		if v.Repository != nil {
+			return v.Repository.NameWithOwner
+		}
+	default:
+		return v.Text
+	}
+
+	return ""
+}
+
+// Values gets all values to match e.g., each label or assignee.
+func (v ProjectItemFieldValue) Values() []string {
+	switch v.Field.DataType {
+	case "LABELS":
+		return v.Labels.Names()
+	case "ASSIGNEES":
+		return v.Users.Logins()
+	}
+
+	if s := v.String(); s != "" {
+		return []string{s}
+	}
+
+	return nil
+}
+
+// Iteration gets the start and end of an iteration value.
+func (v ProjectItemFieldValue) Iteration() (start, end time.Time, ok bool) {
+	start, err := time.Parse("2006-01-02", v.StartDate)
+	if err != nil {
+		return
+	}
+
+	return start, start.AddDate(0, 0, v.Duration), true
+}
+
+// FieldValue gets the value of a field by case-insensitive name.
+func (i ProjectItem) FieldValue(name string) (ProjectItemFieldValue, bool) {
+	for _, value := range i.FieldValues.Nodes {
+		if strings.EqualFold(value.Field.Name, name) {
+			return value, true
+		}
+	}
+
+	return ProjectItemFieldValue{}, false
+}
+
+type nameNode struct {
+	Nodes []struct {
+		Name string
+	}
+}
+
+// Names gets all names.
+func (n nameNode) Names() []string {
+	names := make([]string, len(n.Nodes))
+	for i, node := range n.Nodes {
+		names[i] = node.Name
+	}
+	return names
+}
+
+type loginNode struct {
+	Nodes []struct {
+		Login string
+	}
+}
+
+// Logins gets all logins.
+func (n loginNode) Logins() []string {
+	logins := make([]string, len(n.Nodes))
+	for i, node := range n.Nodes {
+		logins[i] = node.Login
+	}
+	return logins
+}
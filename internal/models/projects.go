@@ -20,17 +20,27 @@ type projectItemNode struct {
 	PageInfo   pageInfo
 }
 type ProjectItem struct {
-	ID      string
-	Type    string
-	Content projectItemContent
+	ID          string
+	Type        string
+	IsArchived  bool
+	Content     projectItemContent
+	FieldValues projectItemFieldValueNode
 }
 
 type projectItemContent struct {
-	ID        string
-	Number    int
-	Title     string
-	CreatedAt *time.Time
-	State     string
+	ID         string
+	Number     int
+	Title      string
+	URL        string
+	CreatedAt  *time.Time
+	UpdatedAt  *time.Time
+	ClosedAt   *time.Time
+	State      string
+	Assignees  loginNode
+	Labels     nameNode
+	Repository *struct {
+		NameWithOwner string
+	}
 }
 
 type RepositoryProjects struct {
@@ -73,5 +83,5 @@ type pageInfo struct {
 }
 
 type actor struct {
-	Login string
+	Login string `json:"login"`
 }
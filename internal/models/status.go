@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+type ProjectStatusUpdate struct {
+	ID         string     `json:"id"`
+	Status     string     `json:"status"`
+	Body       string     `json:"body"`
+	StartDate  string     `json:"startDate,omitempty"`
+	TargetDate string     `json:"targetDate,omitempty"`
+	Creator    actor      `json:"creator"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+type ProjectStatusUpdates struct {
+	ID            string
+	Title         string
+	URL           string
+	StatusUpdates struct {
+		TotalCount int
+		Nodes      []ProjectStatusUpdate
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ProjectView struct {
+	ID                    string
+	Number                int
+	Name                  string
+	Layout                string
+	Filter                string
+	GroupByFields         projectFieldNode
+	VerticalGroupByFields projectFieldNode
+	SortByFields          struct {
+		Nodes []ProjectViewSortBy
+	}
+	Fields projectFieldNode
+}
+
+// LayoutName gets the layout without the "_LAYOUT" suffix e.g., "BOARD".
+func (v ProjectView) LayoutName() string {
+	return strings.TrimSuffix(v.Layout, "_LAYOUT")
+}
+
+// GroupBy gets the names of fields by which items are grouped, including board columns.
+func (v ProjectView) GroupBy() []string {
+	return append(v.VerticalGroupByFields.Names(), v.GroupByFields.Names()...)
+}
+
+// SortBy gets the field names and directions by which items are sorted e.g., "Status (desc)".
+func (v ProjectView) SortBy() []string {
+	sortBy := make([]string, len(v.SortByFields.Nodes))
+	for i, s := range v.SortByFields.Nodes {
+		sortBy[i] = fmt.Sprintf("%s (%s)", s.Field.Name, strings.ToLower(s.Direction))
+	}
+	return sortBy
+}
+
+type ProjectViewSortBy struct {
+	Direction string
+	Field     ProjectField
+}
+
+type projectFieldNode struct {
+	Nodes []ProjectField
+}
+
+// Names gets the names of all fields.
+func (n projectFieldNode) Names() []string {
+	names := make([]string, len(n.Nodes))
+	for i, field := range n.Nodes {
+		names[i] = field.Name
+	}
+	return names
+}
+
+type ProjectViewsNode struct {
+	Nodes    []ProjectView
+	PageInfo pageInfo
+}
@@ -0,0 +1,199 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/heaths/go-console"
+)
+
+// Prompter asks questions on a console and reads answers from a line of input.
+type Prompter struct {
+	con console.Console
+	r   *bufio.Reader
+}
+
+// New creates a Prompter for the console.
+func New(con console.Console) *Prompter {
+	return &Prompter{
+		con: con,
+		r:   bufio.NewReader(con.Stdin()),
+	}
+}
+
+// Input asks for a line of text. If no text is entered, defaultValue is returned.
+func (p *Prompter) Input(message, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		p.ask(fmt.Sprintf("%s %s", message, p.dim("("+defaultValue+")")))
+	} else {
+		p.ask(message)
+	}
+
+	line, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	if line == "" {
+		return defaultValue, nil
+	}
+
+	return line, nil
+}
+
+// Confirm asks a yes or no question. If no answer is entered, defaultValue is returned.
+func (p *Prompter) Confirm(message string, defaultValue bool) (bool, error) {
+	hint := "(y/N)"
+	if defaultValue {
+		hint = "(Y/n)"
+	}
+
+	for {
+		p.ask(fmt.Sprintf("%s %s", message, p.dim(hint)))
+
+		line, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(line) {
+		case "":
+			return defaultValue, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+
+		p.invalid("enter y or n")
+	}
+}
+
+// Select asks to choose one of the options and returns its index.
+// If no option is entered, defaultIndex is returned unless it is less than 0.
+func (p *Prompter) Select(message string, options []string, defaultIndex int) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("no options to select")
+	}
+
+	for {
+		p.ask(message)
+		p.options(options, defaultIndex)
+		fmt.Fprintf(p.con.Stdout(), "  %s ", p.dim("Enter a number:"))
+
+		line, err := p.readLine()
+		if err != nil {
+			return -1, err
+		}
+
+		if line == "" && defaultIndex >= 0 {
+			return defaultIndex, nil
+		}
+
+		if i, err := strconv.Atoi(line); err == nil && i > 0 && i <= len(options) {
+			return i - 1, nil
+		}
+
+		p.invalid(fmt.Sprintf("enter a number between 1 and %d", len(options)))
+	}
+}
+
+// MultiSelect asks to choose any number of options and returns their indices in the order entered.
+// Options are separated by commas or spaces, and ranges like "1-3" are supported.
+func (p *Prompter) MultiSelect(message string, options []string) ([]int, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	for {
+		p.ask(message)
+		p.options(options, -1)
+		fmt.Fprintf(p.con.Stdout(), "  %s ", p.dim("Enter numbers separated by commas, or nothing to skip:"))
+
+		line, err := p.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		indices, err := parseIndices(line, len(options))
+		if err == nil {
+			return indices, nil
+		}
+
+		p.invalid(err.Error())
+	}
+}
+
+func (p *Prompter) ask(message string) {
+	cs := p.con.ColorScheme()
+	fmt.Fprintf(p.con.Stdout(), "%s %s ", cs.Green("?"), cs.ColorFunc("white+b")(message))
+}
+
+func (p *Prompter) options(options []string, defaultIndex int) {
+	fmt.Fprintln(p.con.Stdout())
+	for i, option := range options {
+		marker := " "
+		if i == defaultIndex {
+			marker = p.con.ColorScheme().Cyan(">")
+		}
+		fmt.Fprintf(p.con.Stdout(), "%s %2d. %s\n", marker, i+1, option)
+	}
+}
+
+func (p *Prompter) invalid(message string) {
+	fmt.Fprintf(p.con.Stdout(), "%s %s\n", p.con.ColorScheme().Red("X"), message)
+}
+
+func (p *Prompter) dim(s string) string {
+	return p.con.ColorScheme().ColorFunc("white+d")(s)
+}
+
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+func parseIndices(line string, count int) ([]int, error) {
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	seen := make(map[int]bool, len(fields))
+	indices := make([]int, 0, len(fields))
+	for _, field := range fields {
+		from, to := field, field
+		if i := strings.Index(field, "-"); i > 0 {
+			from, to = field[:i], field[i+1:]
+		}
+
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %s", field)
+		}
+		end, err := strconv.Atoi(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %s", field)
+		}
+
+		if start < 1 || end > count || start > end {
+			return nil, fmt.Errorf("enter numbers between 1 and %d", count)
+		}
+
+		for i := start; i <= end; i++ {
+			if !seen[i] {
+				seen[i] = true
+				indices = append(indices, i-1)
+			}
+		}
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,126 @@
+package prompt
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/heaths/go-console"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrompter_Input(t *testing.T) {
+	p, stdout := newTestPrompter("\nnew title\n")
+
+	got, err := p.Input("Title", "old title")
+	assert.NoError(t, err)
+	assert.Equal(t, "old title", got)
+
+	got, err = p.Input("Title", "old title")
+	assert.NoError(t, err)
+	assert.Equal(t, "new title", got)
+
+	_, err = p.Input("Title", "")
+	assert.ErrorIs(t, err, io.EOF)
+
+	assert.Equal(t, "? Title (old title) ? Title (old title) ? Title ", stdout.String())
+}
+
+func TestPrompter_Confirm(t *testing.T) {
+	p, stdout := newTestPrompter("\nmaybe\nn\n")
+
+	got, err := p.Confirm("Continue?", true)
+	assert.NoError(t, err)
+	assert.True(t, got)
+
+	got, err = p.Confirm("Continue?", true)
+	assert.NoError(t, err)
+	assert.False(t, got)
+
+	assert.Contains(t, stdout.String(), "X enter y or n\n")
+}
+
+func TestPrompter_Select(t *testing.T) {
+	p, stdout := newTestPrompter("\n4\n3\n")
+
+	got, err := p.Select("Status", []string{"Todo", "In Progress", "Done"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got)
+
+	got, err = p.Select("Status", []string{"Todo", "In Progress", "Done"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, got)
+
+	assert.Contains(t, stdout.String(), ">  1. Todo\n   2. In Progress\n")
+	assert.Contains(t, stdout.String(), "X enter a number between 1 and 3\n")
+}
+
+func TestPrompter_MultiSelect(t *testing.T) {
+	p, _ := newTestPrompter("\n3,1\n")
+
+	got, err := p.MultiSelect("Issues", []string{"#1", "#2", "#3"})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = p.MultiSelect("Issues", []string{"#1", "#2", "#3"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 0}, got)
+}
+
+func TestParseIndices(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    []int
+		wantErr string
+	}{
+		{
+			name: "empty",
+			want: []int{},
+		},
+		{
+			name: "commas and spaces",
+			line: "1, 3 2",
+			want: []int{0, 2, 1},
+		},
+		{
+			name: "range",
+			line: "2-4,1",
+			want: []int{1, 2, 3, 0},
+		},
+		{
+			name: "duplicates",
+			line: "1,1-2",
+			want: []int{0, 1},
+		},
+		{
+			name:    "out of range",
+			line:    "5",
+			wantErr: "enter numbers between 1 and 4",
+		},
+		{
+			name:    "invalid",
+			line:    "a",
+			wantErr: "invalid number: a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseIndices(tt.line, 4)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestPrompter(stdin string) (*Prompter, *bytes.Buffer) {
+	fake := console.Fake(console.WithStdin(bytes.NewBufferString(stdin)))
+	stdout, _, _ := fake.Buffers()
+	return New(fake), stdout
+}
@@ -0,0 +1,128 @@
+// Package report computes progress reports from project items.
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Burndown is the points remaining and completed on each day of an iteration.
+type Burndown struct {
+	Iteration string
+	Field     string
+	Start     time.Time
+	End       time.Time
+	Total     float64
+	Days      []Day
+}
+
+// Day is the points remaining and completed at the end of a day.
+type Day struct {
+	Date      time.Time
+	Remaining float64
+	Completed float64
+	Ideal     float64
+}
+
+// Options for computing a burndown.
+type Options struct {
+	// IterationField is the name of the iteration field.
+	IterationField string
+
+	// PointsField is the name of a number field for points. Items are counted if empty.
+	PointsField string
+
+	// Done is the status when work is completed.
+	Done string
+
+	// Now is the last day to compute.
+	Now time.Time
+}
+
+// NewBurndown computes the points remaining and completed on each day of an iteration through today.
+//
+// Items are completed when their issue or pull request was closed, or while their status last
+// changed to Done. Events are keyed by content ID and should be sorted by when they were created.
+func NewBurndown(items []models.ProjectItem, events map[string][]Event, iteration models.ProjectFieldIteration, opts Options) *Burndown {
+	start, end, _ := iteration.Dates()
+	b := &Burndown{
+		Iteration: iteration.Name,
+		Field:     opts.PointsField,
+		Start:     start,
+		End:       end,
+	}
+
+	type point struct {
+		value    float64
+		closedAt *time.Time
+		statuses []Event
+	}
+
+	var points []point
+	for _, item := range items {
+		value, ok := item.FieldValue(opts.IterationField)
+		if !ok || !strings.EqualFold(value.Title, iteration.Name) {
+			continue
+		}
+
+		p := point{value: 1, closedAt: item.Content.ClosedAt}
+		for _, event := range events[item.Content.ID] {
+			if event.Type == EventStatusChanged {
+				p.statuses = append(p.statuses, event)
+			}
+		}
+		if opts.PointsField != "" {
+			p.value = 0
+			if value, ok := item.FieldValue(opts.PointsField); ok && value.Number != nil {
+				p.value = *value.Number
+			}
+		}
+
+		b.Total += p.value
+		points = append(points, p)
+	}
+
+	days := iteration.Duration
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i)
+		if date.After(opts.Now) {
+			break
+		}
+
+		day := Day{
+			Date:      date,
+			Remaining: b.Total,
+			Ideal:     b.Total,
+		}
+		if days > 1 {
+			day.Ideal = b.Total * float64(days-1-i) / float64(days-1)
+		}
+
+		next := date.AddDate(0, 0, 1)
+		for _, p := range points {
+			if p.closedAt != nil && p.closedAt.Before(next) || doneBefore(p.statuses, next, opts.Done) {
+				day.Remaining -= p.value
+				day.Completed += p.value
+			}
+		}
+
+		b.Days = append(b.Days, day)
+	}
+
+	return b
+}
+
+// doneBefore gets whether the status last changed to done before t.
+func doneBefore(statuses []Event, t time.Time, done string) bool {
+	status := ""
+	for _, event := range statuses {
+		if !event.CreatedAt.Before(t) {
+			break
+		}
+		status = event.Status
+	}
+
+	return done != "" && strings.EqualFold(status, done)
+}
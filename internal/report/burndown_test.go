@@ -0,0 +1,158 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const itemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {"number": 1, "title": "Fix the parser", "state": "CLOSED", "closedAt": "2026-10-13T15:00:00Z"},
+		"fieldValues": {
+			"nodes": [
+				{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+				{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_2",
+		"type": "ISSUE",
+		"content": {"id": "I_2", "number": 2, "title": "Add a feature", "state": "OPEN"},
+		"fieldValues": {
+			"nodes": [
+				{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+				{"number": 5, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_3",
+		"type": "PULL_REQUEST",
+		"content": {"number": 3, "title": "Write docs", "state": "MERGED", "closedAt": "2026-10-15T09:00:00Z"},
+		"fieldValues": {
+			"nodes": [
+				{"title": "Iteration 2", "startDate": "2026-10-12", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}}
+			]
+		}
+	},
+	{
+		"id": "PNI_4",
+		"type": "ISSUE",
+		"content": {"number": 4, "title": "Plan the next release", "state": "OPEN"},
+		"fieldValues": {
+			"nodes": [
+				{"title": "Iteration 3", "startDate": "2026-10-19", "duration": 7, "field": {"name": "Iteration", "dataType": "ITERATION"}},
+				{"number": 8, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+			]
+		}
+	}
+]`
+
+func TestNewBurndown(t *testing.T) {
+	var items []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(itemsJSON), &items))
+
+	iteration := models.ProjectFieldIteration{ID: "2", Name: "Iteration 2", StartDate: "2026-10-12", Duration: 7}
+	date := func(day int) time.Time {
+		return time.Date(2026, 10, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		pointsField string
+		events      map[string][]Event
+		now         time.Time
+		wantTotal   float64
+		want        []Day
+	}{
+		{
+			name:        "points",
+			pointsField: "Estimate",
+			now:         time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+			wantTotal:   8,
+			want: []Day{
+				{Date: date(12), Remaining: 8, Completed: 0, Ideal: 8},
+				{Date: date(13), Remaining: 5, Completed: 3, Ideal: 8 * 5.0 / 6},
+				{Date: date(14), Remaining: 5, Completed: 3, Ideal: 8 * 4.0 / 6},
+				{Date: date(15), Remaining: 5, Completed: 3, Ideal: 8 * 3.0 / 6},
+			},
+		},
+		{
+			name:        "done but open",
+			pointsField: "Estimate",
+			events: map[string][]Event{
+				"I_2": {
+					{Type: EventAddedToProject, CreatedAt: time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)},
+					{Type: EventStatusChanged, Status: "In Progress", CreatedAt: time.Date(2026, 10, 12, 10, 0, 0, 0, time.UTC)},
+					{Type: EventStatusChanged, Status: "Done", CreatedAt: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+			now:       time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+			wantTotal: 8,
+			want: []Day{
+				{Date: date(12), Remaining: 8, Completed: 0, Ideal: 8},
+				{Date: date(13), Remaining: 5, Completed: 3, Ideal: 8 * 5.0 / 6},
+				{Date: date(14), Remaining: 0, Completed: 8, Ideal: 8 * 4.0 / 6},
+				{Date: date(15), Remaining: 0, Completed: 8, Ideal: 8 * 3.0 / 6},
+			},
+		},
+		{
+			name:        "done then reopened",
+			pointsField: "Estimate",
+			events: map[string][]Event{
+				"I_2": {
+					{Type: EventStatusChanged, Status: "Done", CreatedAt: time.Date(2026, 10, 13, 10, 0, 0, 0, time.UTC)},
+					{Type: EventStatusChanged, Status: "In Progress", CreatedAt: time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)},
+				},
+			},
+			now:       time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC),
+			wantTotal: 8,
+			want: []Day{
+				{Date: date(12), Remaining: 8, Completed: 0, Ideal: 8},
+				{Date: date(13), Remaining: 0, Completed: 8, Ideal: 8 * 5.0 / 6},
+				{Date: date(14), Remaining: 5, Completed: 3, Ideal: 8 * 4.0 / 6},
+				{Date: date(15), Remaining: 5, Completed: 3, Ideal: 8 * 3.0 / 6},
+			},
+		},
+		{
+			name:      "count",
+			now:       time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC),
+			wantTotal: 3,
+			want: []Day{
+				{Date: date(12), Remaining: 3, Completed: 0, Ideal: 3},
+				{Date: date(13), Remaining: 2, Completed: 1, Ideal: 2.5},
+				{Date: date(14), Remaining: 2, Completed: 1, Ideal: 2},
+			},
+		},
+		{
+			name:      "not started",
+			now:       time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantTotal: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBurndown(items, tt.events, iteration, Options{
+				IterationField: "iteration",
+				PointsField:    tt.pointsField,
+				Done:           "done",
+				Now:            tt.now,
+			})
+
+			assert.Equal(t, "Iteration 2", b.Iteration)
+			assert.Equal(t, date(12), b.Start)
+			assert.Equal(t, date(19), b.End)
+			assert.Equal(t, tt.wantTotal, b.Total)
+			assert.Equal(t, tt.want, b.Days)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+const (
+	// EventAddedToProject is the type of event when an issue or pull request is added to a project.
+	EventAddedToProject = "AddedToProjectV2Event"
+
+	// EventStatusChanged is the type of event when the status of an issue or pull request in a project changes.
+	EventStatusChanged = "ProjectV2ItemStatusChangedEvent"
+
+	day  = 24 * time.Hour
+	week = 7 * day
+)
+
+// Event is a timeline event of an issue or pull request in a project.
+type Event struct {
+	Type      string
+	Status    string
+	CreatedAt time.Time
+}
+
+// Flow is the throughput, lead time, and cycle time of items completed within a period.
+type Flow struct {
+	Since      time.Time
+	Until      time.Time
+	Items      []FlowItem
+	Throughput []Week
+	LeadTime   Stats
+	CycleTime  Stats
+	Histogram  []Bucket
+}
+
+// FlowItem is an item completed within a period.
+type FlowItem struct {
+	Number    int
+	Title     string
+	Created   *time.Time
+	Started   *time.Time
+	Done      time.Time
+	LeadTime  *time.Duration
+	CycleTime *time.Duration
+}
+
+// Week is the number of items completed in the week starting on Monday.
+type Week struct {
+	Start time.Time
+	Count int
+}
+
+// Stats are percentiles of durations.
+type Stats struct {
+	Count int
+	P50   time.Duration
+	P85   time.Duration
+	P95   time.Duration
+}
+
+// Bucket is the number of items with a cycle time less than Max, or any cycle time if Max is 0.
+type Bucket struct {
+	Label string
+	Max   time.Duration
+	Count int
+}
+
+// FlowOptions for computing flow.
+type FlowOptions struct {
+	// StatusField is the name of the single select status field.
+	StatusField string
+
+	// Todo is the status before work has started.
+	Todo string
+
+	// Done is the status when work is completed.
+	Done string
+
+	// Since is the start of the period.
+	Since time.Time
+
+	// Now is the end of the period.
+	Now time.Time
+}
+
+// NewFlow computes the flow of items completed within a period.
+//
+// Items are completed when their status last changed to Done or, if no status changes are
+// available, when their issue or pull request was closed. Cycle time starts when the status
+// first changed from Todo or, if no status changes are available, when the item was added to
+// the project. Events are keyed by content ID and should be sorted by when they were created.
+func NewFlow(items []models.ProjectItem, events map[string][]Event, opts FlowOptions) *Flow {
+	f := &Flow{
+		Since: opts.Since,
+		Until: opts.Now,
+	}
+
+	var leadTimes, cycleTimes []time.Duration
+	for _, item := range items {
+		fi, ok := newFlowItem(item, events[item.Content.ID], opts)
+		if !ok || fi.Done.Before(opts.Since) || fi.Done.After(opts.Now) {
+			continue
+		}
+
+		f.Items = append(f.Items, fi)
+		if fi.LeadTime != nil {
+			leadTimes = append(leadTimes, *fi.LeadTime)
+		}
+		if fi.CycleTime != nil {
+			cycleTimes = append(cycleTimes, *fi.CycleTime)
+		}
+	}
+
+	sort.SliceStable(f.Items, func(i, j int) bool {
+		return f.Items[i].Done.Before(f.Items[j].Done)
+	})
+
+	f.LeadTime = newStats(leadTimes)
+	f.CycleTime = newStats(cycleTimes)
+	f.Throughput = newThroughput(f.Items, opts.Since, opts.Now)
+	f.Histogram = newHistogram(cycleTimes)
+
+	return f
+}
+
+func newFlowItem(item models.ProjectItem, events []Event, opts FlowOptions) (FlowItem, bool) {
+	fi := FlowItem{
+		Number:  item.Content.Number,
+		Title:   item.Content.Title,
+		Created: item.Content.CreatedAt,
+	}
+
+	var done, started, added *time.Time
+	var statusChanged bool
+	for i, event := range events {
+		switch event.Type {
+		case EventAddedToProject:
+			if added == nil {
+				added = &events[i].CreatedAt
+			}
+		case EventStatusChanged:
+			statusChanged = true
+			if started == nil && event.Status != "" && !strings.EqualFold(event.Status, opts.Todo) {
+				started = &events[i].CreatedAt
+			}
+			if strings.EqualFold(event.Status, opts.Done) {
+				done = &events[i].CreatedAt
+			} else {
+				done = nil
+			}
+		}
+	}
+
+	if !statusChanged {
+		done = item.Content.ClosedAt
+		started = added
+	}
+	if done == nil {
+		return fi, false
+	}
+
+	fi.Done = *done
+	if started != nil && !started.After(fi.Done) {
+		fi.Started = started
+		d := fi.Done.Sub(*started)
+		fi.CycleTime = &d
+	}
+
+	// Lead time is from when the issue or pull request was created until it was closed.
+	closed := item.Content.ClosedAt
+	if closed == nil {
+		closed = &fi.Done
+	}
+	if fi.Created != nil && !fi.Created.After(*closed) {
+		d := closed.Sub(*fi.Created)
+		fi.LeadTime = &d
+	}
+
+	return fi, true
+}
+
+func newStats(durations []time.Duration) Stats {
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	return Stats{
+		Count: len(sorted),
+		P50:   percentile(sorted, 50),
+		P85:   percentile(sorted, 85),
+		P95:   percentile(sorted, 95),
+	}
+}
+
+// percentile gets the nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	i := int(math.Ceil(p/100*float64(len(sorted)))) - 1
+	if i < 0 {
+		i = 0
+	}
+	return sorted[i]
+}
+
+func newThroughput(items []FlowItem, since, now time.Time) []Week {
+	// Weeks start on Monday.
+	start := time.Date(since.Year(), since.Month(), since.Day(), 0, 0, 0, 0, time.UTC)
+	start = start.AddDate(0, 0, -(int(start.Weekday())+6)%7)
+
+	var weeks []Week
+	for w := start; !w.After(now); w = w.Add(week) {
+		weeks = append(weeks, Week{Start: w})
+	}
+
+	for _, item := range items {
+		if i := int(item.Done.Sub(start) / week); i >= 0 && i < len(weeks) {
+			weeks[i].Count++
+		}
+	}
+
+	return weeks
+}
+
+func newHistogram(durations []time.Duration) []Bucket {
+	buckets := []Bucket{
+		{Label: "< 1d", Max: day},
+		{Label: "1-2d", Max: 2 * day},
+		{Label: "2-4d", Max: 4 * day},
+		{Label: "4-7d", Max: week},
+		{Label: "1-2w", Max: 2 * week},
+		{Label: "2-4w", Max: 4 * week},
+		{Label: "4w+"},
+	}
+
+	for _, d := range durations {
+		for i := range buckets {
+			if buckets[i].Max == 0 || d < buckets[i].Max {
+				buckets[i].Count++
+				break
+			}
+		}
+	}
+
+	return buckets
+}
+
+// ParseSince parses a number of days or weeks before now e.g., "30d" or "4w", or a date e.g., "2022-08-01".
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if date, err := time.Parse("2006-01-02", value); err == nil {
+		return date, nil
+	}
+
+	if len(value) > 1 {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err == nil && n > 0 {
+			switch value[len(value)-1] {
+			case 'd':
+				return now.AddDate(0, 0, -n), nil
+			case 'w':
+				return now.AddDate(0, 0, -7*n), nil
+			}
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid number of days, weeks, or date: %s", value)
+}
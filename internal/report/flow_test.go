@@ -0,0 +1,138 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const flowItemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "CLOSED", "createdAt": "2026-09-20T00:00:00Z", "closedAt": "2026-09-25T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_2",
+		"type": "ISSUE",
+		"content": {"id": "I_2", "number": 2, "title": "Add a feature", "state": "CLOSED", "createdAt": "2026-10-01T00:00:00Z", "closedAt": "2026-10-08T12:00:00Z"},
+		"fieldValues": {"nodes": []}
+	},
+	{
+		"id": "PNI_3",
+		"type": "PULL_REQUEST",
+		"content": {"id": "PR_3", "number": 3, "title": "Write docs", "state": "OPEN", "createdAt": "2026-10-10T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_4",
+		"type": "ISSUE",
+		"content": {"id": "I_4", "number": 4, "title": "Old bug", "state": "CLOSED", "createdAt": "2026-08-01T00:00:00Z", "closedAt": "2026-09-01T00:00:00Z"},
+		"fieldValues": {"nodes": []}
+	},
+	{
+		"id": "PNI_5",
+		"type": "ISSUE",
+		"content": {"id": "I_5", "number": 5, "title": "Reopened", "state": "OPEN", "createdAt": "2026-10-01T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_6",
+		"type": "DRAFT_ISSUE",
+		"content": {"id": "DI_6", "title": "Someday", "createdAt": "2026-10-01T00:00:00Z"},
+		"fieldValues": {"nodes": []}
+	}
+]`
+
+func TestNewFlow(t *testing.T) {
+	var items []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(flowItemsJSON), &items))
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	events := map[string][]Event{
+		"I_1": {
+			{Type: EventAddedToProject, CreatedAt: date(9, 21)},
+			{Type: EventStatusChanged, Status: "In Progress", CreatedAt: date(9, 22)},
+			{Type: EventStatusChanged, Status: "Done", CreatedAt: date(9, 24)},
+		},
+		"PR_3": {
+			{Type: EventAddedToProject, CreatedAt: date(10, 10)},
+			{Type: EventStatusChanged, Status: "Todo", CreatedAt: date(10, 10)},
+			{Type: EventStatusChanged, Status: "In Progress", CreatedAt: date(10, 12)},
+			{Type: EventStatusChanged, Status: "Done", CreatedAt: date(10, 13)},
+		},
+		"I_5": {
+			{Type: EventStatusChanged, Status: "Done", CreatedAt: date(10, 2)},
+			{Type: EventStatusChanged, Status: "In Progress", CreatedAt: date(10, 3)},
+		},
+	}
+
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	f := NewFlow(items, events, FlowOptions{
+		StatusField: "Status",
+		Todo:        "Todo",
+		Done:        "done",
+		Since:       now.AddDate(0, 0, -30),
+		Now:         now,
+	})
+
+	numbers := make([]int, len(f.Items))
+	for i, item := range f.Items {
+		numbers[i] = item.Number
+	}
+	assert.Equal(t, []int{1, 2, 3}, numbers)
+	assert.Nil(t, f.Items[1].CycleTime)
+
+	assert.Equal(t, []Week{
+		{Start: date(9, 14), Count: 0},
+		{Start: date(9, 21), Count: 1},
+		{Start: date(9, 28), Count: 0},
+		{Start: date(10, 5), Count: 1},
+		{Start: date(10, 12), Count: 1},
+	}, f.Throughput)
+
+	assert.Equal(t, Stats{Count: 3, P50: 5 * day, P85: 7*day + 12*time.Hour, P95: 7*day + 12*time.Hour}, f.LeadTime)
+	assert.Equal(t, Stats{Count: 2, P50: day, P85: 2 * day, P95: 2 * day}, f.CycleTime)
+
+	counts := make([]int, len(f.Histogram))
+	for i, bucket := range f.Histogram {
+		counts[i] = bucket.Count
+	}
+	assert.Equal(t, []int{0, 1, 1, 0, 0, 0, 0}, counts)
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr string
+	}{
+		{value: "30d", want: time.Date(2026, 9, 15, 12, 0, 0, 0, time.UTC)},
+		{value: "2w", want: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)},
+		{value: "2026-09-01", want: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "30", wantErr: "invalid number of days, weeks, or date: 30"},
+		{value: "-1d", wantErr: "invalid number of days, weeks, or date: -1d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package report
+
+import (
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+const (
+	// ReasonInactive is when an issue or pull request has not been updated.
+	ReasonInactive = "no activity"
+
+	// ReasonStatusUnchanged is when the status of an item has not changed.
+	ReasonStatusUnchanged = "status unchanged"
+
+	// ReasonClosedNotDone is when an issue or pull request is closed or merged but the status is not done.
+	ReasonClosedNotDone = "closed but not done"
+)
+
+// StaleItem is an item that may need attention.
+type StaleItem struct {
+	Item    models.ProjectItem
+	Status  string
+	Reasons []string
+}
+
+// Closed gets whether the item is closed or merged but not done.
+func (s StaleItem) Closed() bool {
+	for _, reason := range s.Reasons {
+		if reason == ReasonClosedNotDone {
+			return true
+		}
+	}
+	return false
+}
+
+// StaleOptions for finding stale items.
+type StaleOptions struct {
+	// StatusField is the name of the single select status field.
+	StatusField string
+
+	// Status limits stale items to those with this status, if not empty.
+	Status string
+
+	// Done is the status when work is completed. Items with this status are never stale.
+	Done string
+
+	// Days is the number of days without activity before items are stale.
+	Days int
+
+	// Now is when stale items are found.
+	Now time.Time
+}
+
+// FindStale finds items that have had no activity or status change within a number of days,
+// or are closed or merged but not done. Events are keyed by content ID and should be sorted
+// by when they were created.
+func FindStale(items []models.ProjectItem, events map[string][]Event, opts StaleOptions) []StaleItem {
+	cutoff := opts.Now.AddDate(0, 0, -opts.Days)
+
+	var stale []StaleItem
+	for _, item := range items {
+		value, _ := item.FieldValue(opts.StatusField)
+		if strings.EqualFold(value.Name, opts.Done) {
+			continue
+		}
+		if opts.Status != "" && !strings.EqualFold(value.Name, opts.Status) {
+			continue
+		}
+
+		s := StaleItem{
+			Item:   item,
+			Status: value.Name,
+		}
+
+		switch item.Content.State {
+		case "CLOSED", "MERGED":
+			s.Reasons = append(s.Reasons, ReasonClosedNotDone)
+		default:
+			if updatedAt := item.Content.UpdatedAt; updatedAt != nil && updatedAt.Before(cutoff) {
+				s.Reasons = append(s.Reasons, ReasonInactive)
+			}
+
+			if changedAt, ok := lastStatusChange(events[item.Content.ID]); ok && changedAt.Before(cutoff) {
+				s.Reasons = append(s.Reasons, ReasonStatusUnchanged)
+			}
+		}
+
+		if len(s.Reasons) > 0 {
+			stale = append(stale, s)
+		}
+	}
+
+	return stale
+}
+
+// lastStatusChange gets when the status last changed or, if it never changed, when the item was added to the project.
+func lastStatusChange(events []Event) (changedAt time.Time, ok bool) {
+	for _, event := range events {
+		switch event.Type {
+		case EventStatusChanged:
+			changedAt, ok = event.CreatedAt, true
+		case EventAddedToProject:
+			if !ok {
+				changedAt, ok = event.CreatedAt, true
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const staleItemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN", "updatedAt": "2026-09-01T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_2",
+		"type": "ISSUE",
+		"content": {"id": "I_2", "number": 2, "title": "Add a feature", "state": "OPEN", "updatedAt": "2026-10-14T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_3",
+		"type": "PULL_REQUEST",
+		"content": {"id": "PR_3", "number": 3, "title": "Write docs", "state": "MERGED", "updatedAt": "2026-10-14T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_4",
+		"type": "ISSUE",
+		"content": {"id": "I_4", "number": 4, "title": "Old bug", "state": "CLOSED", "updatedAt": "2026-08-01T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	},
+	{
+		"id": "PNI_5",
+		"type": "ISSUE",
+		"content": {"id": "I_5", "number": 5, "title": "Recently moved", "state": "OPEN", "updatedAt": "2026-10-14T00:00:00Z"},
+		"fieldValues": {"nodes": [{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}]}
+	}
+]`
+
+func TestFindStale(t *testing.T) {
+	var items []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(staleItemsJSON), &items))
+
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	events := map[string][]Event{
+		"I_1": {
+			{Type: EventAddedToProject, CreatedAt: date(8, 20)},
+		},
+		"I_2": {
+			{Type: EventAddedToProject, CreatedAt: date(9, 1)},
+			{Type: EventStatusChanged, Status: "Todo", CreatedAt: date(9, 2)},
+		},
+		"I_5": {
+			{Type: EventAddedToProject, CreatedAt: date(9, 1)},
+			{Type: EventStatusChanged, Status: "In Progress", CreatedAt: date(10, 10)},
+		},
+	}
+
+	type result struct {
+		Number  int
+		Reasons []string
+	}
+
+	tests := []struct {
+		name   string
+		status string
+		want   []result
+	}{
+		{
+			name: "all",
+			want: []result{
+				{1, []string{ReasonInactive, ReasonStatusUnchanged}},
+				{2, []string{ReasonStatusUnchanged}},
+				{3, []string{ReasonClosedNotDone}},
+			},
+		},
+		{
+			name:   "status",
+			status: "in progress",
+			want: []result{
+				{1, []string{ReasonInactive, ReasonStatusUnchanged}},
+				{3, []string{ReasonClosedNotDone}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stale := FindStale(items, events, StaleOptions{
+				StatusField: "Status",
+				Status:      tt.status,
+				Done:        "Done",
+				Days:        14,
+				Now:         date(10, 15),
+			})
+
+			got := make([]result, len(stale))
+			for i, s := range stale {
+				got[i] = result{s.Item.Content.Number, s.Reasons}
+			}
+			assert.Equal(t, tt.want, got)
+			assert.True(t, stale[len(stale)-1].Closed())
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package template
+
+// Alias is a name for a project.
+type Alias struct {
+	Name    string
+	Project string
+}
+
+// Aliases renders a table of project aliases.
+func (t *Template) Aliases(aliases []Alias) error {
+	if _, err := t.t.New("aliases").Parse(`{{range .}}{{tablerow (bold .Name) .Project}}{{end}}{{tablerender}}`); err != nil {
+		return err
+	}
+
+	return t.t.ExecuteTemplate(t.w, "aliases", aliases)
+}
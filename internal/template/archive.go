@@ -0,0 +1,39 @@
+package template
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Archived renders a summary of archived items, which were not archived if dryRun is true.
+func (t *Template) Archived(items []models.ProjectItem, dryRun bool) error {
+	if len(items) == 0 {
+		fmt.Fprintln(t.w, "No items to archive")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	for _, item := range items {
+		number := ""
+		if item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", item.Content.Number)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", number, item.Content.Title)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	count := text.Pluralize(len(items), "item")
+	if dryRun {
+		fmt.Fprintf(t.w, "Would archive %s\n", count)
+	} else {
+		fmt.Fprintf(t.w, "Archived %s\n", count)
+	}
+
+	return nil
+}
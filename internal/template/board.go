@@ -0,0 +1,169 @@
+package template
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+const (
+	// The minimum width of a board column before columns wrap.
+	minColumnWidth = 24
+
+	// The number of spaces between board columns.
+	columnGap = 2
+)
+
+// BoardColumn is a column of items grouped by a field value.
+type BoardColumn struct {
+	Name  string
+	Items []models.ProjectItem
+
+	// TotalCount is the number of items in the column, which may be more than the items shown.
+	TotalCount int
+}
+
+// Board renders columns side by side to fit within width, or as grouped lists if stdout is not a terminal.
+func (t *Template) Board(title string, columns []BoardColumn, width int) error {
+	if !t.c.IsStdoutTTY() {
+		return t.boardLists(columns)
+	}
+
+	cs := t.c.ColorScheme()
+	if title != "" {
+		fmt.Fprintf(t.w, "%s\n\n", cs.ColorFunc("white+b")(title))
+	}
+
+	if len(columns) == 0 {
+		fmt.Fprintln(t.w, "No items")
+		return nil
+	}
+
+	// Wrap columns into rows of as many columns as fit within the width.
+	perRow := (width + columnGap) / (minColumnWidth + columnGap)
+	if perRow < 1 {
+		perRow = 1
+	}
+	if perRow > len(columns) {
+		perRow = len(columns)
+	}
+
+	columnWidth := (width+columnGap)/perRow - columnGap
+	if columnWidth < minColumnWidth {
+		columnWidth = minColumnWidth
+	}
+
+	for i := 0; i < len(columns); i += perRow {
+		if i > 0 {
+			fmt.Fprintln(t.w)
+		}
+
+		end := i + perRow
+		if end > len(columns) {
+			end = len(columns)
+		}
+
+		cells := make([][]string, end-i)
+		height := 0
+		for j, column := range columns[i:end] {
+			cells[j] = t.boardColumn(column, columnWidth)
+			if len(cells[j]) > height {
+				height = len(cells[j])
+			}
+		}
+
+		for line := 0; line < height; line++ {
+			var sb strings.Builder
+			for j, column := range cells {
+				cell := ""
+				if line < len(column) {
+					cell = column[line]
+				}
+
+				if j < len(cells)-1 {
+					sb.WriteString(Pad(cell, columnWidth+columnGap))
+				} else {
+					sb.WriteString(cell)
+				}
+			}
+			fmt.Fprintln(t.w, strings.TrimRight(sb.String(), " "))
+		}
+	}
+
+	return nil
+}
+
+// boardColumn gets the lines of a column truncated to width.
+func (t *Template) boardColumn(column BoardColumn, width int) []string {
+	cs := t.c.ColorScheme()
+	lines := []string{
+		Truncate(width, fmt.Sprintf("%s %s", cs.ColorFunc("white+b")(column.Name), cs.LightBlack(fmt.Sprintf("%d", column.TotalCount)))),
+		cs.LightBlack(strings.Repeat("─", width)),
+	}
+
+	for _, item := range column.Items {
+		title := item.Content.Title
+		if item.Content.Number != 0 {
+			title = fmt.Sprintf("%s %s", cs.Green(fmt.Sprintf("#%d", item.Content.Number)), title)
+		}
+		lines = append(lines, Truncate(width, title))
+
+		if assignees := item.Content.Assignees.Logins(); len(assignees) > 0 {
+			lines = append(lines, Truncate(width, cs.LightBlack("  @"+strings.Join(assignees, ", @"))))
+		}
+	}
+
+	if more := column.TotalCount - len(column.Items); more > 0 {
+		lines = append(lines, cs.LightBlack(fmt.Sprintf("+%d more", more)))
+	}
+
+	return lines
+}
+
+// boardLists renders each column as a list of tab-separated items.
+func (t *Template) boardLists(columns []BoardColumn) error {
+	for i, column := range columns {
+		if i > 0 {
+			fmt.Fprintln(t.w)
+		}
+
+		fmt.Fprintf(t.w, "%s (%s)\n", column.Name, text.Pluralize(column.TotalCount, "item"))
+
+		if err := writeItems(t.w, column.Items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeItems(w io.Writer, items []models.ProjectItem) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, item := range items {
+		number := ""
+		if item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", item.Content.Number)
+		}
+
+		// Avoid padding the last column when there are no assignees.
+		line := number + "\t" + item.Content.Title
+		if assignees := item.Content.Assignees.Logins(); len(assignees) > 0 {
+			line += "\t" + strings.Join(assignees, ", ")
+		}
+		fmt.Fprintln(tw, line)
+	}
+
+	return tw.Flush()
+}
+
+// Pad appends spaces to s to fill the display width.
+func Pad(s string, width int) string {
+	if w := DisplayWidth(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
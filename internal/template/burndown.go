@@ -0,0 +1,200 @@
+package template
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/heaths/gh-projects/internal/report"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// BurndownChart renders the points remaining, or completed for a burnup, as bars for each day.
+func (t *Template) BurndownChart(b *report.Burndown, burnup bool, width int) error {
+	cs := t.c.ColorScheme()
+
+	kind, points := "burndown", "items"
+	if burnup {
+		kind = "burnup"
+	}
+	if b.Field != "" {
+		points = b.Field
+	}
+
+	fmt.Fprintf(t.w, "%s %s\n", cs.ColorFunc("white+b")(fmt.Sprintf("%s %s", b.Iteration, kind)), cs.LightBlack("of "+points))
+	fmt.Fprintf(t.w, "%s to %s\n\n", b.Start.Format(dateLayout), b.End.AddDate(0, 0, -1).Format(dateLayout))
+
+	if len(b.Days) == 0 {
+		fmt.Fprintln(t.w, "Iteration has not started")
+		return nil
+	}
+
+	values := make([]float64, len(b.Days))
+	ideals := make([]float64, len(b.Days))
+	for i, day := range b.Days {
+		values[i], ideals[i] = day.Remaining, day.Ideal
+		if burnup {
+			values[i], ideals[i] = day.Completed, b.Total-day.Ideal
+		}
+	}
+
+	last := b.Days[len(b.Days)-1]
+	if burnup {
+		fmt.Fprintf(t.w, "%s of %s completed  %s\n\n", formatPoints(last.Completed), formatPoints(b.Total), cs.Cyan(sparkline(values, b.Total)))
+	} else {
+		fmt.Fprintf(t.w, "%s of %s remaining  %s\n\n", formatPoints(last.Remaining), formatPoints(b.Total), cs.Cyan(sparkline(values, b.Total)))
+	}
+
+	const labelWidth = 9
+	valueWidth := len(formatPoints(b.Total))
+	barWidth := width - labelWidth - valueWidth - 4
+	if barWidth < 10 {
+		barWidth = 10
+	}
+
+	column := func(value float64) int {
+		if b.Total <= 0 {
+			return 0
+		}
+		return int(math.Round(value / b.Total * float64(barWidth)))
+	}
+
+	for i, day := range b.Days {
+		bar, ideal := column(values[i]), column(ideals[i])
+
+		var sb strings.Builder
+		sb.WriteString(cs.Cyan(strings.Repeat("█", bar)))
+		for c := bar; c < barWidth; c++ {
+			if c == ideal {
+				sb.WriteString(cs.LightBlack("┆"))
+			} else {
+				sb.WriteString(" ")
+			}
+		}
+
+		fmt.Fprintf(t.w, "%s  %s  %*s\n", day.Date.Format("Mon 01-02"), sb.String(), valueWidth, formatPoints(values[i]))
+	}
+
+	return nil
+}
+
+// BurndownCSV renders the points remaining, completed, and ideally remaining for each day as CSV.
+func (t *Template) BurndownCSV(b *report.Burndown) error {
+	w := csv.NewWriter(t.w)
+	_ = w.Write([]string{"date", "remaining", "completed", "ideal"})
+	for _, day := range b.Days {
+		_ = w.Write([]string{
+			day.Date.Format(dateLayout),
+			formatPoints(day.Remaining),
+			formatPoints(day.Completed),
+			formatPoints(day.Ideal),
+		})
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// BurndownJSON renders the burndown as JSON.
+func (t *Template) BurndownJSON(b *report.Burndown) error {
+	type day struct {
+		Date      string  `json:"date"`
+		Remaining float64 `json:"remaining"`
+		Completed float64 `json:"completed"`
+		Ideal     float64 `json:"ideal"`
+	}
+
+	data := struct {
+		Iteration string  `json:"iteration"`
+		Field     string  `json:"field,omitempty"`
+		StartDate string  `json:"startDate"`
+		EndDate   string  `json:"endDate"`
+		Total     float64 `json:"total"`
+		Days      []day   `json:"days"`
+	}{
+		Iteration: b.Iteration,
+		Field:     b.Field,
+		StartDate: b.Start.Format(dateLayout),
+		EndDate:   b.End.AddDate(0, 0, -1).Format(dateLayout),
+		Total:     b.Total,
+		Days:      make([]day, len(b.Days)),
+	}
+
+	for i, d := range b.Days {
+		data.Days[i] = day{
+			Date:      d.Date.Format(dateLayout),
+			Remaining: d.Remaining,
+			Completed: d.Completed,
+			Ideal:     roundPoints(d.Ideal),
+		}
+	}
+
+	enc := json.NewEncoder(t.w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(data)
+}
+
+// BurndownMermaid renders the points remaining, or completed for a burnup, as a Mermaid xychart in a fenced code block.
+func (t *Template) BurndownMermaid(b *report.Burndown, burnup bool) error {
+	kind, points := "burndown", "Items"
+	if burnup {
+		kind = "burnup"
+	}
+	if b.Field != "" {
+		points = b.Field
+	}
+
+	dates := make([]string, len(b.Days))
+	values := make([]string, len(b.Days))
+	ideals := make([]string, len(b.Days))
+	for i, day := range b.Days {
+		dates[i] = strconv.Quote(day.Date.Format("01-02"))
+		if burnup {
+			values[i], ideals[i] = formatPoints(day.Completed), formatPoints(b.Total-day.Ideal)
+		} else {
+			values[i], ideals[i] = formatPoints(day.Remaining), formatPoints(day.Ideal)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```mermaid\nxychart-beta\n")
+	fmt.Fprintf(&sb, "    title %s\n", strconv.Quote(fmt.Sprintf("%s %s", b.Iteration, kind)))
+	fmt.Fprintf(&sb, "    x-axis [%s]\n", strings.Join(dates, ", "))
+	fmt.Fprintf(&sb, "    y-axis %s 0 --> %s\n", strconv.Quote(points), formatPoints(b.Total))
+	fmt.Fprintf(&sb, "    bar [%s]\n", strings.Join(values, ", "))
+	fmt.Fprintf(&sb, "    line [%s]\n", strings.Join(ideals, ", "))
+	sb.WriteString("```\n")
+
+	_, err := fmt.Fprint(t.w, sb.String())
+	return err
+}
+
+func sparkline(values []float64, max float64) string {
+	var sb strings.Builder
+	for _, value := range values {
+		i := 0
+		if max > 0 {
+			i = int(math.Round(value / max * float64(len(sparks)-1)))
+		}
+		if i < 0 {
+			i = 0
+		} else if i >= len(sparks) {
+			i = len(sparks) - 1
+		}
+		sb.WriteRune(sparks[i])
+	}
+	return sb.String()
+}
+
+func roundPoints(value float64) float64 {
+	return math.Round(value*10) / 10
+}
+
+func formatPoints(value float64) string {
+	return strconv.FormatFloat(roundPoints(value), 'f', -1, 64)
+}
@@ -0,0 +1,158 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/report"
+)
+
+// The maximum width of histogram bars.
+const maxHistogramWidth = 40
+
+// FlowTable renders the throughput per week, percentiles of lead and cycle times, and a histogram of cycle times.
+func (t *Template) FlowTable(f *report.Flow) error {
+	cs := t.c.ColorScheme()
+	fmt.Fprintf(t.w, "%s %s\n\n", cs.ColorFunc("white+b")("Flow"), cs.LightBlack(fmt.Sprintf("%s to %s", f.Since.Format(dateLayout), f.Until.Format(dateLayout))))
+
+	w := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "WEEK\tTHROUGHPUT")
+	for _, week := range f.Throughput {
+		fmt.Fprintf(w, "%s\t%d\n", week.Start.Format(dateLayout), week.Count)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(t.w)
+
+	w = tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\tITEMS\tP50\tP85\tP95")
+	for _, row := range []struct {
+		name  string
+		stats report.Stats
+	}{
+		{"Lead time", f.LeadTime},
+		{"Cycle time", f.CycleTime},
+	} {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", row.name, row.stats.Count, formatDays(row.stats.P50), formatDays(row.stats.P85), formatDays(row.stats.P95))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(t.w)
+
+	max := 0
+	for _, bucket := range f.Histogram {
+		if bucket.Count > max {
+			max = bucket.Count
+		}
+	}
+
+	w = tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CYCLE TIME\tITEMS")
+	for _, bucket := range f.Histogram {
+		// Avoid padding the last column when there is no bar.
+		line := fmt.Sprintf("%s\t%d", bucket.Label, bucket.Count)
+		if bucket.Count > 0 {
+			width := bucket.Count * maxHistogramWidth / max
+			if width == 0 {
+				width = 1
+			}
+			line += "\t" + cs.Cyan(strings.Repeat("█", width))
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	return w.Flush()
+}
+
+// FlowJSON renders the flow as JSON with durations in days.
+func (t *Template) FlowJSON(f *report.Flow) error {
+	type stats struct {
+		Count int     `json:"count"`
+		P50   float64 `json:"p50"`
+		P85   float64 `json:"p85"`
+		P95   float64 `json:"p95"`
+	}
+	type week struct {
+		Start string `json:"start"`
+		Count int    `json:"count"`
+	}
+	type bucket struct {
+		Label string `json:"label"`
+		Count int    `json:"count"`
+	}
+	type item struct {
+		Number    int      `json:"number,omitempty"`
+		Title     string   `json:"title"`
+		Done      string   `json:"done"`
+		LeadTime  *float64 `json:"leadTime,omitempty"`
+		CycleTime *float64 `json:"cycleTime,omitempty"`
+	}
+
+	newStats := func(s report.Stats) stats {
+		return stats{
+			Count: s.Count,
+			P50:   days(s.P50),
+			P85:   days(s.P85),
+			P95:   days(s.P95),
+		}
+	}
+	optionalDays := func(d *time.Duration) *float64 {
+		if d == nil {
+			return nil
+		}
+		v := days(*d)
+		return &v
+	}
+
+	data := struct {
+		Since      string   `json:"since"`
+		Until      string   `json:"until"`
+		Throughput []week   `json:"throughput"`
+		LeadTime   stats    `json:"leadTime"`
+		CycleTime  stats    `json:"cycleTime"`
+		Histogram  []bucket `json:"histogram"`
+		Items      []item   `json:"items"`
+	}{
+		Since:      f.Since.Format(dateLayout),
+		Until:      f.Until.Format(dateLayout),
+		Throughput: make([]week, len(f.Throughput)),
+		LeadTime:   newStats(f.LeadTime),
+		CycleTime:  newStats(f.CycleTime),
+		Histogram:  make([]bucket, len(f.Histogram)),
+		Items:      make([]item, len(f.Items)),
+	}
+
+	for i, w := range f.Throughput {
+		data.Throughput[i] = week{Start: w.Start.Format(dateLayout), Count: w.Count}
+	}
+	for i, b := range f.Histogram {
+		data.Histogram[i] = bucket{Label: b.Label, Count: b.Count}
+	}
+	for i, it := range f.Items {
+		data.Items[i] = item{
+			Number:    it.Number,
+			Title:     it.Title,
+			Done:      it.Done.Format(time.RFC3339),
+			LeadTime:  optionalDays(it.LeadTime),
+			CycleTime: optionalDays(it.CycleTime),
+		}
+	}
+
+	enc := json.NewEncoder(t.w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(data)
+}
+
+func days(d time.Duration) float64 {
+	return roundPoints(d.Hours() / 24)
+}
+
+func formatDays(d time.Duration) string {
+	return fmt.Sprintf("%.1fd", d.Hours()/24)
+}
@@ -0,0 +1,53 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Ranked renders items in their new order with values of fields, marking items that moved with "*".
+// Items were not moved if dryRun is true.
+func (t *Template) Ranked(items []models.ProjectItem, fields []string, moved map[string]bool, dryRun bool) error {
+	w := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	for i, item := range items {
+		marker := ""
+		if moved[item.ID] {
+			marker = "*"
+		}
+
+		number := ""
+		if item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", item.Content.Number)
+		}
+
+		values := make([]string, len(fields))
+		for j, name := range fields {
+			if value, ok := item.FieldValue(name); ok {
+				values[j] = value.String()
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", marker, i+1, number, item.Content.Title, strings.Join(values, "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(moved) == 0 {
+		fmt.Fprintln(t.w, "Items are already in order")
+		return nil
+	}
+
+	count := text.Pluralize(len(moved), "item")
+	if dryRun {
+		fmt.Fprintf(t.w, "Would move %s\n", count)
+	} else {
+		fmt.Fprintf(t.w, "Moved %s\n", count)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout = "2006-01-02"
+
+	// The maximum width of roadmap labels.
+	maxLabelWidth = 40
+
+	// The minimum width of the roadmap chart before labels are truncated.
+	minChartWidth = 20
+)
+
+// RoadmapGroup is a group of bars on a roadmap.
+type RoadmapGroup struct {
+	Name string
+	Bars []RoadmapBar
+}
+
+// RoadmapBar is an item on a roadmap from the start date through the end date.
+type RoadmapBar struct {
+	Label string
+	Start time.Time
+	End   time.Time
+}
+
+// Milestone gets whether the bar starts and ends on the same day.
+func (b RoadmapBar) Milestone() bool {
+	return b.Start.Equal(b.End)
+}
+
+// Roadmap renders groups of bars as a Gantt chart to fit within width, with a marker for today.
+func (t *Template) Roadmap(title string, groups []RoadmapGroup, today time.Time, width int) error {
+	cs := t.c.ColorScheme()
+	if title != "" && t.c.IsStdoutTTY() {
+		fmt.Fprintf(t.w, "%s\n\n", cs.ColorFunc("white+b")(title))
+	}
+
+	start, end, ok := roadmapRange(groups)
+	if !ok {
+		fmt.Fprintln(t.w, "No items with dates")
+		return nil
+	}
+
+	labelWidth := 0
+	for _, group := range groups {
+		for _, bar := range group.Bars {
+			if w := DisplayWidth(bar.Label) + 2; w > labelWidth {
+				labelWidth = w
+			}
+		}
+	}
+	if labelWidth > maxLabelWidth {
+		labelWidth = maxLabelWidth
+	}
+	if width-labelWidth-1 < minChartWidth {
+		labelWidth = width - minChartWidth - 1
+		if labelWidth < 10 {
+			labelWidth = 10
+		}
+	}
+	chartWidth := width - labelWidth - 1
+	if chartWidth < minChartWidth {
+		chartWidth = minChartWidth
+	}
+
+	days := int(end.Sub(start).Hours()/24) + 1
+	column := func(date time.Time) int {
+		c := int(date.Sub(start).Hours() / 24 * float64(chartWidth) / float64(days))
+		if c >= chartWidth {
+			c = chartWidth - 1
+		}
+		return c
+	}
+
+	todayColumn := -1
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	if !today.Before(start) && !today.After(end) {
+		todayColumn = column(today)
+	}
+
+	// Show the first and last dates on the axis.
+	axis := []rune(strings.Repeat(" ", chartWidth))
+	copy(axis, []rune(start.Format(dateLayout)))
+	endLabel := []rune(end.Format(dateLayout))
+	if chartWidth >= 2*len(endLabel)+1 {
+		copy(axis[chartWidth-len(endLabel):], endLabel)
+	}
+	fmt.Fprintf(t.w, "%s %s\n", strings.Repeat(" ", labelWidth), strings.TrimRight(string(axis), " "))
+
+	if todayColumn >= 0 {
+		// Point to today from the left if there's no room on the right.
+		marker, markerColumn := "▼ today", todayColumn
+		if markerColumn+DisplayWidth(marker) > chartWidth {
+			marker = "today ▼"
+			markerColumn -= DisplayWidth(marker) - 1
+		}
+		fmt.Fprintf(t.w, "%s %s%s\n", strings.Repeat(" ", labelWidth), strings.Repeat(" ", markerColumn), cs.Red(marker))
+	}
+
+	colors := []func(string) string{cs.Cyan, cs.Magenta, cs.Yellow, cs.Green, cs.Blue}
+	for i, group := range groups {
+		if len(group.Bars) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(t.w, cs.ColorFunc("white+b")(Truncate(width, group.Name)))
+		color := colors[i%len(colors)]
+
+		for _, bar := range group.Bars {
+			from, to := column(bar.Start), column(bar.End)
+
+			var sb strings.Builder
+			for c := 0; c < chartWidth; c++ {
+				switch {
+				case bar.Milestone() && c == from:
+					sb.WriteString(color("◆"))
+				case c >= from && c <= to && !bar.Milestone():
+					sb.WriteString(color("█"))
+				case c == todayColumn:
+					sb.WriteString(cs.Red("│"))
+				default:
+					sb.WriteString(" ")
+				}
+			}
+
+			fmt.Fprintf(t.w, "%s %s\n", Pad(Truncate(labelWidth, "  "+bar.Label), labelWidth), strings.TrimRight(sb.String(), " "))
+		}
+	}
+
+	return nil
+}
+
+// Mermaid renders groups of bars as a Mermaid gantt chart in a fenced code block.
+func (t *Template) Mermaid(title string, groups []RoadmapGroup) error {
+	var sb strings.Builder
+	sb.WriteString("```mermaid\ngantt\n")
+	if title != "" {
+		fmt.Fprintf(&sb, "    title %s\n", mermaidText(title))
+	}
+	sb.WriteString("    dateFormat YYYY-MM-DD\n")
+
+	for _, group := range groups {
+		if len(group.Bars) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&sb, "    section %s\n", mermaidText(group.Name))
+		for _, bar := range group.Bars {
+			if bar.Milestone() {
+				fmt.Fprintf(&sb, "    %s :milestone, %s, 0d\n", mermaidText(bar.Label), bar.Start.Format(dateLayout))
+			} else {
+				// Mermaid end dates are exclusive.
+				fmt.Fprintf(&sb, "    %s :%s, %s\n", mermaidText(bar.Label), bar.Start.Format(dateLayout), bar.End.AddDate(0, 0, 1).Format(dateLayout))
+			}
+		}
+	}
+
+	sb.WriteString("```\n")
+	_, err := fmt.Fprint(t.w, sb.String())
+	return err
+}
+
+// mermaidText removes characters that have meaning in Mermaid gantt charts.
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " ", ";", " ", "\n", " ").Replace(s)
+}
+
+func roadmapRange(groups []RoadmapGroup) (start, end time.Time, ok bool) {
+	for _, group := range groups {
+		for _, bar := range group.Bars {
+			if !ok || bar.Start.Before(start) {
+				start = bar.Start
+			}
+			if !ok || bar.End.After(end) {
+				end = bar.End
+			}
+			ok = true
+		}
+	}
+	return
+}
@@ -0,0 +1,29 @@
+package template
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/heaths/gh-projects/internal/report"
+)
+
+// Stale renders stale items with their status and why they are stale.
+func (t *Template) Stale(items []report.StaleItem) error {
+	if len(items) == 0 {
+		fmt.Fprintln(t.w, "No stale items")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	for _, s := range items {
+		number := ""
+		if s.Item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", s.Item.Content.Number)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", number, s.Item.Content.Title, s.Status, strings.Join(s.Reasons, ", "))
+	}
+
+	return w.Flush()
+}
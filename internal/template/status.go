@@ -0,0 +1,87 @@
+package template
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/MakeNowJust/heredoc"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// StatusUpdate renders a single project status update with its body rendered as markdown.
+func (t *Template) StatusUpdate(update models.ProjectStatusUpdate) error {
+	if _, err := t.t.New("statusUpdate").Parse(heredoc.Doc(`
+		{{if isTTY}}{{statusUpdate .Status | bold}}{{with .Creator.Login}} • {{.}}{{end}}{{with .CreatedAt}} posted {{ago .}}{{end}}{{with .StartDate}}
+		{{"Start date:" | dim}} {{.}}{{end}}{{with .TargetDate}}
+		{{"Target date:" | dim}} {{.}}{{end}}
+		{{if .Body}}
+		  {{markdown .Body}}{{end}}{{else}}id:	{{.ID}}
+		status:	{{.Status}}{{with .StartDate}}
+		startDate:	{{.}}{{end}}{{with .TargetDate}}
+		targetDate:	{{.}}{{end}}
+		creator:	{{.Creator.Login}}{{with .CreatedAt}}
+		createdAt:	{{.Format "2006-01-02T15:04:05Z07:00"}}{{end}}
+		--
+		{{.Body}}{{end}}
+	`)); err != nil {
+		return err
+	}
+
+	return t.t.ExecuteTemplate(t.w, "statusUpdate", update)
+}
+
+// StatusUpdates renders a table of project status updates with the first line of each body.
+func (t *Template) StatusUpdates(title string, updates []models.ProjectStatusUpdate, totalCount int) error {
+	if _, err := t.t.New("statusUpdates").Parse(heredoc.Doc(`
+		{{if isTTY}}
+		Showing {{len .Updates}} of {{pluralize .TotalCount "status update"}} for {{bold .Title}}
+
+		{{end}}{{range .Updates}}{{tablerow (statusUpdate .Status) .TargetDate .Creator.Login (summary .Body | truncate 60) (dim .ID)}}{{end}}{{tablerender}}
+	`)); err != nil {
+		return err
+	}
+
+	data := struct {
+		Title      string
+		Updates    []models.ProjectStatusUpdate
+		TotalCount int
+	}{
+		Title:      title,
+		Updates:    updates,
+		TotalCount: totalCount,
+	}
+
+	return t.t.ExecuteTemplate(t.w, "statusUpdates", data)
+}
+
+// StatusUpdateJSON renders a project status update as JSON.
+func (t *Template) StatusUpdateJSON(update models.ProjectStatusUpdate) error {
+	return t.statusUpdatesJSON(update)
+}
+
+// StatusUpdatesJSON renders project status updates as a JSON array.
+func (t *Template) StatusUpdatesJSON(updates []models.ProjectStatusUpdate) error {
+	if updates == nil {
+		updates = []models.ProjectStatusUpdate{}
+	}
+
+	return t.statusUpdatesJSON(updates)
+}
+
+func (t *Template) statusUpdatesJSON(v interface{}) error {
+	enc := json.NewEncoder(t.w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
+
+// summary gets the first non-empty line of text.
+func summary(text string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+
+	return ""
+}
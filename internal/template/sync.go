@@ -0,0 +1,51 @@
+package template
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/pkg/text"
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// FieldChange is a change to the value of a field for an item.
+type FieldChange struct {
+	Item models.ProjectItem
+	From string
+	To   string
+}
+
+// FieldChanges renders a summary of changes to field values, which were not made if dryRun is true.
+func (t *Template) FieldChanges(changes []FieldChange, dryRun bool) error {
+	if len(changes) == 0 {
+		fmt.Fprintln(t.w, "No items to update")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(t.w, 0, 0, 2, ' ', 0)
+	for _, change := range changes {
+		number := ""
+		if change.Item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", change.Item.Content.Number)
+		}
+
+		from := change.From
+		if from == "" {
+			from = "(none)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s → %s\n", number, change.Item.Content.Title, from, change.To)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	count := text.Pluralize(len(changes), "item")
+	if dryRun {
+		fmt.Fprintf(t.w, "Would update %s\n", count)
+	} else {
+		fmt.Fprintf(t.w, "Updated %s\n", count)
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ package template
 import (
 	"fmt"
 	"io"
+	"strings"
 	"text/tabwriter"
 	tt "text/template"
 
@@ -14,6 +15,7 @@ import (
 )
 
 type Template struct {
+	c  console.Console
 	t  *tt.Template
 	w  io.Writer
 	ts tableState
@@ -22,6 +24,7 @@ type Template struct {
 func New(c console.Console) (*Template, error) {
 	templ := tt.New("")
 	t := &Template{
+		c: c,
 		t: templ,
 		w: c.Stdout(),
 	}
@@ -33,7 +36,19 @@ func New(c console.Console) (*Template, error) {
 		"color": func(style, text string) string {
 			return cs.ColorFunc(style)(text)
 		},
-		"isTTY":    c.IsStdoutTTY,
+		"fields": func(item models.ProjectItem, names []string) []string {
+			values := make([]string, len(names))
+			for i, name := range names {
+				if value, ok := item.FieldValue(name); ok {
+					values[i] = value.String()
+				}
+			}
+			return values
+		},
+		"isTTY": c.IsStdoutTTY,
+		"join": func(sep string, values []string) string {
+			return strings.Join(values, sep)
+		},
 		"markdown": markdown(c.IsStdoutTTY),
 		"number": func(number int) string {
 			if number != 0 {
@@ -56,9 +71,27 @@ func New(c console.Console) (*Template, error) {
 				return cs.Black("")
 			}
 		},
+		"statusUpdate": func(s string) string {
+			switch s {
+			case "INACTIVE":
+				return cs.LightBlack("Inactive")
+			case "ON_TRACK":
+				return cs.Green("On track")
+			case "AT_RISK":
+				return cs.Yellow("At risk")
+			case "OFF_TRACK":
+				return cs.Red("Off track")
+			case "COMPLETE":
+				return cs.Magenta("Complete")
+			default:
+				// Return colored empty string to use consistent column width.
+				return cs.Black("")
+			}
+		},
+		"summary":     summary,
 		"tablerow":    tablerowFunc(&t.ts),
 		"tablerender": tablerenderFunc(&t.ts),
-		"truncate":    truncate,
+		"truncate":    Truncate,
 		"type": func(it string) string {
 			switch it {
 			case "DRAFT_ISSUE":
@@ -124,3 +157,39 @@ func (t *Template) Projects(projects []models.Project, totalCount int) error {
 
 	return t.t.ExecuteTemplate(t.w, "projects", data)
 }
+
+func (t *Template) Items(items []models.ProjectItem, fields []string, totalCount int) error {
+	if _, err := t.t.New("items").Parse(heredoc.Doc(`
+		{{if isTTY}}
+		Showing {{len .Items}} of {{pluralize .TotalCount "item"}}
+
+		{{end}}{{range .Items}}{{if $.Fields}}{{tablerow (type .Type) (number .Content.Number) (.Content.Title | truncate 80) (state .Content.State) (fields . $.Fields | join "\t")}}{{else}}{{tablerow (type .Type) (number .Content.Number) (.Content.Title | truncate 80) (state .Content.State)}}{{end}}{{end}}{{tablerender}}
+	`)); err != nil {
+		return err
+	}
+
+	data := struct {
+		Items      []models.ProjectItem
+		Fields     []string
+		TotalCount int
+	}{
+		Items:      items,
+		Fields:     fields,
+		TotalCount: totalCount,
+	}
+
+	return t.t.ExecuteTemplate(t.w, "items", data)
+}
+
+func (t *Template) Views(views []models.ProjectView) error {
+	if _, err := t.t.New("views").Parse(heredoc.Doc(`
+		{{if isTTY}}
+		Showing {{pluralize (len .) "view"}}
+
+		{{end}}{{range .}}{{tablerow (number .Number) (bold .Name) .LayoutName (.Filter | dim) (join ", " .GroupBy) (join ", " .SortBy) (join ", " .Fields.Names)}}{{end}}{{tablerender}}
+	`)); err != nil {
+		return err
+	}
+
+	return t.t.ExecuteTemplate(t.w, "views", views)
+}
@@ -1,7 +1,7 @@
 package template
 
 // Copied from https://raw.githubusercontent.com/cli/cli/e2973453b5cd77df1b246a6147bbed6b47e4ce1c/pkg/text/truncate.go,
-// which I helped write. Made some minimal changes where I don't need to export functions currently.
+// which I helped write. Made some minimal changes.
 
 import (
 	"github.com/muesli/reflow/ansi"
@@ -13,14 +13,14 @@ const (
 	minWidthForEllipsis = len(ellipsis) + 2
 )
 
-// displayWidth calculates what the rendered width of a string may be.
-func displayWidth(s string) int {
+// DisplayWidth calculates what the rendered width of a string may be.
+func DisplayWidth(s string) int {
 	return ansi.PrintableRuneWidth(s)
 }
 
-// truncate shortens a string to fit the maximum display width.
-func truncate(maxWidth int, s string) string {
-	w := displayWidth(s)
+// Truncate shortens a string to fit the maximum display width.
+func Truncate(maxWidth int, s string) string {
+	w := DisplayWidth(s)
 	if w <= maxWidth {
 		return s
 	}
@@ -31,7 +31,7 @@ func truncate(maxWidth int, s string) string {
 	}
 
 	r := trunc.StringWithTail(s, uint(maxWidth), tail)
-	if displayWidth(r) < maxWidth {
+	if DisplayWidth(r) < maxWidth {
 		r += " "
 	}
 
@@ -34,7 +34,7 @@ func TestTruncate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := truncate(tt.maxWidth, tt.s)
+			got := Truncate(tt.maxWidth, tt.s)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -0,0 +1,50 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/heaths/gh-projects/internal/watch"
+)
+
+// Changes renders a line for each change to project items.
+func (t *Template) Changes(changes []watch.Change) error {
+	cs := t.c.ColorScheme()
+	for _, change := range changes {
+		item := change.Title
+		if change.Number != 0 {
+			item = cs.Green(fmt.Sprintf("#%d", change.Number)) + " " + item
+		}
+
+		var description string
+		switch {
+		case change.Kind != watch.KindChanged:
+			description = string(change.Kind)
+		case change.From == "":
+			description = fmt.Sprintf("%s set to %s", change.Field, change.To)
+		case change.To == "":
+			description = fmt.Sprintf("%s cleared from %s", change.Field, change.From)
+		default:
+			description = fmt.Sprintf("%s changed from %s to %s", change.Field, change.From, change.To)
+		}
+
+		if _, err := fmt.Fprintf(t.w, "%s %s: %s\n", cs.LightBlack(change.Time.Format("15:04:05")), item, description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChangesJSON renders each change to project items as a line of JSON.
+func (t *Template) ChangesJSON(changes []watch.Change) error {
+	enc := json.NewEncoder(t.w)
+	enc.SetEscapeHTML(false)
+	for _, change := range changes {
+		if err := enc.Encode(change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
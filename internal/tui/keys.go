@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"bufio"
+	"strings"
+)
+
+const (
+	keyUp         = "up"
+	keyDown       = "down"
+	keyLeft       = "left"
+	keyRight      = "right"
+	keyShiftLeft  = "shift+left"
+	keyShiftRight = "shift+right"
+	keyPageUp     = "pgup"
+	keyPageDown   = "pgdown"
+	keyHome       = "home"
+	keyEnd        = "end"
+	keyEnter      = "enter"
+	keyEsc        = "esc"
+	keyBackspace  = "backspace"
+	keyTab        = "tab"
+	keyCtrlC      = "ctrl+c"
+)
+
+// key is either a named key like "up" or a printable rune.
+type key struct {
+	name string
+	r    rune
+}
+
+func (k key) is(names ...string) bool {
+	for _, name := range names {
+		if k.name == name || (k.name == "" && string(k.r) == name) {
+			return true
+		}
+	}
+	return false
+}
+
+var escapeSequences = map[string]string{
+	"A":    keyUp,
+	"B":    keyDown,
+	"C":    keyRight,
+	"D":    keyLeft,
+	"1;2C": keyShiftRight,
+	"1;2D": keyShiftLeft,
+	"5~":   keyPageUp,
+	"6~":   keyPageDown,
+	"H":    keyHome,
+	"1~":   keyHome,
+	"F":    keyEnd,
+	"4~":   keyEnd,
+}
+
+// readKey reads a key from a terminal in raw mode. Unknown escape sequences are returned as an empty key.
+func readKey(r *bufio.Reader) (key, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return key{}, err
+	}
+
+	switch c {
+	case 0x1b:
+		// A lone escape is not followed by buffered input.
+		if r.Buffered() == 0 {
+			return key{name: keyEsc}, nil
+		}
+
+		next, _, err := r.ReadRune()
+		if err != nil {
+			return key{}, err
+		}
+		if next != '[' && next != 'O' {
+			_ = r.UnreadRune()
+			return key{name: keyEsc}, nil
+		}
+
+		var seq strings.Builder
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return key{}, err
+			}
+			seq.WriteByte(b)
+
+			// Final bytes of control sequences are in the range 0x40-0x7e.
+			if b >= 0x40 && b <= 0x7e {
+				break
+			}
+		}
+
+		return key{name: escapeSequences[seq.String()]}, nil
+
+	case '\r', '\n':
+		return key{name: keyEnter}, nil
+	case 0x7f, 0x08:
+		return key{name: keyBackspace}, nil
+	case '\t':
+		return key{name: keyTab}, nil
+	case 0x03:
+		return key{name: keyCtrlC}, nil
+	}
+
+	return key{r: c}, nil
+}
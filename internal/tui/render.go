@@ -0,0 +1,279 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/gh-projects/internal/template"
+)
+
+const (
+	// The minimum width of a board column before columns scroll.
+	minColumnWidth = 24
+
+	// The number of spaces between board and table columns.
+	columnGap = 2
+
+	// The width of table columns for field values.
+	fieldColumnWidth = 14
+
+	selectedMarker = "› "
+)
+
+// draw renders a frame and writes it to the screen.
+func (u *UI) draw() {
+	u.frame = u.render()
+
+	u.con.ClearScreen()
+	fmt.Fprint(u.con.Stdout(), strings.Join(u.frame, "\r\n"))
+}
+
+// bodyHeight gets the number of lines between the header and footer.
+func (u *UI) bodyHeight() int {
+	if h := u.height - 3; h > 0 {
+		return h
+	}
+	return 1
+}
+
+// render gets exactly as many lines as the height of the screen.
+func (u *UI) render() []string {
+	var body []string
+	switch {
+	case u.choices != nil:
+		body = u.renderChoices()
+	case u.mode == modeDetail:
+		body = u.renderDetail()
+	case u.mode == modeTable:
+		body = u.renderTable()
+	default:
+		body = u.renderBoard()
+	}
+
+	lines := make([]string, 0, u.height)
+	lines = append(lines, u.renderHeader(), "")
+	for i := 0; i < u.bodyHeight(); i++ {
+		line := ""
+		if i < len(body) {
+			line = template.Truncate(u.width, body[i])
+		}
+		lines = append(lines, line)
+	}
+	lines = append(lines, u.renderFooter())
+
+	return lines[:u.height]
+}
+
+func (u *UI) renderHeader() string {
+	cs := u.con.ColorScheme()
+
+	var details []string
+	switch u.mode {
+	case modeBoard:
+		details = append(details, "board by "+u.groupBy.Name)
+	case modeTable:
+		details = append(details, "table")
+	}
+	if u.filterText != "" {
+		details = append(details, "filter: "+u.filterText)
+	}
+	details = append(details, fmt.Sprintf("%d of %d items", len(u.items), len(u.project.Items)))
+
+	return template.Truncate(u.width, fmt.Sprintf("%s  %s", cs.ColorFunc("white+b")(u.project.Title), cs.LightBlack(strings.Join(details, " • "))))
+}
+
+func (u *UI) renderFooter() string {
+	cs := u.con.ColorScheme()
+	switch {
+	case u.input != nil:
+		return template.Truncate(u.width, fmt.Sprintf("%s %s█", cs.Green(u.input.label+":"), string(u.input.text)))
+	case u.choices != nil:
+		return cs.LightBlack("↓↑ select • enter choose • esc cancel")
+	case u.status != "":
+		return template.Truncate(u.width, u.status)
+	case u.mode == modeDetail:
+		return cs.LightBlack("↓↑ scroll • q back")
+	default:
+		return cs.LightBlack(template.Truncate(u.width, "←↓↑→ select • <> move • enter open • e edit • / filter • g group • tab table • ? help • q quit"))
+	}
+}
+
+func (u *UI) renderBoard() []string {
+	if len(u.columns) == 0 {
+		return []string{"No items"}
+	}
+
+	cs := u.con.ColorScheme()
+
+	perView := (u.width + columnGap) / (minColumnWidth + columnGap)
+	perView = clamp(perView, 1, len(u.columns))
+	width := (u.width+columnGap)/perView - columnGap
+
+	// Scroll columns to keep the selected column visible.
+	if u.col < u.colOffset {
+		u.colOffset = u.col
+	} else if u.col >= u.colOffset+perView {
+		u.colOffset = u.col - perView + 1
+	}
+	u.colOffset = clamp(u.colOffset, 0, len(u.columns)-perView)
+
+	cards := u.bodyHeight() - 2
+	columns := make([][]string, 0, perView)
+	for c := u.colOffset; c < u.colOffset+perView; c++ {
+		column := u.columns[c]
+		lines := []string{
+			template.Truncate(width, fmt.Sprintf("%s %s", cs.ColorFunc("white+b")(column.Name), cs.LightBlack(fmt.Sprintf("%d", len(column.Items))))),
+			cs.LightBlack(strings.Repeat("─", width)),
+		}
+
+		// Scroll cards to keep the selected card visible.
+		offset := 0
+		if c == u.col && u.row >= cards {
+			offset = u.row - cards + 1
+		}
+
+		for r := offset; r < len(column.Items) && r < offset+cards; r++ {
+			item := column.Items[r]
+			title := item.Content.Title
+			if item.Content.Number != 0 {
+				title = fmt.Sprintf("%s %s", cs.Green(fmt.Sprintf("#%d", item.Content.Number)), title)
+			}
+
+			if c == u.col && r == u.row {
+				lines = append(lines, cs.ColorFunc("white+i")(template.Truncate(width, selectedMarker+title)))
+			} else {
+				lines = append(lines, template.Truncate(width, "  "+title))
+			}
+		}
+
+		columns = append(columns, lines)
+	}
+
+	return joinColumns(columns, width)
+}
+
+// joinColumns joins the lines of each column side by side.
+func joinColumns(columns [][]string, width int) []string {
+	height := 0
+	for _, column := range columns {
+		if len(column) > height {
+			height = len(column)
+		}
+	}
+
+	lines := make([]string, height)
+	for i := range lines {
+		var sb strings.Builder
+		for c, column := range columns {
+			cell := ""
+			if i < len(column) {
+				cell = column[i]
+			}
+
+			if c < len(columns)-1 {
+				sb.WriteString(template.Pad(cell, width+columnGap))
+			} else {
+				sb.WriteString(cell)
+			}
+		}
+		lines[i] = strings.TrimRight(sb.String(), " ")
+	}
+
+	return lines
+}
+
+func (u *UI) renderTable() []string {
+	if len(u.items) == 0 {
+		return []string{"No items"}
+	}
+
+	cs := u.con.ColorScheme()
+
+	// Show as many field values as fit with a title of at least the minimum column width.
+	const numberWidth, stateWidth = 7, 8
+	var fields []models.ProjectField
+	titleWidth := u.width - len(selectedMarker) - numberWidth - stateWidth - 2*columnGap
+	for _, field := range u.project.Fields {
+		switch field.DataType {
+		case "TITLE", "LINKED_PULL_REQUESTS", "TRACKS", "REVIEWERS":
+			continue
+		}
+		if titleWidth-fieldColumnWidth-columnGap < minColumnWidth {
+			break
+		}
+		fields = append(fields, field)
+		titleWidth -= fieldColumnWidth + columnGap
+	}
+
+	row := func(marker, number, title, state string, values []string) string {
+		var sb strings.Builder
+		sb.WriteString(marker)
+		sb.WriteString(template.Pad(template.Truncate(numberWidth, number), numberWidth))
+		sb.WriteString(template.Pad(template.Truncate(titleWidth, title), titleWidth+columnGap))
+		sb.WriteString(template.Pad(template.Truncate(stateWidth, state), stateWidth+columnGap))
+		for _, value := range values {
+			sb.WriteString(template.Pad(template.Truncate(fieldColumnWidth, value), fieldColumnWidth+columnGap))
+		}
+		return strings.TrimRight(sb.String(), " ")
+	}
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+	lines := []string{cs.ColorFunc("white+b")(row("  ", "#", "Title", "State", names))}
+
+	// Scroll rows to keep the selected row visible.
+	rows := u.bodyHeight() - 1
+	if u.tableRow < u.tableOffset {
+		u.tableOffset = u.tableRow
+	} else if u.tableRow >= u.tableOffset+rows {
+		u.tableOffset = u.tableRow - rows + 1
+	}
+
+	for i := u.tableOffset; i < len(u.items) && i < u.tableOffset+rows; i++ {
+		item := u.items[i]
+
+		number := ""
+		if item.Content.Number != 0 {
+			number = fmt.Sprintf("#%d", item.Content.Number)
+		}
+
+		values := make([]string, len(fields))
+		for j, field := range fields {
+			if value, ok := item.FieldValue(field.Name); ok {
+				values[j] = value.String()
+			}
+		}
+
+		if i == u.tableRow {
+			lines = append(lines, cs.ColorFunc("white+i")(row(selectedMarker, number, item.Content.Title, strings.ToLower(item.Content.State), values)))
+		} else {
+			lines = append(lines, row("  ", number, item.Content.Title, strings.ToLower(item.Content.State), values))
+		}
+	}
+
+	return lines
+}
+
+func (u *UI) renderDetail() []string {
+	end := u.detailOffset + u.bodyHeight()
+	if end > len(u.detail) {
+		end = len(u.detail)
+	}
+	return u.detail[u.detailOffset:end]
+}
+
+func (u *UI) renderChoices() []string {
+	cs := u.con.ColorScheme()
+	lines := []string{cs.ColorFunc("white+b")(u.choices.label), ""}
+	for i, option := range u.choices.options {
+		if i == u.choices.selected {
+			lines = append(lines, cs.ColorFunc("white+i")(fmt.Sprintf("%s%d. %s", selectedMarker, i+1, option)))
+		} else {
+			lines = append(lines, fmt.Sprintf("  %d. %s", i+1, option))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,669 @@
+// Package tui implements a full-screen terminal UI to browse and edit a project.
+package tui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/heaths/gh-projects/internal/filter"
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/go-console"
+)
+
+// Project is the project to browse.
+type Project struct {
+	Title  string
+	Readme string
+	Fields []models.ProjectField
+	Items  []models.ProjectItem
+
+	// Viewer is the login to match "@me" in filters.
+	Viewer string
+}
+
+// Backend reads and changes project items.
+type Backend interface {
+	// SetField sets the value of a field for an item.
+	SetField(item models.ProjectItem, field models.ProjectField, value string) error
+
+	// Body gets the body of a draft issue, issue, or pull request.
+	Body(item models.ProjectItem) (string, error)
+}
+
+// Options for the UI.
+type Options struct {
+	// GroupBy is the name of the single select or iteration field to group items by on the board.
+	GroupBy string
+
+	Width  int
+	Height int
+}
+
+type mode int
+
+const (
+	modeBoard mode = iota
+	modeTable
+	modeDetail
+)
+
+// UI is a full-screen terminal UI. Keys are read from the console's stdin, which should be in raw mode.
+type UI struct {
+	con     console.Console
+	in      *bufio.Reader
+	project *Project
+	backend Backend
+	width   int
+	height  int
+
+	mode     mode
+	prevMode mode
+	groupBy  *models.ProjectField
+
+	filter     *filter.Filter
+	filterText string
+	items      []models.ProjectItem
+	columns    []filter.Group
+
+	// Board selection and the first visible column.
+	col, row  int
+	colOffset int
+
+	// Table selection and the first visible row.
+	tableRow    int
+	tableOffset int
+
+	// Detail lines and the first visible line.
+	detail       []string
+	detailOffset int
+
+	input   *input
+	choices *choices
+	status  string
+	quit    bool
+
+	// frame is the last rendered frame.
+	frame []string
+}
+
+// input reads a line of text in the footer.
+type input struct {
+	label    string
+	text     []rune
+	onSubmit func(string)
+}
+
+// choices selects one of the options in place of the body.
+type choices struct {
+	label    string
+	options  []string
+	selected int
+	onChoose func(int)
+}
+
+// New creates a UI for the project.
+func New(con console.Console, project *Project, backend Backend, opts Options) (*UI, error) {
+	u := &UI{
+		con:     con,
+		in:      bufio.NewReader(con.Stdin()),
+		project: project,
+		backend: backend,
+		width:   opts.Width,
+		height:  opts.Height,
+	}
+
+	if u.width <= 0 {
+		u.width = 80
+	}
+	if u.height <= 0 {
+		u.height = 24
+	}
+
+	for i := range project.Fields {
+		if strings.EqualFold(project.Fields[i].Name, opts.GroupBy) {
+			u.groupBy = &project.Fields[i]
+			break
+		}
+	}
+	if u.groupBy == nil {
+		return nil, fmt.Errorf("field not found: %s", opts.GroupBy)
+	}
+
+	if err := u.refresh(); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Run reads keys and redraws the UI until quit or the input is closed.
+func (u *UI) Run() error {
+	u.con.StartAlternativeScreenBuffer()
+	defer u.con.StopAlternativeScreenBuffer()
+
+	u.draw()
+	for !u.quit {
+		k, err := readKey(u.in)
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		u.handle(k)
+		u.draw()
+	}
+
+	return nil
+}
+
+// refresh filters and groups items, and keeps the selection in range.
+func (u *UI) refresh() error {
+	u.items = u.items[:0]
+	env := filter.Env{Viewer: u.project.Viewer}
+	for _, item := range u.project.Items {
+		if u.filter.Match(item, env) {
+			u.items = append(u.items, item)
+		}
+	}
+
+	columns, err := filter.GroupBy(u.items, u.groupBy)
+	if err != nil {
+		return err
+	}
+	u.columns = columns
+
+	u.col = clamp(u.col, 0, len(u.columns)-1)
+	if len(u.columns) > 0 {
+		u.row = clamp(u.row, 0, len(u.columns[u.col].Items)-1)
+	}
+	u.tableRow = clamp(u.tableRow, 0, len(u.items)-1)
+
+	return nil
+}
+
+// selected gets the selected item.
+func (u *UI) selected() (models.ProjectItem, bool) {
+	switch u.mode {
+	case modeBoard:
+		if u.col < len(u.columns) && u.row < len(u.columns[u.col].Items) {
+			return u.columns[u.col].Items[u.row], true
+		}
+	case modeTable:
+		if u.tableRow < len(u.items) {
+			return u.items[u.tableRow], true
+		}
+	}
+
+	return models.ProjectItem{}, false
+}
+
+// selectItem moves the selection to the item after it was regrouped.
+func (u *UI) selectItem(id string) {
+	for i, item := range u.items {
+		if item.ID == id {
+			u.tableRow = i
+		}
+	}
+
+	for c, column := range u.columns {
+		for r, item := range column.Items {
+			if item.ID == id {
+				u.col, u.row = c, r
+				return
+			}
+		}
+	}
+}
+
+func (u *UI) handle(k key) {
+	if k.is(keyCtrlC) {
+		u.quit = true
+		return
+	}
+
+	if u.input != nil {
+		u.handleInput(k)
+		return
+	}
+
+	if u.choices != nil {
+		u.handleChoices(k)
+		return
+	}
+
+	u.status = ""
+	if u.mode == modeDetail {
+		u.handleDetail(k)
+		return
+	}
+
+	switch {
+	case k.is("q"):
+		u.quit = true
+	case k.is(keyTab):
+		if u.mode == modeBoard {
+			u.mode = modeTable
+		} else {
+			u.mode = modeBoard
+		}
+		if item, ok := u.selected(); ok {
+			u.selectItem(item.ID)
+		}
+	case k.is(keyUp, "k"):
+		u.moveSelection(0, -1)
+	case k.is(keyDown, "j"):
+		u.moveSelection(0, 1)
+	case k.is(keyLeft, "h"):
+		u.moveSelection(-1, 0)
+	case k.is(keyRight, "l"):
+		u.moveSelection(1, 0)
+	case k.is(keyShiftLeft, "<"):
+		u.moveCard(-1)
+	case k.is(keyShiftRight, ">"):
+		u.moveCard(1)
+	case k.is(keyEnter):
+		u.openItem()
+	case k.is("e"):
+		u.editItem()
+	case k.is("/"):
+		u.prompt("Filter", u.filterText, u.setFilter)
+	case k.is("g"):
+		u.chooseGroupBy()
+	case k.is("R"):
+		u.showDetail(u.project.Title, "# "+u.project.Title+"\n\n"+u.project.Readme)
+	case k.is("?"):
+		u.showHelp()
+	}
+}
+
+func (u *UI) handleInput(k key) {
+	switch {
+	case k.is(keyEnter):
+		in := u.input
+		u.input = nil
+		in.onSubmit(string(in.text))
+	case k.is(keyEsc):
+		u.input = nil
+	case k.is(keyBackspace):
+		if n := len(u.input.text); n > 0 {
+			u.input.text = u.input.text[:n-1]
+		}
+	case k.name == "" && k.r >= ' ':
+		u.input.text = append(u.input.text, k.r)
+	}
+}
+
+func (u *UI) handleChoices(k key) {
+	c := u.choices
+	switch {
+	case k.is(keyEnter):
+		u.choices = nil
+		c.onChoose(c.selected)
+	case k.is(keyEsc, "q"):
+		u.choices = nil
+	case k.is(keyUp, "k"):
+		c.selected = clamp(c.selected-1, 0, len(c.options)-1)
+	case k.is(keyDown, "j"):
+		c.selected = clamp(c.selected+1, 0, len(c.options)-1)
+	case k.name == "" && k.r >= '1' && k.r <= '9':
+		if i := int(k.r - '1'); i < len(c.options) {
+			u.choices = nil
+			c.onChoose(i)
+		}
+	}
+}
+
+func (u *UI) handleDetail(k key) {
+	page := u.bodyHeight()
+	switch {
+	case k.is(keyEsc, "q", keyEnter):
+		u.mode = u.prevMode
+	case k.is(keyUp, "k"):
+		u.detailOffset--
+	case k.is(keyDown, "j"):
+		u.detailOffset++
+	case k.is(keyPageUp):
+		u.detailOffset -= page
+	case k.is(keyPageDown, " "):
+		u.detailOffset += page
+	case k.is(keyHome):
+		u.detailOffset = 0
+	case k.is(keyEnd):
+		u.detailOffset = len(u.detail)
+	}
+
+	u.detailOffset = clamp(u.detailOffset, 0, len(u.detail)-page)
+}
+
+func (u *UI) moveSelection(dx, dy int) {
+	if u.mode == modeTable {
+		u.tableRow = clamp(u.tableRow+dy, 0, len(u.items)-1)
+		return
+	}
+
+	if len(u.columns) == 0 {
+		return
+	}
+
+	u.col = clamp(u.col+dx, 0, len(u.columns)-1)
+	u.row = clamp(u.row+dy, 0, len(u.columns[u.col].Items)-1)
+}
+
+// moveCard moves the selected card to an adjacent column by setting the field it is grouped by.
+func (u *UI) moveCard(dx int) {
+	item, ok := u.selected()
+	if !ok || u.mode != modeBoard {
+		return
+	}
+
+	target := u.col + dx
+	if target < 0 || target >= len(u.columns) {
+		return
+	}
+
+	name := u.columns[target].Name
+	if !u.isValue(u.groupBy, name) {
+		u.status = fmt.Sprintf("Cannot move to %q", name)
+		return
+	}
+
+	u.setField(item, *u.groupBy, name)
+}
+
+// isValue gets whether name is an option or iteration of the field.
+func (u *UI) isValue(field *models.ProjectField, name string) bool {
+	for _, option := range field.Options {
+		if strings.EqualFold(option.Name, name) {
+			return true
+		}
+	}
+	for _, iteration := range field.Configuration.Iterations {
+		if strings.EqualFold(iteration.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *UI) editItem() {
+	item, ok := u.selected()
+	if !ok {
+		return
+	}
+
+	var fields []models.ProjectField
+	var names []string
+	for _, field := range u.project.Fields {
+		switch field.DataType {
+		case "SINGLE_SELECT", "ITERATION", "TEXT", "NUMBER", "DATE":
+			fields = append(fields, field)
+			names = append(names, field.Name)
+		}
+	}
+
+	if len(fields) == 0 {
+		u.status = "No fields can be edited"
+		return
+	}
+
+	u.choose("Edit field", names, 0, func(i int) {
+		u.editField(item, fields[i])
+	})
+}
+
+func (u *UI) editField(item models.ProjectItem, field models.ProjectField) {
+	current := ""
+	if value, ok := item.FieldValue(field.Name); ok {
+		current = value.String()
+	}
+
+	var values []string
+	switch field.DataType {
+	case "SINGLE_SELECT":
+		for _, option := range field.Options {
+			values = append(values, option.Name)
+		}
+	case "ITERATION":
+		for _, iteration := range field.Configuration.Iterations {
+			values = append(values, iteration.Name)
+		}
+	default:
+		u.prompt(field.Name, current, func(value string) {
+			u.setField(item, field, value)
+		})
+		return
+	}
+
+	selected := 0
+	for i, value := range values {
+		if strings.EqualFold(value, current) {
+			selected = i
+		}
+	}
+
+	u.choose(field.Name, values, selected, func(i int) {
+		u.setField(item, field, values[i])
+	})
+}
+
+// setField sets the field value using the backend, then updates the item locally.
+func (u *UI) setField(item models.ProjectItem, field models.ProjectField, value string) {
+	if err := u.backend.SetField(item, field, value); err != nil {
+		u.status = u.con.ColorScheme().Red(err.Error())
+		return
+	}
+
+	for i := range u.project.Items {
+		if u.project.Items[i].ID == item.ID {
+			setValue(&u.project.Items[i], field, value)
+		}
+	}
+
+	if err := u.refresh(); err != nil {
+		u.status = u.con.ColorScheme().Red(err.Error())
+		return
+	}
+
+	u.selectItem(item.ID)
+	u.status = fmt.Sprintf("Set %s to %q for %s", field.Name, value, itemName(item))
+}
+
+// setValue sets the value of a field on the item as it would be returned from the API.
+func setValue(item *models.ProjectItem, field models.ProjectField, value string) {
+	var v *models.ProjectItemFieldValue
+	for i := range item.FieldValues.Nodes {
+		if strings.EqualFold(item.FieldValues.Nodes[i].Field.Name, field.Name) {
+			v = &item.FieldValues.Nodes[i]
+			break
+		}
+	}
+
+	if v == nil {
+		item.FieldValues.Nodes = append(item.FieldValues.Nodes, models.ProjectItemFieldValue{})
+		v = &item.FieldValues.Nodes[len(item.FieldValues.Nodes)-1]
+		v.Field.Name = field.Name
+		v.Field.DataType = field.DataType
+	}
+
+	switch field.DataType {
+	case "SINGLE_SELECT":
+		for _, option := range field.Options {
+			if strings.EqualFold(option.Name, value) {
+				v.Name = option.Name
+			}
+		}
+	case "ITERATION":
+		for _, iteration := range field.Configuration.Iterations {
+			if strings.EqualFold(iteration.Name, value) {
+				v.Title, v.StartDate, v.Duration = iteration.Name, iteration.StartDate, iteration.Duration
+			}
+		}
+	case "NUMBER":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			v.Number = &n
+		}
+	case "DATE":
+		if len(value) > len("2006-01-02") {
+			value = value[:len("2006-01-02")]
+		}
+		v.Date = value
+	default:
+		v.Text = value
+	}
+}
+
+func (u *UI) setFilter(text string) {
+	f, err := filter.Parse(text)
+	if err != nil {
+		u.status = u.con.ColorScheme().Red(err.Error())
+		return
+	}
+
+	u.filter, u.filterText = f, text
+	if err := u.refresh(); err != nil {
+		u.status = u.con.ColorScheme().Red(err.Error())
+	}
+}
+
+func (u *UI) chooseGroupBy() {
+	var fields []*models.ProjectField
+	var names []string
+	selected := 0
+	for i := range u.project.Fields {
+		field := &u.project.Fields[i]
+		if field.DataType == "SINGLE_SELECT" || field.DataType == "ITERATION" {
+			if field == u.groupBy {
+				selected = len(fields)
+			}
+			fields = append(fields, field)
+			names = append(names, field.Name)
+		}
+	}
+
+	u.choose("Group by", names, selected, func(i int) {
+		u.groupBy = fields[i]
+		u.col, u.row, u.colOffset = 0, 0, 0
+		if err := u.refresh(); err != nil {
+			u.status = u.con.ColorScheme().Red(err.Error())
+		}
+	})
+}
+
+func (u *UI) openItem() {
+	item, ok := u.selected()
+	if !ok {
+		return
+	}
+
+	body, err := u.backend.Body(item)
+	if err != nil {
+		u.status = u.con.ColorScheme().Red(err.Error())
+		return
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", item.Content.Title)
+	if item.Content.Number != 0 {
+		fmt.Fprintf(&sb, "**#%d**", item.Content.Number)
+		if item.Content.State != "" {
+			fmt.Fprintf(&sb, " • %s", strings.ToLower(item.Content.State))
+		}
+		if item.Content.Repository != nil {
+			fmt.Fprintf(&sb, " • %s", item.Content.Repository.NameWithOwner)
+		}
+		sb.WriteString("\n\n")
+	}
+
+	for _, value := range item.FieldValues.Nodes {
+		if s := value.String(); s != "" && value.Field.DataType != "TITLE" {
+			fmt.Fprintf(&sb, "- **%s:** %s\n", value.Field.Name, s)
+		}
+	}
+
+	if body != "" {
+		fmt.Fprintf(&sb, "\n---\n\n%s\n", body)
+	}
+
+	u.showDetail(itemName(item), sb.String())
+}
+
+func (u *UI) showDetail(title, markdown string) {
+	style := glamour.WithStandardStyle("notty")
+	if u.con.IsStdoutTTY() {
+		style = glamour.WithAutoStyle()
+	}
+
+	text := markdown
+	if r, err := glamour.NewTermRenderer(style, glamour.WithWordWrap(u.width-4)); err == nil {
+		if rendered, err := r.Render(markdown); err == nil {
+			text = rendered
+		}
+	}
+
+	u.detail = strings.Split(strings.Trim(text, "\n"), "\n")
+	for i, line := range u.detail {
+		u.detail[i] = strings.TrimRight(line, " ")
+	}
+	u.detailOffset = 0
+	if u.mode != modeDetail {
+		u.prevMode = u.mode
+	}
+	u.mode = modeDetail
+}
+
+func (u *UI) showHelp() {
+	u.showDetail("Help", `# Keys
+
+| Key | Action |
+| --- | --- |
+| ←↓↑→ or hjkl | Select an item |
+| < > or shift+←→ | Move the card to another column |
+| enter | Open the item |
+| e | Edit a field of the item |
+| / | Filter items e.g., "status:Todo -label:bug" |
+| g | Group by another field |
+| tab | Switch between board and table |
+| R | Show the project readme |
+| q | Go back or quit |
+`)
+}
+
+func (u *UI) prompt(label, text string, onSubmit func(string)) {
+	u.input = &input{
+		label:    label,
+		text:     []rune(text),
+		onSubmit: onSubmit,
+	}
+}
+
+func (u *UI) choose(label string, options []string, selected int, onChoose func(int)) {
+	u.choices = &choices{
+		label:    label,
+		options:  options,
+		selected: selected,
+		onChoose: onChoose,
+	}
+}
+
+func itemName(item models.ProjectItem) string {
+	if item.Content.Number != 0 {
+		return fmt.Sprintf("#%d", item.Content.Number)
+	}
+	return item.Content.Title
+}
+
+func clamp(v, min, max int) int {
+	if v > max {
+		v = max
+	}
+	if v < min {
+		v = min
+	}
+	return v
+}
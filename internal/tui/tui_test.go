@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/heaths/go-console"
+	"github.com/heaths/go-console/pkg/colorscheme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectJSON = `{
+	"title": "Project",
+	"readme": "Ship it!",
+	"viewer": "heaths",
+	"fields": [
+		{"id": "PNF_Title", "name": "Title", "dataType": "TITLE"},
+		{
+			"id": "PNF_Status",
+			"name": "Status",
+			"dataType": "SINGLE_SELECT",
+			"options": [
+				{"id": "1", "name": "Todo"},
+				{"id": "2", "name": "In Progress"},
+				{"id": "3", "name": "Done"}
+			]
+		},
+		{"id": "PNF_Estimate", "name": "Estimate", "dataType": "NUMBER"}
+	],
+	"items": [
+		{
+			"id": "PNI_1",
+			"type": "ISSUE",
+			"content": {"number": 1, "title": "Fix the parser", "state": "OPEN"},
+			"fieldValues": {
+				"nodes": [
+					{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+					{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+				]
+			}
+		},
+		{
+			"id": "PNI_2",
+			"type": "ISSUE",
+			"content": {"number": 2, "title": "Add a feature", "state": "OPEN"},
+			"fieldValues": {
+				"nodes": [
+					{"name": "In Progress", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}}
+				]
+			}
+		},
+		{
+			"id": "PNI_3",
+			"type": "DRAFT_ISSUE",
+			"content": {"title": "Write docs"},
+			"fieldValues": {
+				"nodes": []
+			}
+		}
+	]
+}`
+
+type fakeBackend struct {
+	set  []string
+	body string
+}
+
+func (b *fakeBackend) SetField(item models.ProjectItem, field models.ProjectField, value string) error {
+	b.set = append(b.set, item.ID+" "+field.Name+"="+value)
+	return nil
+}
+
+func (b *fakeBackend) Body(item models.ProjectItem) (string, error) {
+	return b.body, nil
+}
+
+func run(t *testing.T, keys string, backend *fakeBackend) *UI {
+	t.Helper()
+
+	var project Project
+	require.NoError(t, json.Unmarshal([]byte(projectJSON), &project))
+
+	fake := console.Fake(
+		console.WithStdin(bytes.NewBufferString(keys)),
+		console.WithColorScheme(colorscheme.New(colorscheme.WithTTY(func() bool { return false }))),
+	)
+
+	u, err := New(fake, &project, backend, Options{GroupBy: "status", Width: 80, Height: 12})
+	require.NoError(t, err)
+	require.NoError(t, u.Run())
+
+	return u
+}
+
+func frame(u *UI) string {
+	return strings.Join(u.frame, "\n")
+}
+
+func TestUI_board(t *testing.T) {
+	u := run(t, "", &fakeBackend{})
+	assert.Equal(t, strings.Join([]string{
+		"Project  board by Status • 3 of 3 items",
+		"",
+		"No Status 1                Todo 1                     In Progress 1",
+		"─────────────────────────  ─────────────────────────  ─────────────────────────",
+		"› Write docs                 #1 Fix the parser          #2 Add a feature",
+		"", "", "", "", "", "",
+		"←↓↑→ select • <> move • enter open • e edit • / filter • g group • tab table ...",
+	}, "\n"), frame(u))
+}
+
+func TestUI_moveCard(t *testing.T) {
+	backend := &fakeBackend{}
+
+	// Select #1 in Todo and move it right to In Progress, then right again to Done.
+	u := run(t, "l>>", backend)
+	assert.Equal(t, []string{"PNI_1 Status=In Progress", "PNI_1 Status=Done"}, backend.set)
+
+	item, ok := u.selected()
+	assert.True(t, ok)
+	assert.Equal(t, "PNI_1", item.ID)
+	assert.Contains(t, frame(u), `Set Status to "Done" for #1`)
+
+	// Cannot move to items without a status.
+	u = run(t, "l<", backend)
+	assert.Contains(t, frame(u), `Cannot move to "No Status"`)
+	assert.Len(t, backend.set, 2)
+}
+
+func TestUI_editField(t *testing.T) {
+	backend := &fakeBackend{}
+
+	// Edit the estimate of #1, which is the second editable field.
+	u := run(t, "le2\x7f5\r", backend)
+	assert.Equal(t, []string{"PNI_1 Estimate=5"}, backend.set)
+
+	value, ok := u.project.Items[0].FieldValue("Estimate")
+	assert.True(t, ok)
+	assert.Equal(t, "5", value.String())
+}
+
+func TestUI_filter(t *testing.T) {
+	u := run(t, "/-status:todo\r", &fakeBackend{})
+	assert.Contains(t, u.frame[0], "filter: -status:todo • 2 of 3 items")
+	assert.NotContains(t, frame(u), "Fix the parser")
+
+	// Escape cancels without changing the filter.
+	u = run(t, "/is:open\x1b", &fakeBackend{})
+	assert.Contains(t, u.frame[0], "3 of 3 items")
+}
+
+func TestUI_table(t *testing.T) {
+	u := run(t, "\tj", &fakeBackend{})
+	assert.Equal(t, "  #      Title                      State     Status          Estimate", u.frame[2])
+	assert.Equal(t, "  #1     Fix the parser             open      Todo            3", u.frame[3])
+	assert.Equal(t, "› #2     Add a feature              open      In Progress", u.frame[4])
+}
+
+func TestUI_detail(t *testing.T) {
+	backend := &fakeBackend{body: "The parser fails on empty input."}
+
+	u := run(t, "l\r", backend)
+	assert.Equal(t, modeDetail, u.mode)
+	assert.Contains(t, frame(u), "# Fix the parser")
+	assert.NotContains(t, frame(u), "The parser fails on empty input.")
+
+	// Scroll to the end of the body.
+	u = run(t, "l\r\x1b[F", backend)
+	assert.Contains(t, frame(u), "The parser fails on empty input.")
+
+	// Going back returns to the board.
+	u = run(t, "l\rq", backend)
+	assert.Equal(t, modeBoard, u.mode)
+	assert.False(t, u.quit)
+}
+
+func TestUI_groupBy(t *testing.T) {
+	var project Project
+	require.NoError(t, json.Unmarshal([]byte(projectJSON), &project))
+
+	_, err := New(console.Fake(), &project, &fakeBackend{}, Options{GroupBy: "Missing"})
+	assert.EqualError(t, err, "field not found: Missing")
+
+	_, err = New(console.Fake(), &project, &fakeBackend{}, Options{GroupBy: "Estimate"})
+	assert.EqualError(t, err, `field "Estimate" cannot be used to group items`)
+}
+
+func TestUI_quit(t *testing.T) {
+	u := run(t, "q", &fakeBackend{})
+	assert.True(t, u.quit)
+}
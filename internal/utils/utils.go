@@ -10,6 +10,23 @@ func Ptr[T any](v T) *T {
 	return &v
 }
 
+// Chunk splits values into slices of at most size elements.
+func Chunk[T any](values []T, size int) [][]T {
+	if size < 1 {
+		size = 1
+	}
+
+	chunks := make([][]T, 0, (len(values)+size-1)/size)
+	for size < len(values) {
+		values, chunks = values[size:], append(chunks, values[:size])
+	}
+	if len(values) > 0 {
+		chunks = append(chunks, values)
+	}
+
+	return chunks
+}
+
 func StringSliceContains(value string, values []string) bool {
 	for _, v := range values {
 		if strings.EqualFold(value, v) {
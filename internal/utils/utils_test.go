@@ -8,6 +8,13 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestChunk(t *testing.T) {
+	assert.Equal(t, [][]int{}, Chunk([]int{}, 2))
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}}, Chunk([]int{1, 2, 3, 4}, 2))
+	assert.Equal(t, [][]int{{1, 2}, {3}}, Chunk([]int{1, 2, 3}, 2))
+	assert.Equal(t, [][]int{{1}, {2}}, Chunk([]int{1, 2}, 0))
+}
+
 func TestStringSliceContains(t *testing.T) {
 	assert.True(t, StringSliceContains("b", []string{"a", "b", "c"}))
 	assert.False(t, StringSliceContains("z", []string{"a", "b", "c"}))
@@ -0,0 +1,133 @@
+// Package watch detects changes between snapshots of project items.
+package watch
+
+import (
+	"strings"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+)
+
+// Kind is the kind of change to an item.
+type Kind string
+
+const (
+	KindAdded   Kind = "added"
+	KindRemoved Kind = "removed"
+	KindChanged Kind = "changed"
+)
+
+// StateField is the name used for changes to the state of an issue or pull request.
+const StateField = "State"
+
+// Change is an item added to or removed from a project, or a change to a field value of an item.
+type Change struct {
+	Time   time.Time `json:"time"`
+	Kind   Kind      `json:"kind"`
+	ItemID string    `json:"itemId"`
+	Number int       `json:"number,omitempty"`
+	Title  string    `json:"title"`
+	URL    string    `json:"url,omitempty"`
+	Field  string    `json:"field,omitempty"`
+	From   string    `json:"from,omitempty"`
+	To     string    `json:"to,omitempty"`
+}
+
+// Diff gets the changes from prev to next items. Removed items are returned first,
+// followed by added and changed items in the order of next.
+func Diff(prev, next []models.ProjectItem, now time.Time) []Change {
+	prevItems := make(map[string]*models.ProjectItem, len(prev))
+	for i := range prev {
+		prevItems[prev[i].ID] = &prev[i]
+	}
+
+	nextItems := make(map[string]bool, len(next))
+	for _, item := range next {
+		nextItems[item.ID] = true
+	}
+
+	var changes []Change
+	for _, item := range prev {
+		if !nextItems[item.ID] {
+			changes = append(changes, newChange(now, KindRemoved, item))
+		}
+	}
+
+	for _, item := range next {
+		old, ok := prevItems[item.ID]
+		if !ok {
+			changes = append(changes, newChange(now, KindAdded, item))
+			continue
+		}
+
+		oldValues := fieldValues(*old)
+		newValues := fieldValues(item)
+		for _, name := range fieldNames(*old, item) {
+			if from, to := oldValues[name], newValues[name]; from != to {
+				change := newChange(now, KindChanged, item)
+				change.Field = name
+				change.From = from
+				change.To = to
+				changes = append(changes, change)
+			}
+		}
+	}
+
+	return changes
+}
+
+func newChange(now time.Time, kind Kind, item models.ProjectItem) Change {
+	return Change{
+		Time:   now,
+		Kind:   kind,
+		ItemID: item.ID,
+		Number: item.Content.Number,
+		Title:  item.Content.Title,
+		URL:    item.Content.URL,
+	}
+}
+
+// fieldValues gets the display value of each field, and the state of an issue or pull request.
+func fieldValues(item models.ProjectItem) map[string]string {
+	values := make(map[string]string, len(item.FieldValues.Nodes)+1)
+	for _, value := range item.FieldValues.Nodes {
+		values[value.Field.Name] = value.String()
+	}
+	if item.Content.State != "" {
+		values[StateField] = strings.ToLower(item.Content.State)
+	}
+	return values
+}
+
+// fieldNames gets the names of fields of the new item followed by any only the old item had, and the state last.
+func fieldNames(old, item models.ProjectItem) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, it := range []models.ProjectItem{item, old} {
+		for _, value := range it.FieldValues.Nodes {
+			if name := value.Field.Name; !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	return append(names, StateField)
+}
+
+// Backoff gets how long to wait between polls, doubling from Min up to Max while nothing changes.
+type Backoff struct {
+	Min time.Duration
+	Max time.Duration
+
+	current time.Duration
+}
+
+// Next gets how long to wait before the next poll, resetting to Min if changed.
+func (b *Backoff) Next(changed bool) time.Duration {
+	if changed || b.current == 0 {
+		b.current = b.Min
+	} else if b.current *= 2; b.current > b.Max {
+		b.current = b.Max
+	}
+	return b.current
+}
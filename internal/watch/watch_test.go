@@ -0,0 +1,84 @@
+package watch
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/heaths/gh-projects/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const prevItemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "OPEN"},
+		"fieldValues": {"nodes": [
+			{"text": "Fix the parser", "field": {"name": "Title", "dataType": "TITLE"}},
+			{"name": "Todo", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+			{"number": 3, "field": {"name": "Estimate", "dataType": "NUMBER"}}
+		]}
+	},
+	{
+		"id": "PNI_2",
+		"type": "DRAFT_ISSUE",
+		"content": {"id": "DI_2", "title": "Someday"},
+		"fieldValues": {"nodes": [
+			{"text": "Someday", "field": {"name": "Title", "dataType": "TITLE"}}
+		]}
+	}
+]`
+
+const nextItemsJSON = `[
+	{
+		"id": "PNI_1",
+		"type": "ISSUE",
+		"content": {"id": "I_1", "number": 1, "title": "Fix the parser", "state": "CLOSED"},
+		"fieldValues": {"nodes": [
+			{"text": "Fix the parser", "field": {"name": "Title", "dataType": "TITLE"}},
+			{"name": "Done", "field": {"name": "Status", "dataType": "SINGLE_SELECT"}},
+			{"title": "Iteration 1", "field": {"name": "Iteration", "dataType": "ITERATION"}}
+		]}
+	},
+	{
+		"id": "PNI_3",
+		"type": "PULL_REQUEST",
+		"content": {"id": "PR_3", "number": 3, "title": "Add a feature", "url": "https://github.com/heaths/gh-projects/pull/3", "state": "OPEN"},
+		"fieldValues": {"nodes": [
+			{"text": "Add a feature", "field": {"name": "Title", "dataType": "TITLE"}}
+		]}
+	}
+]`
+
+func TestDiff(t *testing.T) {
+	var prev, next []models.ProjectItem
+	require.NoError(t, json.Unmarshal([]byte(prevItemsJSON), &prev))
+	require.NoError(t, json.Unmarshal([]byte(nextItemsJSON), &next))
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	changes := Diff(prev, next, now)
+
+	assert.Equal(t, []Change{
+		{Time: now, Kind: KindRemoved, ItemID: "PNI_2", Title: "Someday"},
+		{Time: now, Kind: KindChanged, ItemID: "PNI_1", Number: 1, Title: "Fix the parser", Field: "Status", From: "Todo", To: "Done"},
+		{Time: now, Kind: KindChanged, ItemID: "PNI_1", Number: 1, Title: "Fix the parser", Field: "Iteration", To: "Iteration 1"},
+		{Time: now, Kind: KindChanged, ItemID: "PNI_1", Number: 1, Title: "Fix the parser", Field: "Estimate", From: "3"},
+		{Time: now, Kind: KindChanged, ItemID: "PNI_1", Number: 1, Title: "Fix the parser", Field: "State", From: "open", To: "closed"},
+		{Time: now, Kind: KindAdded, ItemID: "PNI_3", Number: 3, Title: "Add a feature", URL: "https://github.com/heaths/gh-projects/pull/3"},
+	}, changes)
+
+	assert.Empty(t, Diff(next, next, now))
+}
+
+func TestBackoff(t *testing.T) {
+	b := Backoff{Min: 30 * time.Second, Max: 2 * time.Minute}
+
+	assert.Equal(t, 30*time.Second, b.Next(false))
+	assert.Equal(t, time.Minute, b.Next(false))
+	assert.Equal(t, 2*time.Minute, b.Next(false))
+	assert.Equal(t, 2*time.Minute, b.Next(false))
+	assert.Equal(t, 30*time.Second, b.Next(true))
+	assert.Equal(t, time.Minute, b.Next(false))
+}
@@ -0,0 +1,58 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Logger writes structured logs as JSON lines.
+type Logger struct {
+	mu  sync.Mutex
+	w   io.Writer
+	now func() time.Time
+}
+
+// NewLogger creates a Logger that writes to w.
+func NewLogger(w io.Writer) *Logger {
+	return &Logger{
+		w:   w,
+		now: time.Now,
+	}
+}
+
+// Info logs a message with alternating keys and values.
+func (l *Logger) Info(msg string, fields ...interface{}) {
+	l.log("info", msg, fields)
+}
+
+// Error logs an error message with alternating keys and values.
+func (l *Logger) Error(msg string, fields ...interface{}) {
+	l.log("error", msg, fields)
+}
+
+func (l *Logger) log(level, msg string, fields []interface{}) {
+	if l == nil {
+		return
+	}
+
+	entry := map[string]interface{}{
+		"time":  l.now().UTC().Format(time.RFC3339),
+		"level": level,
+		"msg":   msg,
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		entry[fmt.Sprint(fields[i])] = fields[i+1]
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(append(b, '\n'))
+}
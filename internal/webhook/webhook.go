@@ -0,0 +1,208 @@
+// Package webhook receives GitHub webhook events for issues, pull requests, and project items.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// The maximum size of a webhook payload GitHub will send.
+const maxPayloadSize = 25 << 20
+
+// Event is a webhook event for an issue, pull request, or project item.
+type Event struct {
+	// Name is the name of the event e.g., "issues", "pull_request", or "projects_v2_item".
+	Name string
+
+	// Action is the action that triggered the event e.g., "opened" or "edited".
+	Action string
+
+	// Delivery is the unique ID of the delivery.
+	Delivery string
+
+	// ContentID is the node ID of the issue or pull request.
+	ContentID string
+
+	// Number is the number of the issue or pull request.
+	Number int
+
+	// Repository is the name with owner of the repository for issue and pull request events.
+	Repository string
+
+	// ItemID is the node ID of the project item for project item events.
+	ItemID string
+
+	// ProjectID is the node ID of the project for project item events.
+	ProjectID string
+}
+
+// Handler serves webhook events on any path, and health checks on /healthz.
+type Handler struct {
+	// Secret validates the X-Hub-Signature-256 header, if not empty.
+	Secret []byte
+
+	// Handle is called for supported events.
+	Handle func(*Event) error
+
+	// Log writes structured logs.
+	Log *Logger
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/healthz" {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := r.Header.Get("X-GitHub-Event")
+	delivery := r.Header.Get("X-GitHub-Delivery")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		h.Log.Error("failed to read payload", "event", name, "delivery", delivery, "error", err.Error())
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	if len(h.Secret) > 0 && !Verify(h.Secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		h.Log.Error("invalid signature", "event", name, "delivery", delivery)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	if name == "ping" {
+		h.Log.Info("ping", "delivery", delivery)
+		fmt.Fprintln(w, "pong")
+		return
+	}
+
+	event, err := Parse(name, body)
+	if err != nil {
+		h.Log.Error("invalid payload", "event", name, "delivery", delivery, "error", err.Error())
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if event == nil {
+		h.Log.Info("ignored event", "event", name, "delivery", delivery)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	event.Delivery = delivery
+	h.Log.Info("received event", event.fields()...)
+
+	if err := h.Handle(event); err != nil {
+		h.Log.Error("failed to handle event", append(event.fields(), "error", err.Error())...)
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintln(w, "ok")
+}
+
+func (e *Event) fields() []interface{} {
+	fields := []interface{}{"event", e.Name, "action", e.Action, "delivery", e.Delivery}
+	if e.ContentID != "" {
+		fields = append(fields, "content", e.ContentID)
+	}
+	if e.Number != 0 {
+		fields = append(fields, "number", e.Number)
+	}
+	if e.Repository != "" {
+		fields = append(fields, "repository", e.Repository)
+	}
+	if e.ItemID != "" {
+		fields = append(fields, "item", e.ItemID)
+	}
+	return fields
+}
+
+// Verify gets whether the signature e.g., "sha256=..." is the HMAC SHA-256 of the body using the secret.
+func Verify(secret, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, "sha256=") {
+		return false
+	}
+
+	want, err := hex.DecodeString(strings.TrimPrefix(signature, "sha256="))
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), want)
+}
+
+// Parse parses a payload for supported events, or returns nil for unsupported events.
+func Parse(name string, body []byte) (*Event, error) {
+	var payload struct {
+		Action string
+		Issue  *struct {
+			NodeID string `json:"node_id"`
+			Number int
+		}
+		PullRequest *struct {
+			NodeID string `json:"node_id"`
+			Number int
+		} `json:"pull_request"`
+		ProjectsV2Item *struct {
+			NodeID        string `json:"node_id"`
+			ProjectNodeID string `json:"project_node_id"`
+			ContentNodeID string `json:"content_node_id"`
+		} `json:"projects_v2_item"`
+		Repository *struct {
+			FullName string `json:"full_name"`
+		}
+	}
+
+	switch name {
+	case "issues", "pull_request", "projects_v2_item":
+	default:
+		return nil, nil
+	}
+
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+
+	event := &Event{
+		Name:   name,
+		Action: payload.Action,
+	}
+	if payload.Repository != nil {
+		event.Repository = payload.Repository.FullName
+	}
+
+	switch {
+	case name == "issues" && payload.Issue != nil:
+		event.ContentID = payload.Issue.NodeID
+		event.Number = payload.Issue.Number
+	case name == "pull_request" && payload.PullRequest != nil:
+		event.ContentID = payload.PullRequest.NodeID
+		event.Number = payload.PullRequest.Number
+	case name == "projects_v2_item" && payload.ProjectsV2Item != nil:
+		event.ContentID = payload.ProjectsV2Item.ContentNodeID
+		event.ItemID = payload.ProjectsV2Item.NodeID
+		event.ProjectID = payload.ProjectsV2Item.ProjectNodeID
+	default:
+		return nil, fmt.Errorf("missing %s in payload", strings.TrimSuffix(name, "s"))
+	}
+
+	return event, nil
+}
@@ -0,0 +1,282 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		fixture string
+		body    string
+		want    *Event
+		wantErr string
+	}{
+		{
+			name:    "issues",
+			event:   "issues",
+			fixture: "issues_opened.json",
+			want: &Event{
+				Name:       "issues",
+				Action:     "opened",
+				ContentID:  "I_1",
+				Number:     1,
+				Repository: "heaths/gh-projects",
+			},
+		},
+		{
+			name:    "pull request",
+			event:   "pull_request",
+			fixture: "pull_request_closed.json",
+			want: &Event{
+				Name:       "pull_request",
+				Action:     "closed",
+				ContentID:  "PR_2",
+				Number:     2,
+				Repository: "heaths/gh-projects",
+			},
+		},
+		{
+			name:    "project item",
+			event:   "projects_v2_item",
+			fixture: "projects_v2_item_edited.json",
+			want: &Event{
+				Name:      "projects_v2_item",
+				Action:    "edited",
+				ContentID: "PR_2",
+				ItemID:    "PNI_2",
+				ProjectID: "PN_1",
+			},
+		},
+		{
+			name:    "unsupported",
+			event:   "push",
+			fixture: "ping.json",
+		},
+		{
+			name:    "mismatched",
+			event:   "pull_request",
+			fixture: "issues_opened.json",
+			wantErr: "missing pull_request in payload",
+		},
+		{
+			name:    "invalid",
+			event:   "issues",
+			body:    "{",
+			wantErr: "unexpected end of JSON input",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := []byte(tt.body)
+			if tt.fixture != "" {
+				body = readFixture(t, tt.fixture)
+			}
+
+			got, err := Parse(tt.event, body)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	secret := []byte("secret")
+	body := []byte(`{"action":"opened"}`)
+
+	assert.True(t, Verify(secret, body, sign(secret, body)))
+	assert.False(t, Verify(secret, body, sign([]byte("wrong"), body)))
+	assert.False(t, Verify(secret, body, strings.TrimPrefix(sign(secret, body), "sha256=")))
+	assert.False(t, Verify(secret, body, "sha256=zz"))
+	assert.False(t, Verify(secret, body, ""))
+}
+
+func TestHandler(t *testing.T) {
+	secret := []byte("secret")
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		event      string
+		fixture    string
+		signature  string
+		handleErr  error
+		wantStatus int
+		wantBody   string
+		wantEvent  *Event
+		wantLog    string
+	}{
+		{
+			name:       "healthz",
+			method:     http.MethodGet,
+			path:       "/healthz",
+			wantStatus: http.StatusOK,
+			wantBody:   "ok\n",
+		},
+		{
+			name:       "healthz post",
+			method:     http.MethodPost,
+			path:       "/healthz",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "method not allowed\n",
+		},
+		{
+			name:       "get",
+			method:     http.MethodGet,
+			path:       "/",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantBody:   "method not allowed\n",
+		},
+		{
+			name:       "invalid signature",
+			event:      "issues",
+			fixture:    "issues_opened.json",
+			signature:  "sha256=00",
+			wantStatus: http.StatusUnauthorized,
+			wantBody:   "invalid signature\n",
+			wantLog:    `{"delivery":"1","event":"issues","level":"error","msg":"invalid signature","time":"2022-10-04T12:00:00Z"}` + "\n",
+		},
+		{
+			name:       "ping",
+			event:      "ping",
+			fixture:    "ping.json",
+			wantStatus: http.StatusOK,
+			wantBody:   "pong\n",
+		},
+		{
+			name:       "ignored",
+			event:      "push",
+			fixture:    "ping.json",
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "issues",
+			event:      "issues",
+			fixture:    "issues_opened.json",
+			wantStatus: http.StatusOK,
+			wantBody:   "ok\n",
+			wantEvent: &Event{
+				Name:       "issues",
+				Action:     "opened",
+				Delivery:   "1",
+				ContentID:  "I_1",
+				Number:     1,
+				Repository: "heaths/gh-projects",
+			},
+			wantLog: `{"action":"opened","content":"I_1","delivery":"1","event":"issues","level":"info","msg":"received event","number":1,"repository":"heaths/gh-projects","time":"2022-10-04T12:00:00Z"}` + "\n",
+		},
+		{
+			name:       "handler error",
+			event:      "projects_v2_item",
+			fixture:    "projects_v2_item_edited.json",
+			handleErr:  errors.New("failed"),
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   "failed to handle event\n",
+			wantEvent: &Event{
+				Name:      "projects_v2_item",
+				Action:    "edited",
+				Delivery:  "1",
+				ContentID: "PR_2",
+				ItemID:    "PNI_2",
+				ProjectID: "PN_1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.fixture != "" {
+				body = readFixture(t, tt.fixture)
+			}
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			path := tt.path
+			if path == "" {
+				path = "/"
+			}
+
+			req := httptest.NewRequest(method, path, bytes.NewReader(body))
+			if tt.event != "" {
+				req.Header.Set("X-GitHub-Event", tt.event)
+				req.Header.Set("X-GitHub-Delivery", "1")
+
+				signature := tt.signature
+				if signature == "" {
+					signature = sign(secret, body)
+				}
+				req.Header.Set("X-Hub-Signature-256", signature)
+			}
+
+			var log bytes.Buffer
+			var got *Event
+			h := &Handler{
+				Secret: secret,
+				Handle: func(e *Event) error {
+					got = e
+					return tt.handleErr
+				},
+				Log: &Logger{
+					w:   &log,
+					now: func() time.Time { return time.Date(2022, 10, 4, 12, 0, 0, 0, time.UTC) },
+				},
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+			assert.Equal(t, tt.wantEvent, got)
+			if tt.wantLog != "" {
+				assert.Contains(t, log.String(), tt.wantLog)
+			}
+		})
+	}
+}
+
+func TestLogger_nil(t *testing.T) {
+	var log *Logger
+	assert.NotPanics(t, func() {
+		log.Info("message", "key", "value")
+	})
+}
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return body
+}
+
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cli/go-gh"
 	"github.com/cli/go-gh/pkg/auth"
 	"github.com/cli/go-gh/pkg/repository"
+	"github.com/heaths/gh-projects/internal/cache"
 	"github.com/heaths/gh-projects/internal/cmd"
 	"github.com/heaths/gh-projects/internal/logger"
 	"github.com/heaths/gh-projects/internal/utils"
@@ -22,6 +23,7 @@ var (
 
 func main() {
 	var repoFlag string
+	var noCache bool
 	opts := &cmd.GlobalOptions{
 		Console: console.System(),
 	}
@@ -34,11 +36,25 @@ func main() {
 
 		Both current and beta projects are supported.
 		`),
-		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+		PersistentPreRunE: func(c *cobra.Command, args []string) (err error) {
+			// Settings are only required to be valid for commands other than config and alias.
+			if err = opts.ConfigError(); err != nil {
+				return
+			}
+
 			if opts.Verbose {
 				opts.Log = logger.New(opts.Console, "black+h")
 			}
 
+			if !noCache {
+				opts.Cache = cache.New(cache.DefaultDir())
+			}
+
+			// Flags are not parsed before requesting completions, which resolve the repository when needed.
+			if c.Name() == cobra.ShellCompRequestCmd {
+				return
+			}
+
 			// Try to get the host from the specified repo.
 			var repo repository.Repository
 			if repoFlag != "" {
@@ -48,9 +64,19 @@ func main() {
 				}
 			}
 
-			// Validate that the user is authenticated.
-			var host string
-			if repo != nil {
+			// Otherwise, use the configured default owner outside a repository unless GH_REPO is set.
+			if repo == nil && opts.Config.Owner != "" && os.Getenv("GH_REPO") == "" {
+				if repo, _ = gh.CurrentRepository(); repo == nil {
+					repo, err = cmd.OwnerRepository(opts.Config.Owner)
+					if err != nil {
+						return
+					}
+				}
+			}
+
+			// Validate that the user is authenticated, preferring the host of a project URL or alias.
+			host, projectOwner := opts.ProjectOwner()
+			if host == "" && repo != nil {
 				host = repo.Host()
 			}
 			if host == "" {
@@ -62,15 +88,16 @@ func main() {
 			}
 
 			// If the repo is still unassigned, try to use the current repository.
+			// A project URL or alias sets the owner, so the current repository is not required.
 			if repo == nil {
 				repo, err = gh.CurrentRepository()
-				if err != nil {
+				if err != nil && projectOwner == "" {
 					return
 				}
 			}
 
 			opts.Repo = repo
-			return
+			return cmd.ResolveProjectArg(opts)
 		},
 		SilenceErrors: true,
 		SilenceUsage:  true,
@@ -78,13 +105,31 @@ func main() {
 
 	rootCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Select another repository to use using the [HOST/]OWNER/REPO format.")
 	rootCmd.PersistentFlags().BoolVarP(&opts.Verbose, "verbose", "v", false, "Show verbose output.")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Do not use or update cached project metadata.")
 
+	rootCmd.AddCommand(cmd.NewAliasCmd(opts))
+	rootCmd.AddCommand(cmd.NewAutomateCmd(opts))
+	rootCmd.AddCommand(cmd.NewCacheCmd(opts))
 	rootCmd.AddCommand(cmd.NewCloneCmd(opts, nil))
+	rootCmd.AddCommand(cmd.NewConfigCmd(opts))
 	rootCmd.AddCommand(cmd.NewEditCmd(opts, nil))
+	rootCmd.AddCommand(cmd.NewItemCmd(opts))
 	rootCmd.AddCommand(cmd.NewListCmd(opts))
+	rootCmd.AddCommand(cmd.NewReportCmd(opts))
+	rootCmd.AddCommand(cmd.NewServeCmd(opts))
+	rootCmd.AddCommand(cmd.NewStatusUpdateCmd(opts))
+	rootCmd.AddCommand(cmd.NewSyncStatusCmd(opts))
+	rootCmd.AddCommand(cmd.NewTUICmd(opts))
 	rootCmd.AddCommand(cmd.NewViewCmd(opts))
+	rootCmd.AddCommand(cmd.NewViewLayoutCmd(opts))
+
+	// Load settings before arguments are parsed, since they may provide a default project.
+	// Errors are returned when commands run, so config and alias can still change invalid settings.
+	_ = cmd.LoadConfig(opts)
+
+	err := rootCmd.Execute()
 
-	if err := rootCmd.Execute(); err != nil {
+	if err != nil {
 		if utils.AsGQLError(err, "INSUFFICIENT_SCOPES") != nil {
 			err = errInsufficientScopes
 		}